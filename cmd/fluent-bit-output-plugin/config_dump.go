@@ -30,6 +30,15 @@ func dumpConfiguration(conf *config.Config) {
 	logger.V(1).Info("[flb-go]", "TagKey", fmt.Sprintf("%+v", conf.PluginConfig.KubernetesMetadata.TagKey))
 	logger.V(1).Info("[flb-go]", "TagPrefix", fmt.Sprintf("%+v", conf.PluginConfig.KubernetesMetadata.TagPrefix))
 	logger.V(1).Info("[flb-go]", "Origin", fmt.Sprintf("%+v", conf.PluginConfig.Origin))
+	// Processor chain and multiline configuration
+	logger.V(1).Info("[flb-go]", "Processors", fmt.Sprintf("%+v", conf.PluginConfig.Processors))
+	if conf.PluginConfig.Multiline.Enabled() {
+		logger.V(1).Info("[flb-go]", "MultilineRules", fmt.Sprintf("%+v", conf.PluginConfig.Multiline.Rules))
+		logger.V(1).Info("[flb-go]", "MultilineFlushTimeout", fmt.Sprintf("%+v", conf.PluginConfig.Multiline.FlushTimeout))
+		logger.V(1).Info("[flb-go]", "MultilineMaxLines", fmt.Sprintf("%+v", conf.PluginConfig.Multiline.MaxLines))
+		logger.V(1).Info("[flb-go]", "MultilineMaxBytes", fmt.Sprintf("%+v", conf.PluginConfig.Multiline.MaxBytes))
+		logger.V(1).Info("[flb-go]", "MultilineMaxStreams", fmt.Sprintf("%+v", conf.PluginConfig.Multiline.MaxStreams))
+	}
 	logger.V(1).Info("")
 	logger.V(1).Info("[flb-go] =====   Controller Config   =====")
 	logger.V(1).Info("[flb-go]", "ControllerSyncTimeout", fmt.Sprintf("%+v", conf.ControllerConfig.CtlSyncTimeout.String()))
@@ -37,6 +46,10 @@ func dumpConfiguration(conf *config.Config) {
 	logger.V(1).Info("[flb-go]", "DynamicHostPrefix", fmt.Sprintf("%+v", conf.ControllerConfig.DynamicHostPrefix))
 	logger.V(1).Info("[flb-go]", "DynamicHostSuffix", fmt.Sprintf("%+v", conf.ControllerConfig.DynamicHostSuffix))
 	logger.V(1).Info("[flb-go]", "DynamicHostRegex", fmt.Sprintf("%+v", conf.ControllerConfig.DynamicHostRegex))
+	logger.V(1).Info("[flb-go]", "TenantRequestsPerSec", fmt.Sprintf("%+v", conf.ControllerConfig.TenantRequestsPerSec))
+	logger.V(1).Info("[flb-go]", "TenantBytesPerSec", fmt.Sprintf("%+v", conf.ControllerConfig.TenantBytesPerSec))
+	logger.V(1).Info("[flb-go]", "TenantFairShareRequestsPerSec", fmt.Sprintf("%+v", conf.ControllerConfig.TenantFairShareRequestsPerSec))
+	logger.V(1).Info("[flb-go]", "TenantFairShareBytesPerSec", fmt.Sprintf("%+v", conf.ControllerConfig.TenantFairShareBytesPerSec))
 	logger.V(1).Info("[flb-go]", "SendLogsToShootWhenIsInCreationState", fmt.Sprintf("%+v", conf.ControllerConfig.ShootControllerClientConfig.SendLogsWhenIsInCreationState))
 	logger.V(1).Info("[flb-go]", "SendLogsToShootWhenIsInReadyState", fmt.Sprintf("%+v", conf.ControllerConfig.ShootControllerClientConfig.SendLogsWhenIsInReadyState))
 	logger.V(1).Info("[flb-go]", "SendLogsToShootWhenIsInHibernatingState", fmt.Sprintf("%+v", conf.ControllerConfig.ShootControllerClientConfig.SendLogsWhenIsInHibernatingState))
@@ -62,6 +75,8 @@ func dumpConfiguration(conf *config.Config) {
 	logger.V(1).Info("[flb-go]", "DQueName", fmt.Sprintf("%+v", conf.OTLPConfig.DQueConfig.DQueName)) // DQue Batch Processor configuration
 	logger.V(1).Info("[flb-go]", "DQueBatchProcessorMaxQueueSize", fmt.Sprintf("%+v", conf.OTLPConfig.DQueBatchProcessorMaxQueueSize))
 	logger.V(1).Info("[flb-go]", "DQueBatchProcessorMaxBatchSize", fmt.Sprintf("%+v", conf.OTLPConfig.DQueBatchProcessorMaxBatchSize))
+	logger.V(1).Info("[flb-go]", "DQueBatchProcessorMaxBatchBytes", fmt.Sprintf("%+v", conf.OTLPConfig.DQueBatchProcessorMaxBatchBytes))
+	logger.V(1).Info("[flb-go]", "DQueBatchProcessorDeadLetterMaxBytes", fmt.Sprintf("%+v", conf.OTLPConfig.DQueBatchProcessorDeadLetterMaxBytes))
 	logger.V(1).Info("[flb-go]", "DQueBatchProcessorExportTimeout", fmt.Sprintf("%+v", conf.OTLPConfig.DQueBatchProcessorExportTimeout))
 	logger.V(1).Info("[flb-go]", "DQueBatchProcessorExportInterval", fmt.Sprintf("%+v", conf.OTLPConfig.DQueBatchProcessorExportInterval))
 	logger.V(1).Info("[flb-go]", "DQueBatchProcessorExportBufferSize", fmt.Sprintf("%+v", conf.OTLPConfig.DQueBatchProcessorExportBufferSize))
@@ -78,11 +93,40 @@ func dumpConfiguration(conf *config.Config) {
 
 	logger.V(1).Info("[flb-go]", "Insecure", fmt.Sprintf("%+v", conf.OTLPConfig.Insecure))
 	logger.V(1).Info("[flb-go]", "Compression", fmt.Sprintf("%+v", conf.OTLPConfig.Compression))
+	logger.V(1).Info("[flb-go]", "Encoding", fmt.Sprintf("%+v", conf.OTLPConfig.Encoding))
 	logger.V(1).Info("[flb-go]", "Timeout", fmt.Sprintf("%+v", conf.OTLPConfig.Timeout))
 
+	// OTLP log record configuration
+	logger.V(1).Info("[flb-go]", "StructuredBody", fmt.Sprintf("%+v", conf.OTLPConfig.StructuredBody))
+	logger.V(1).Info("[flb-go]", "MaxBodySize", fmt.Sprintf("%+v", conf.OTLPConfig.MaxBodySize))
+	logger.V(1).Info("[flb-go]", "MaxStructuredBodySize", fmt.Sprintf("%+v", conf.OTLPConfig.MaxStructuredBodySize))
+	logger.V(1).Info("[flb-go]", "ResourceGrouping", fmt.Sprintf("%+v", conf.OTLPConfig.ResourceGrouping))
+	logger.V(1).Info("[flb-go]", "TraceContextEnabled", fmt.Sprintf("%+v", conf.OTLPConfig.TraceContextEnabled))
+	if conf.OTLPConfig.TraceContextEnabled {
+		logger.V(1).Info("[flb-go]", "TraceIDField", fmt.Sprintf("%+v", conf.OTLPConfig.TraceIDField))
+		logger.V(1).Info("[flb-go]", "SpanIDField", fmt.Sprintf("%+v", conf.OTLPConfig.SpanIDField))
+		logger.V(1).Info("[flb-go]", "TraceFlagsField", fmt.Sprintf("%+v", conf.OTLPConfig.TraceFlagsField))
+		logger.V(1).Info("[flb-go]", "TraceParentField", fmt.Sprintf("%+v", conf.OTLPConfig.TraceParentField))
+		logger.V(1).Info("[flb-go]", "TraceContextObjectField", fmt.Sprintf("%+v", conf.OTLPConfig.TraceContextObjectField))
+	}
+	logger.V(1).Info("[flb-go]", "K8sPodLabelsAllowList", fmt.Sprintf("%+v", conf.OTLPConfig.KubernetesAttributes.PodLabels.Allow))
+	logger.V(1).Info("[flb-go]", "K8sPodLabelsDenyList", fmt.Sprintf("%+v", conf.OTLPConfig.KubernetesAttributes.PodLabels.Deny))
+	logger.V(1).Info("[flb-go]", "K8sPodAnnotationsAllowList", fmt.Sprintf("%+v", conf.OTLPConfig.KubernetesAttributes.PodAnnotations.Allow))
+	logger.V(1).Info("[flb-go]", "K8sPodAnnotationsDenyList", fmt.Sprintf("%+v", conf.OTLPConfig.KubernetesAttributes.PodAnnotations.Deny))
+	logger.V(1).Info("[flb-go]", "K8sWorkloadAttributes", fmt.Sprintf("%+v", conf.OTLPConfig.KubernetesAttributes.WorkloadAttributes))
+	// Severity rules, including the rules of the SeverityPresets
+	logger.V(1).Info("[flb-go]", "SeverityPresets", fmt.Sprintf("%+v", conf.OTLPConfig.SeverityConfig.Presets))
+	logger.V(1).Info("[flb-go]", "SeverityFields", fmt.Sprintf("%+v", conf.OTLPConfig.SeverityConfig.Fields))
+	logger.V(1).Info("[flb-go]", "SeverityBodyRegex", fmt.Sprintf("%+v", conf.OTLPConfig.SeverityConfig.BodyPatterns))
+	logger.V(1).Info("[flb-go]", "SeverityValues", fmt.Sprintf("%+v", conf.OTLPConfig.SeverityConfig.Values))
+	logger.V(1).Info("[flb-go]", "SeverityNumericValues", fmt.Sprintf("%+v", conf.OTLPConfig.SeverityConfig.NumericValues))
+
 	if len(conf.OTLPConfig.Headers) > 0 {
 		logger.V(1).Info("[flb-go]", "Headers", fmt.Sprintf("%+v", conf.OTLPConfig.Headers))
 	}
+	if conf.OTLPConfig.HeaderFiles != nil {
+		logger.V(1).Info("[flb-go]", "HeaderFiles", "configured")
+	}
 	// OTLP Client Retry configuration
 	logger.V(1).Info("[flb-go]", "RetryEnabled", fmt.Sprintf("%+v", conf.OTLPConfig.RetryEnabled))
 	logger.V(1).Info("[flb-go]", "RetryInitialInterval", fmt.Sprintf("%+v", conf.OTLPConfig.RetryInitialInterval))
@@ -92,9 +136,39 @@ func dumpConfiguration(conf *config.Config) {
 		logger.V(1).Info("[flb-go]", "RetryConfig", "configured")
 	}
 
+	// OTLP Client Failover configuration
+	if len(conf.OTLPConfig.FailoverEndpoints) > 0 {
+		logger.V(1).Info("[flb-go]", "FailoverEndpoints", fmt.Sprintf("%+v", conf.OTLPConfig.FailoverEndpoints))
+		logger.V(1).Info("[flb-go]", "FailoverFailureThreshold", fmt.Sprintf("%+v", conf.OTLPConfig.FailoverFailureThreshold))
+		logger.V(1).Info("[flb-go]", "FailoverCooldown", fmt.Sprintf("%+v", conf.OTLPConfig.FailoverCooldown))
+	}
+
+	// OTLP Client Authentication configuration, the secrets are not logged
+	logger.V(1).Info("[flb-go]", "Authenticator", fmt.Sprintf("%+v", conf.OTLPConfig.Authenticator))
+	//nolint:revive // enforce-switch-style: default-case is omitted on purpose, none has no settings
+	switch conf.OTLPConfig.Authenticator {
+	case config.AuthenticatorOAuth2:
+		logger.V(1).Info("[flb-go]", "OAuth2TokenURL", fmt.Sprintf("%+v", conf.OTLPConfig.OAuth2TokenURL))
+		logger.V(1).Info("[flb-go]", "OAuth2ClientID", fmt.Sprintf("%+v", conf.OTLPConfig.OAuth2ClientID))
+		logger.V(1).Info("[flb-go]", "OAuth2ClientSecretFile", fmt.Sprintf("%+v", conf.OTLPConfig.OAuth2ClientSecretFile))
+		logger.V(1).Info("[flb-go]", "OAuth2Scopes", fmt.Sprintf("%+v", conf.OTLPConfig.OAuth2Scopes))
+	case config.AuthenticatorKubernetes:
+		logger.V(1).Info("[flb-go]", "KubernetesTokenFile", fmt.Sprintf("%+v", conf.OTLPConfig.KubernetesTokenFile))
+	case config.AuthenticatorBasic:
+		logger.V(1).Info("[flb-go]", "BasicAuthUsername", fmt.Sprintf("%+v", conf.OTLPConfig.BasicAuthUsername))
+		logger.V(1).Info("[flb-go]", "BasicAuthPasswordFile", fmt.Sprintf("%+v", conf.OTLPConfig.BasicAuthPasswordFile))
+	}
+
 	// Throttle configuration
 	logger.V(1).Info("[flb-go]", "ThrottleEnabled", fmt.Sprintf("%+v", conf.OTLPConfig.ThrottleEnabled))
 	logger.V(1).Info("[flb-go]", "ThrottlePeriod", fmt.Sprintf("%+v", conf.OTLPConfig.ThrottleRequestsPerSec))
+	logger.V(1).Info("[flb-go]", "ThrottleBytesPerSec", fmt.Sprintf("%+v", conf.OTLPConfig.ThrottleBytesPerSec))
+	logger.V(1).Info("[flb-go]", "ThrottleAdaptive", fmt.Sprintf("%+v", conf.OTLPConfig.ThrottleAdaptive))
+	if conf.OTLPConfig.ThrottleAdaptive {
+		logger.V(1).Info("[flb-go]", "ThrottleAdaptiveMinPercent", fmt.Sprintf("%+v", conf.OTLPConfig.ThrottleAdaptiveMinPercent))
+		logger.V(1).Info("[flb-go]", "ThrottleAdaptiveIncreasePercent", fmt.Sprintf("%+v", conf.OTLPConfig.ThrottleAdaptiveIncreasePercent))
+		logger.V(1).Info("[flb-go]", "ThrottleAdaptiveInterval", fmt.Sprintf("%+v", conf.OTLPConfig.ThrottleAdaptiveInterval))
+	}
 
 	// OTLP TLS configuration
 	logger.V(1).Info("[flb-go]", "TLSCertFile", fmt.Sprintf("%+v", conf.OTLPConfig.TLSCertFile))
@@ -107,4 +181,68 @@ func dumpConfiguration(conf *config.Config) {
 	if conf.OTLPConfig.TLSConfig != nil {
 		logger.V(1).Info("[flb-go]", "TLSConfig", "configured")
 	}
+
+	// OTLP HTTP proxy configuration
+	if conf.OTLPConfig.HTTPProxyURL != nil {
+		logger.V(1).Info("[flb-go]", "HTTPProxy", conf.OTLPConfig.HTTPProxyURL.Redacted())
+	}
+	logger.V(1).Info("[flb-go]", "HTTPNoProxy", fmt.Sprintf("%+v", conf.OTLPConfig.HTTPNoProxy))
+	logger.V(1).Info("[flb-go]", "HTTPProxyTLSCAFile", fmt.Sprintf("%+v", conf.OTLPConfig.HTTPProxyTLSCAFile))
+	logger.V(1).Info("[flb-go]", "HTTPProxyTLSServerName", fmt.Sprintf("%+v", conf.OTLPConfig.HTTPProxyTLSServerName))
+	logger.V(1).Info("[flb-go]", "HTTPProxyTLSInsecureSkipVerify", fmt.Sprintf("%+v", conf.OTLPConfig.HTTPProxyTLSInsecureSkipVerify))
+
+	logger.V(1).Info("")
+	logger.V(1).Info("[flb-go] =====   Output Client Config   =====")
+	// File client configuration
+	logger.V(1).Info("[flb-go]", "FilePath", fmt.Sprintf("%+v", conf.FileConfig.Path))
+	logger.V(1).Info("[flb-go]", "FileFormat", fmt.Sprintf("%+v", conf.FileConfig.Format))
+	logger.V(1).Info("[flb-go]", "FileMaxSize", fmt.Sprintf("%+v", conf.FileConfig.MaxSize))
+	logger.V(1).Info("[flb-go]", "FileRotationInterval", fmt.Sprintf("%+v", conf.FileConfig.RotationInterval))
+	logger.V(1).Info("[flb-go]", "FileMaxFiles", fmt.Sprintf("%+v", conf.FileConfig.MaxFiles))
+	logger.V(1).Info("[flb-go]", "FileCompress", fmt.Sprintf("%+v", conf.FileConfig.Compress))
+
+	// Loki client configuration
+	logger.V(1).Info("[flb-go]", "LokiPushPath", fmt.Sprintf("%+v", conf.LokiConfig.PushPath))
+	logger.V(1).Info("[flb-go]", "LokiTenantID", fmt.Sprintf("%+v", conf.LokiConfig.TenantID))
+	logger.V(1).Info("[flb-go]", "LokiLabels", fmt.Sprintf("%+v", conf.LokiConfig.Labels))
+	logger.V(1).Info("[flb-go]", "LokiStructuredMetadata", fmt.Sprintf("%+v", conf.LokiConfig.StructuredMetadata))
+
+	// OpenSearch client configuration, credentials are not logged
+	logger.V(1).Info("[flb-go]", "OpenSearchIndex", fmt.Sprintf("%+v", conf.OpenSearchConfig.Index))
+	logger.V(1).Info("[flb-go]", "OpenSearchUsername", fmt.Sprintf("%+v", conf.OpenSearchConfig.Username))
+	if conf.OpenSearchConfig.Password != "" {
+		logger.V(1).Info("[flb-go]", "OpenSearchPassword", "configured")
+	}
+	if conf.OpenSearchConfig.APIKey != "" {
+		logger.V(1).Info("[flb-go]", "OpenSearchAPIKey", "configured")
+	}
+
+	// Kafka client configuration, the SASL password is not logged
+	logger.V(1).Info("[flb-go]", "KafkaBrokers", fmt.Sprintf("%+v", conf.KafkaConfig.Brokers))
+	logger.V(1).Info("[flb-go]", "KafkaTopic", fmt.Sprintf("%+v", conf.KafkaConfig.Topic))
+	logger.V(1).Info("[flb-go]", "KafkaKey", fmt.Sprintf("%+v", conf.KafkaConfig.Key))
+	logger.V(1).Info("[flb-go]", "KafkaEncoding", fmt.Sprintf("%+v", conf.KafkaConfig.Encoding))
+	logger.V(1).Info("[flb-go]", "KafkaRequiredAcks", fmt.Sprintf("%+v", conf.KafkaConfig.RequiredAcks))
+	logger.V(1).Info("[flb-go]", "KafkaAllowAutoTopicCreation", fmt.Sprintf("%+v", conf.KafkaConfig.AllowAutoTopicCreation))
+	logger.V(1).Info("[flb-go]", "KafkaSASLMechanism", fmt.Sprintf("%+v", conf.KafkaConfig.SASLMechanism))
+	logger.V(1).Info("[flb-go]", "KafkaSASLUsername", fmt.Sprintf("%+v", conf.KafkaConfig.SASLUsername))
+	if conf.KafkaConfig.SASLPassword != "" {
+		logger.V(1).Info("[flb-go]", "KafkaSASLPassword", "configured")
+	}
+
+	// Syslog client configuration
+	logger.V(1).Info("[flb-go]", "SyslogNetwork", fmt.Sprintf("%+v", conf.SyslogConfig.Network))
+	logger.V(1).Info("[flb-go]", "SyslogFacility", fmt.Sprintf("%+v", conf.SyslogConfig.Facility))
+	logger.V(1).Info("[flb-go]", "SyslogAppName", fmt.Sprintf("%+v", conf.SyslogConfig.AppName))
+	logger.V(1).Info("[flb-go]", "SyslogStructuredDataID", fmt.Sprintf("%+v", conf.SyslogConfig.StructuredDataID))
+
+	// Multi client configuration
+	for _, d := range conf.MultiConfig.Destinations {
+		filter := ""
+		if d.Filter != nil {
+			filter = d.Filter.Expression
+		}
+		logger.V(1).Info("[flb-go]", "Destination", d.Name, "Type", d.Type.String(),
+			"Endpoint", d.Config.OTLPConfig.Endpoint, "Filter", filter)
+	}
 }
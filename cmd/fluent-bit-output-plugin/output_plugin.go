@@ -10,6 +10,7 @@ import (
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	_ "net/http/pprof"
 	"strings"
@@ -79,6 +80,9 @@ func FLBPluginInit(ctx unsafe.Pointer) int {
 	if cfg.PluginConfig.LogLevel != "info" {
 		logger = log.New(cfg.PluginConfig.LogLevel)
 	}
+	for _, warning := range cfg.Warnings {
+		logger.Info("[flb-go] deprecated configuration", "warning", warning)
+	}
 
 	dumpConfiguration(cfg)
 
@@ -128,34 +132,51 @@ func FLBPluginFlushCtx(ctx, data unsafe.Pointer, length C.int, _ *C.char) int {
 		return output.FLB_ERROR
 	}
 
-	var ret int
-	var ts any
-	var record map[any]any
+	dec := newRecordDecoder(C.GoBytes(data, length))
 
-	dec := output.NewDecoder(data, int(length))
+	// group is the log group the following records belong to, if any
+	var group *types.LogGroup
 
 	for {
-		ret, ts, record = output.GetRecord(dec)
-		if ret != 0 {
+		event, err := dec.next()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				logger.Info("[flb-go] failed to decode record", "error", err)
+			}
+
 			break
 		}
 
+		switch event.kind {
+		case eventGroupStart:
+			group = toLogGroup(event.body)
+
+			continue
+		case eventGroupEnd:
+			group = nil
+
+			continue
+		case eventRecord:
+			// handled below
+		}
+
 		var timestamp time.Time
-		switch t := ts.(type) {
+		switch t := event.timestamp.(type) {
 		case output.FLBTime:
-			timestamp = ts.(output.FLBTime).Time
+			timestamp = t.Time
 		case uint64:
 			timestamp = time.Unix(int64(t), 0)
+		case int64:
+			timestamp = time.Unix(t, 0)
 		default:
-			logger.Info(fmt.Sprintf("[flb-go] unknown timestamp type: %T", ts))
+			logger.Info(fmt.Sprintf("[flb-go] unknown timestamp type: %T", event.timestamp))
 			timestamp = time.Now()
 		}
 
-		// TODO: it shall also handle logs groups when opentelemetry envelope is enabled
-		// https://docs.fluentbit.io/manual/data-pipeline/processors/opentelemetry-envelope
 		l := types.OutputEntry{
 			Timestamp: timestamp,
-			Record:    toOutputRecord(record),
+			Record:    toOutputRecord(event.body),
+			Group:     group,
 		}
 		if err := outputPlugin.SendRecord(l); err != nil {
 			return output.FLB_RETRY
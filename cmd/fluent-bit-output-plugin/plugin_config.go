@@ -42,6 +42,15 @@ func (c *pluginConfig) toStringMap() map[string]string {
 
 		"HostnameValue", "hostnameValue", "hostname_value",
 		"Origin", "origin",
+		"Processors", "processors",
+
+		// Multiline configs
+		"MultilinePresets", "multilinePresets", "multiline_presets",
+		"MultilineContinuationRegex", "multilineContinuationRegex", "multiline_continuation_regex",
+		"MultilineFlushTimeout", "multilineFlushTimeout", "multiline_flush_timeout",
+		"MultilineMaxLines", "multilineMaxLines", "multiline_max_lines",
+		"MultilineMaxBytes", "multilineMaxBytes", "multiline_max_bytes",
+		"MultilineMaxStreams", "multilineMaxStreams", "multiline_max_streams",
 
 		// Kubernetes metadata - TODO: revisit how to handle kubernetes metadata. Simplify?
 		"FallbackToTagWhenMetadataIsMissing", "fallbackToTagWhenMetadataIsMissing", "fallback_to_tag_when_metadata_is_missing",
@@ -60,6 +69,12 @@ func (c *pluginConfig) toStringMap() map[string]string {
 		"DeletedClientTimeExpiration", "deletedClientTimeExpiration", "deleted_client_time_expiration",
 		"ControllerSyncTimeout", "controllerSyncTimeout", "controller_sync_timeout",
 
+		// Tenant quota config
+		"TenantRequestsPerSec", "tenantRequestsPerSec", "tenant_requests_per_sec",
+		"TenantBytesPerSec", "tenantBytesPerSec", "tenant_bytes_per_sec",
+		"TenantFairShareRequestsPerSec", "tenantFairShareRequestsPerSec", "tenant_fair_share_requests_per_sec",
+		"TenantFairShareBytesPerSec", "tenantFairShareBytesPerSec", "tenant_fair_share_bytes_per_sec",
+
 		// OpenTelemetryCollector watching config
 		"WatchOpenTelemetryCollector", "watchOpenTelemetryCollector", "watch_open_telemetry_collector",
 		"OpenTelemetryCollectorLabelSelector", "openTelemetryCollectorLabelSelector", "open_telemetry_collector_label_selector",
@@ -94,8 +109,10 @@ func (c *pluginConfig) toStringMap() map[string]string {
 		"EndpointUrlPath:", "endpointUrlPath", "endpoint_url_path",
 		"Insecure", "insecure",
 		"Compression", "compression",
+		"Encoding", "encoding",
 		"Timeout", "timeout",
 		"Headers", "headers",
+		"HeaderFiles", "headerFiles", "header_files",
 
 		// OTLP Retry configs
 		"RetryEnabled", "retryEnabled", "retry_enabled",
@@ -103,9 +120,28 @@ func (c *pluginConfig) toStringMap() map[string]string {
 		"RetryMaxInterval", "retryMaxInterval", "retry_max_interval",
 		"RetryMaxElapsedTime", "retryMaxElapsedTime", "retry_max_elapsed_time",
 
+		// OTLP Failover configs
+		"FailoverEndpoints", "failoverEndpoints", "failover_endpoints",
+		"FailoverFailureThreshold", "failoverFailureThreshold", "failover_failure_threshold",
+		"FailoverCooldown", "failoverCooldown", "failover_cooldown",
+
+		// OTLP Authentication configs
+		"Authenticator", "authenticator",
+		"OAuth2TokenURL", "oauth2TokenURL", "oauth2_token_url",
+		"OAuth2ClientID", "oauth2ClientID", "oauth2_client_id",
+		"OAuth2ClientSecretFile", "oauth2ClientSecretFile", "oauth2_client_secret_file",
+		"OAuth2Scopes", "oauth2Scopes", "oauth2_scopes",
+		"KubernetesTokenFile", "kubernetesTokenFile", "kubernetes_token_file",
+		"BasicAuthUsername", "basicAuthUsername", "basic_auth_username",
+		"BasicAuthPasswordFile", "basicAuthPasswordFile", "basic_auth_password_file",
+
 		// OTLP HTTP specific configs
 		"HTTPPath", "httpPath", "http_path",
 		"HTTPProxy", "httpProxy", "http_proxy",
+		"HTTPNoProxy", "httpNoProxy", "http_no_proxy",
+		"HTTPProxyTLSCAFile", "httpProxyTLSCAFile", "http_proxy_tls_ca_file",
+		"HTTPProxyTLSServerName", "httpProxyTLSServerName", "http_proxy_tls_server_name",
+		"HTTPProxyTLSInsecureSkipVerify", "httpProxyTLSInsecureSkipVerify", "http_proxy_tls_insecure_skip_verify",
 
 		// OTLP TLS configs
 		"TLSCertFile", "tlsCertFile", "tls_cert_file",
@@ -116,12 +152,87 @@ func (c *pluginConfig) toStringMap() map[string]string {
 		"TLSMinVersion", "tlsMinVersion", "tls_min_version",
 		"TLSMaxVersion", "tlsMaxVersion", "tls_max_version",
 
+		// OTLP Body configs
+		"StructuredBody", "structuredBody", "structured_body",
+		"MaxBodySize", "maxBodySize", "max_body_size",
+		"MaxStructuredBodySize", "maxStructuredBodySize", "max_structured_body_size",
+
+		// Trace context extraction configs
+		"TraceContextEnabled", "traceContextEnabled", "trace_context_enabled",
+		"TraceIDField", "traceIDField", "trace_id_field",
+		"SpanIDField", "spanIDField", "span_id_field",
+		"TraceFlagsField", "traceFlagsField", "trace_flags_field",
+		"TraceParentField", "traceParentField", "trace_parent_field",
+		"TraceContextObjectField", "traceContextObjectField", "trace_context_object_field",
+
+		// Kubernetes attributes configs
+		"ResourceGrouping", "resourceGrouping", "resource_grouping",
+		"K8sPodLabelsAllowList", "k8sPodLabelsAllowList", "k8s_pod_labels_allow_list",
+		"K8sPodLabelsDenyList", "k8sPodLabelsDenyList", "k8s_pod_labels_deny_list",
+		"K8sPodAnnotationsAllowList", "k8sPodAnnotationsAllowList", "k8s_pod_annotations_allow_list",
+		"K8sPodAnnotationsDenyList", "k8sPodAnnotationsDenyList", "k8s_pod_annotations_deny_list",
+		"K8sWorkloadAttributes", "k8sWorkloadAttributes", "k8s_workload_attributes",
+
+		// Severity mapping configs
+		"SeverityPresets", "severityPresets", "severity_presets",
+		"SeverityFields", "severityFields", "severity_fields",
+		"SeverityBodyRegex", "severityBodyRegex", "severity_body_regex",
+		"SeverityValues", "severityValues", "severity_values",
+		"SeverityNumericValues", "severityNumericValues", "severity_numeric_values",
+
 		"ThrottleEnabled", "throttleEnabled", "throttle_enabled",
 		"ThrottleRequestsPerSec", "throttleRequestsPerSec", "throttle_requests_per_sec",
+		"ThrottleBytesPerSec", "throttleBytesPerSec", "throttle_bytes_per_sec",
+		"ThrottleAdaptive", "throttleAdaptive", "throttle_adaptive",
+		"ThrottleAdaptiveMinPercent", "throttleAdaptiveMinPercent", "throttle_adaptive_min_percent",
+		"ThrottleAdaptiveIncreasePercent", "throttleAdaptiveIncreasePercent", "throttle_adaptive_increase_percent",
+		"ThrottleAdaptiveInterval", "throttleAdaptiveInterval", "throttle_adaptive_interval",
+
+		// File client configs
+		"FilePath", "filePath", "file_path",
+		"FileFormat", "fileFormat", "file_format",
+		"FileMaxSize", "fileMaxSize", "file_max_size",
+		"FileRotationInterval", "fileRotationInterval", "file_rotation_interval",
+		"FileMaxFiles", "fileMaxFiles", "file_max_files",
+		"FileCompress", "fileCompress", "file_compress",
+
+		// Loki client configs
+		"LokiPushPath", "lokiPushPath", "loki_push_path",
+		"LokiTenantID", "lokiTenantID", "loki_tenant_id",
+		"LokiLabels", "lokiLabels", "loki_labels",
+		"LokiStructuredMetadata", "lokiStructuredMetadata", "loki_structured_metadata",
+
+		// OpenSearch client configs
+		"OpenSearchIndex", "openSearchIndex", "opensearch_index",
+		"OpenSearchUsername", "openSearchUsername", "opensearch_username",
+		"OpenSearchPassword", "openSearchPassword", "opensearch_password",
+		"OpenSearchAPIKey", "openSearchAPIKey", "opensearch_api_key",
+
+		// Kafka client configs
+		"KafkaBrokers", "kafkaBrokers", "kafka_brokers",
+		"KafkaTopic", "kafkaTopic", "kafka_topic",
+		"KafkaKey", "kafkaKey", "kafka_key",
+		"KafkaEncoding", "kafkaEncoding", "kafka_encoding",
+		"KafkaRequiredAcks", "kafkaRequiredAcks", "kafka_required_acks",
+		"KafkaAllowAutoTopicCreation", "kafkaAllowAutoTopicCreation", "kafka_allow_auto_topic_creation",
+		"KafkaSASLMechanism", "kafkaSASLMechanism", "kafka_sasl_mechanism",
+		"KafkaSASLUsername", "kafkaSASLUsername", "kafka_sasl_username",
+		"KafkaSASLPassword", "kafkaSASLPassword", "kafka_sasl_password",
+
+		// Syslog client configs
+		"SyslogNetwork", "syslogNetwork", "syslog_network",
+		"SyslogFacility", "syslogFacility", "syslog_facility",
+		"SyslogAppName", "syslogAppName", "syslog_app_name",
+		"SyslogStructuredDataID", "syslogStructuredDataID", "syslog_structured_data_id",
+
+		// Multi client configs
+		"Destinations", "destinations",
 
 		// OTLP Batch Processor configs
 		"DQueBatchProcessorMaxQueueSize", "dqueBatchProcessorMaxQueueSize", "dque_batch_processor_max_queue_size",
 		"DQueBatchProcessorMaxBatchSize", "dqueBatchProcessorMaxBatchSize", "dque_batch_processor_max_batch_size",
+		"DQueBatchProcessorMaxBatchBytes", "dqueBatchProcessorMaxBatchBytes", "dque_batch_processor_max_batch_bytes",
+		"DQueBatchProcessorDeadLetterMaxBytes", "dqueBatchProcessorDeadLetterMaxBytes", "dque_batch_processor_dead_letter_max_bytes",
 		"DQueBatchProcessorExportTimeout", "dqueBatchProcessorExportTimeout", "dque_batch_processor_export_timeout",
 		"DQueBatchProcessorExportInterval", "dqueBatchProcessorExportInterval", "dque_batch_processor_export_interval",
 		"DQueBatchProcessorExportBufferSize", "dqueBatchProcessorExportBufferSize", "dque_batch_processor_export_buffer_size",
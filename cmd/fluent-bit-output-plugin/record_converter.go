@@ -8,6 +8,7 @@ import (
 
 	"github.com/gardener/logging/v1/pkg/app"
 	"github.com/gardener/logging/v1/pkg/metrics"
+	"github.com/gardener/logging/v1/pkg/types"
 )
 
 // toOutputRecord converts fluent-bit's map[any]any to types.OutputRecord.
@@ -62,3 +63,25 @@ func toSlice(slice []any) []any {
 
 	return s
 }
+
+// toLogGroup converts the body of a fluent-bit group start event to types.LogGroup.
+// The body follows the layout of fluent-bit's opentelemetry input and opentelemetry-envelope processor:
+// {"resource": {"attributes": {...}, "schema_url": ""}, "scope": {"name": "", "version": "", "attributes": {...}, "schema_url": ""}}
+func toLogGroup(body map[any]any) *types.LogGroup {
+	b := toOutputRecord(body)
+	group := &types.LogGroup{}
+
+	if resource, ok := b["resource"].(map[string]any); ok {
+		group.ResourceAttributes, _ = resource["attributes"].(map[string]any)
+		group.ResourceSchemaURL, _ = resource["schema_url"].(string)
+	}
+
+	if scope, ok := b["scope"].(map[string]any); ok {
+		group.ScopeName, _ = scope["name"].(string)
+		group.ScopeVersion, _ = scope["version"].(string)
+		group.ScopeAttributes, _ = scope["attributes"].(map[string]any)
+		group.ScopeSchemaURL, _ = scope["schema_url"].(string)
+	}
+
+	return group
+}
@@ -520,3 +520,40 @@ var _ = Describe("toSlice", func() {
 		})
 	})
 })
+
+var _ = Describe("toLogGroup", func() {
+	It("should extract resource and scope of the group", func() {
+		input := map[any]any{
+			"resource": map[any]any{
+				"attributes": map[any]any{
+					"service.name":       []byte("gardener-apiserver"),
+					"k8s.namespace.name": "garden",
+				},
+				"schema_url": "https://opentelemetry.io/schemas/1.27.0",
+			},
+			"scope": map[any]any{
+				"name":       "apiserver",
+				"version":    "v1.2.3",
+				"attributes": map[any]any{"component": "audit"},
+			},
+		}
+
+		group := toLogGroup(input)
+
+		Expect(group.ResourceAttributes).To(HaveKeyWithValue("service.name", "gardener-apiserver"))
+		Expect(group.ResourceAttributes).To(HaveKeyWithValue("k8s.namespace.name", "garden"))
+		Expect(group.ResourceSchemaURL).To(Equal("https://opentelemetry.io/schemas/1.27.0"))
+		Expect(group.ScopeName).To(Equal("apiserver"))
+		Expect(group.ScopeVersion).To(Equal("v1.2.3"))
+		Expect(group.ScopeAttributes).To(HaveKeyWithValue("component", "audit"))
+		Expect(group.ScopeSchemaURL).To(BeEmpty())
+	})
+
+	It("should return an empty group when resource and scope are missing", func() {
+		group := toLogGroup(map[any]any{"other": "value"})
+
+		Expect(group).NotTo(BeNil())
+		Expect(group.ResourceAttributes).To(BeEmpty())
+		Expect(group.ScopeName).To(BeEmpty())
+	})
+})
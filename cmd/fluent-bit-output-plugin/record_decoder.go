@@ -0,0 +1,105 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/fluent/fluent-bit-go/output"
+	"github.com/ugorji/go/codec"
+)
+
+const (
+	// groupStartMarker is the timestamp fluent-bit uses for the event opening a log group
+	groupStartMarker = -1
+	// groupEndMarker is the timestamp fluent-bit uses for the event closing a log group
+	groupEndMarker = -2
+)
+
+// eventKind distinguishes log records from log group markers
+type eventKind int
+
+const (
+	eventRecord eventKind = iota
+	eventGroupStart
+	eventGroupEnd
+)
+
+// flbEvent is a single event decoded from a fluent-bit chunk
+type flbEvent struct {
+	kind      eventKind
+	timestamp any
+	metadata  map[any]any
+	body      map[any]any
+}
+
+// recordDecoder decodes fluent-bit msgpack chunks.
+// Unlike output.GetRecord it keeps the event metadata and recognises the
+// group start/end markers emitted when logs are carried in an opentelemetry envelope.
+// https://docs.fluentbit.io/manual/data-pipeline/processors/opentelemetry-envelope
+type recordDecoder struct {
+	mpdec  *codec.Decoder
+	length int
+}
+
+// newRecordDecoder creates a decoder for the given msgpack encoded chunk
+func newRecordDecoder(data []byte) *recordDecoder {
+	handle := new(codec.MsgpackHandle)
+	// fluent-bit encodes event timestamps as msgpack extension type 0
+	_ = handle.SetBytesExt(reflect.TypeFor[output.FLBTime](), 0, &output.FLBTime{})
+
+	return &recordDecoder{mpdec: codec.NewDecoderBytes(data, handle), length: len(data)}
+}
+
+// next decodes the next event of the chunk. It returns io.EOF when the chunk is exhausted.
+// Both the legacy [timestamp, body] and the fluent-bit v2 [[timestamp, metadata], body] formats are supported.
+func (d *recordDecoder) next() (flbEvent, error) {
+	if d.mpdec.NumBytesRead() >= d.length {
+		return flbEvent{}, io.EOF
+	}
+
+	var m any
+	if err := d.mpdec.Decode(&m); err != nil {
+		return flbEvent{}, err
+	}
+
+	entry, ok := m.([]any)
+	if !ok || len(entry) != 2 {
+		return flbEvent{}, fmt.Errorf("unexpected event format: %T", m)
+	}
+
+	event := flbEvent{kind: eventRecord, timestamp: entry[0]}
+
+	// fluent-bit v2 format carries the metadata next to the timestamp
+	if header, ok := entry[0].([]any); ok {
+		if len(header) < 2 {
+			return flbEvent{}, errors.New("unexpected event header: missing metadata")
+		}
+		event.timestamp = header[0]
+		event.metadata, _ = header[1].(map[any]any)
+	}
+
+	body, ok := entry[1].(map[any]any)
+	if !ok {
+		return flbEvent{}, fmt.Errorf("unexpected event body: %T", entry[1])
+	}
+	event.body = body
+
+	// group markers are encoded as negative integer timestamps
+	if marker, ok := event.timestamp.(int64); ok {
+		switch marker {
+		case groupStartMarker:
+			event.kind = eventGroupStart
+		case groupEndMarker:
+			event.kind = eventGroupEnd
+		default:
+			// any other integer is a plain epoch timestamp
+		}
+	}
+
+	return event, nil
+}
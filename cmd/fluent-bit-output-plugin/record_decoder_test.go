@@ -0,0 +1,86 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/ugorji/go/codec"
+)
+
+// encodeEvents encodes the given events the way fluent-bit lays out a chunk
+func encodeEvents(events ...any) []byte {
+	var data []byte
+	enc := codec.NewEncoderBytes(&data, new(codec.MsgpackHandle))
+	for _, e := range events {
+		Expect(enc.Encode(e)).To(Succeed())
+	}
+
+	return data
+}
+
+var _ = Describe("recordDecoder", func() {
+	It("should decode records in the legacy format", func() {
+		dec := newRecordDecoder(encodeEvents(
+			[]any{uint64(1700000000), map[string]any{"log": "hello"}},
+		))
+
+		event, err := dec.next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(event.kind).To(Equal(eventRecord))
+		Expect(event.timestamp).To(Equal(uint64(1700000000)))
+		Expect(event.metadata).To(BeNil())
+		Expect(toOutputRecord(event.body)).To(HaveKeyWithValue("log", "hello"))
+
+		_, err = dec.next()
+		Expect(err).To(MatchError(io.EOF))
+	})
+
+	It("should decode records with metadata", func() {
+		dec := newRecordDecoder(encodeEvents(
+			[]any{[]any{uint64(1700000000), map[string]any{"otlp": map[string]any{"severity_text": "INFO"}}}, map[string]any{"log": "hello"}},
+		))
+
+		event, err := dec.next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(event.kind).To(Equal(eventRecord))
+		Expect(event.timestamp).To(Equal(uint64(1700000000)))
+		Expect(toOutputRecord(event.metadata)).To(HaveKey("otlp"))
+	})
+
+	It("should recognise log group markers", func() {
+		dec := newRecordDecoder(encodeEvents(
+			[]any{[]any{int64(groupStartMarker), map[string]any{"schema": "otlp", "resource_id": 0, "scope_id": 0}},
+				map[string]any{"resource": map[string]any{"attributes": map[string]any{"service.name": "test"}}}},
+			[]any{[]any{uint64(1700000000), map[string]any{}}, map[string]any{"log": "grouped"}},
+			[]any{[]any{int64(groupEndMarker), map[string]any{}}, map[string]any{}},
+		))
+
+		start, err := dec.next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(start.kind).To(Equal(eventGroupStart))
+		Expect(toLogGroup(start.body).ResourceAttributes).To(HaveKeyWithValue("service.name", "test"))
+
+		record, err := dec.next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(record.kind).To(Equal(eventRecord))
+		Expect(toOutputRecord(record.body)).To(HaveKeyWithValue("log", "grouped"))
+
+		end, err := dec.next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(end.kind).To(Equal(eventGroupEnd))
+
+		_, err = dec.next()
+		Expect(err).To(MatchError(io.EOF))
+	})
+
+	It("should fail on malformed events", func() {
+		dec := newRecordDecoder(encodeEvents([]any{uint64(1700000000)}))
+
+		_, err := dec.next()
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,74 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package auth provides the authenticators setting the Authorization header of the OTLP exporters
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+// Authenticator provides the Authorization header value of requests
+type Authenticator interface {
+	// Authorization returns the current Authorization header value, e.g. a bearer token
+	Authorization(ctx context.Context) (string, error)
+}
+
+// New creates the configured authenticator, nil if no authenticator is configured.
+// The secret files are read initially, so that missing files fail the client creation.
+func New(cfg config.OTLPConfig) (Authenticator, error) {
+	switch cfg.Authenticator {
+	case "", config.AuthenticatorNone:
+		return nil, nil
+	case config.AuthenticatorOAuth2:
+		return newOAuth2(cfg)
+	case config.AuthenticatorKubernetes:
+		token, err := config.NewReloadingFile(cfg.KubernetesTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read service account token: %w", err)
+		}
+
+		return &kubernetesAuthenticator{token: token}, nil
+	case config.AuthenticatorBasic:
+		password, err := config.NewReloadingFile(cfg.BasicAuthPasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read basic authentication password: %w", err)
+		}
+
+		return &basicAuthenticator{username: cfg.BasicAuthUsername, password: password}, nil
+	default:
+		return nil, fmt.Errorf("unsupported authenticator %q", cfg.Authenticator)
+	}
+}
+
+// kubernetesAuthenticator sends the projected service account token as bearer token,
+// the token is read again when the kubelet rotates it
+type kubernetesAuthenticator struct {
+	token *config.ReloadingFile
+}
+
+func (a *kubernetesAuthenticator) Authorization(context.Context) (string, error) {
+	token := a.token.Content()
+	if token == "" {
+		return "", errors.New("service account token is empty")
+	}
+
+	return "Bearer " + token, nil
+}
+
+// basicAuthenticator sends the username and the password of the secret file as basic authentication
+type basicAuthenticator struct {
+	username string
+	password *config.ReloadingFile
+}
+
+func (a *basicAuthenticator) Authorization(context.Context) (string, error) {
+	credentials := a.username + ":" + a.password.Content()
+
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials)), nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/logging/v1/pkg/client/auth"
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+var _ = Describe("Authenticator", func() {
+	var (
+		cfg config.OTLPConfig
+		dir string
+		ctx context.Context
+	)
+
+	BeforeEach(func() {
+		cfg = config.DefaultOTLPConfig
+		dir = GinkgoT().TempDir()
+		ctx = context.Background()
+	})
+
+	// write replaces the file and moves its modification time forward, so that the change is detected
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		info, statErr := os.Stat(path)
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		if statErr == nil {
+			modTime := info.ModTime().Add(time.Second)
+			Expect(os.Chtimes(path, modTime, modTime)).To(Succeed())
+		}
+
+		return path
+	}
+
+	It("should not create an authenticator without configuration", func() {
+		authenticator, err := auth.New(cfg)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(authenticator).To(BeNil())
+	})
+
+	Describe("OAuth2", func() {
+		var server *fakeTokenServer
+
+		BeforeEach(func() {
+			server = newFakeTokenServer()
+			cfg.Authenticator = config.AuthenticatorOAuth2
+			cfg.OAuth2TokenURL = server.URL + "/oauth2/token"
+			cfg.OAuth2ClientID = "fluent-bit"
+			cfg.OAuth2ClientSecretFile = write("client-secret", "first-secret\n")
+			cfg.OAuth2Scopes = []string{"logs.write", "logs.read"}
+		})
+
+		It("should request tokens with the client credentials grant and cache them", func() {
+			authenticator, err := auth.New(cfg)
+			Expect(err).ToNot(HaveOccurred())
+
+			for range 3 {
+				Expect(authenticator.Authorization(ctx)).To(Equal("Bearer token-1"))
+			}
+			Expect(server.received()).To(Equal([]tokenRequest{{
+				grantType:    "client_credentials",
+				scope:        "logs.write logs.read",
+				clientID:     "fluent-bit",
+				clientSecret: "first-secret",
+			}}))
+		})
+
+		It("should request a new token with the rotated secret when the token expires", func() {
+			// Tokens expiring within the expiry delta of 10s are refreshed on every use
+			server.set(http.StatusOK, 5)
+			authenticator, err := auth.New(cfg)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(authenticator.Authorization(ctx)).To(Equal("Bearer token-1"))
+			write("client-secret", "rotated-secret")
+			Expect(authenticator.Authorization(ctx)).To(Equal("Bearer token-2"))
+
+			requests := server.received()
+			Expect(requests).To(HaveLen(2))
+			Expect(requests[1].clientSecret).To(Equal("rotated-secret"))
+		})
+
+		It("should fail if no token can be obtained and recover afterwards", func() {
+			server.set(http.StatusUnauthorized, 3600)
+			authenticator, err := auth.New(cfg)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = authenticator.Authorization(ctx)
+			Expect(err).To(MatchError(ContainSubstring("failed to get OAuth2 token")))
+
+			server.set(http.StatusOK, 3600)
+			Expect(authenticator.Authorization(ctx)).To(Equal("Bearer token-2"))
+		})
+
+		It("should request tokens through the HTTP proxy", func() {
+			var proxied []string
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				proxied = append(proxied, r.URL.String())
+				server.handle(w, r)
+			}))
+			DeferCleanup(proxy.Close)
+
+			proxyURL, err := url.Parse(proxy.URL)
+			Expect(err).ToNot(HaveOccurred())
+			cfg.HTTPProxyURL = proxyURL
+			cfg.OAuth2TokenURL = "http://auth.example/oauth2/token"
+
+			authenticator, err := auth.New(cfg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(authenticator.Authorization(ctx)).To(Equal("Bearer token-1"))
+			Expect(proxied).To(Equal([]string{"http://auth.example/oauth2/token"}))
+		})
+
+		It("should request tokens with the context of the export", func() {
+			authenticator, err := auth.New(cfg)
+			Expect(err).ToNot(HaveOccurred())
+
+			canceled, cancel := context.WithCancel(ctx)
+			cancel()
+			_, err = authenticator.Authorization(canceled)
+			Expect(err).To(MatchError(context.Canceled))
+			Expect(server.received()).To(BeEmpty())
+
+			Expect(authenticator.Authorization(ctx)).To(Equal("Bearer token-1"))
+		})
+
+		It("should fail without client secret file", func() {
+			cfg.OAuth2ClientSecretFile = filepath.Join(dir, "missing")
+			_, err := auth.New(cfg)
+			Expect(err).To(MatchError(ContainSubstring("failed to read OAuth2 client secret")))
+		})
+	})
+
+	Describe("Kubernetes", func() {
+		It("should send the service account token and read it again when it is rotated", func() {
+			cfg.Authenticator = config.AuthenticatorKubernetes
+			cfg.KubernetesTokenFile = write("token", "first-token\n")
+			authenticator, err := auth.New(cfg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(authenticator.Authorization(ctx)).To(Equal("Bearer first-token"))
+
+			write("token", "second-token")
+			Expect(authenticator.Authorization(ctx)).To(Equal("Bearer second-token"))
+
+			write("token", "")
+			_, err = authenticator.Authorization(ctx)
+			Expect(err).To(MatchError(ContainSubstring("service account token is empty")))
+		})
+
+		It("should fail without token file", func() {
+			cfg.Authenticator = config.AuthenticatorKubernetes
+			cfg.KubernetesTokenFile = filepath.Join(dir, "missing")
+			_, err := auth.New(cfg)
+			Expect(err).To(MatchError(ContainSubstring("failed to read service account token")))
+		})
+	})
+
+	Describe("Basic", func() {
+		It("should send the username and the password of the secret file", func() {
+			cfg.Authenticator = config.AuthenticatorBasic
+			cfg.BasicAuthUsername = "fluent-bit"
+			cfg.BasicAuthPasswordFile = write("password", "secret\n")
+			authenticator, err := auth.New(cfg)
+			Expect(err).ToNot(HaveOccurred())
+
+			basic := func(credentials string) string {
+				return "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+			}
+			Expect(authenticator.Authorization(ctx)).To(Equal(basic("fluent-bit:secret")))
+
+			write("password", "rotated")
+			Expect(authenticator.Authorization(ctx)).To(Equal(basic("fluent-bit:rotated")))
+		})
+	})
+})
@@ -0,0 +1,86 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// tokenRequest is a token request received by the fake token server
+type tokenRequest struct {
+	grantType    string
+	scope        string
+	clientID     string
+	clientSecret string
+}
+
+// fakeTokenServer is an OAuth2 token endpoint issuing numbered tokens for the client credentials grant
+type fakeTokenServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	requests  []tokenRequest
+	expiresIn int
+	status    int
+}
+
+func newFakeTokenServer() *fakeTokenServer {
+	s := &fakeTokenServer{expiresIn: 3600, status: http.StatusOK}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	DeferCleanup(s.Close)
+
+	return s
+}
+
+func (s *fakeTokenServer) handle(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, _ := r.BasicAuth()
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, tokenRequest{
+		grantType:    r.PostForm.Get("grant_type"),
+		scope:        r.PostForm.Get("scope"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+	})
+	if s.status != http.StatusOK {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(s.status)
+		_, _ = w.Write([]byte(`{"error": "invalid_client"}`))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": fmt.Sprintf("token-%d", len(s.requests)),
+		"token_type":   "bearer",
+		"expires_in":   s.expiresIn,
+	})
+}
+
+func (s *fakeTokenServer) received() []tokenRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]tokenRequest(nil), s.requests...)
+}
+
+func (s *fakeTokenServer) set(status, expiresIn int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status, s.expiresIn = status, expiresIn
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+// oauth2Authenticator sends bearer tokens of the OAuth2 client credentials flow.
+// Tokens are cached and requested again shortly before they expire.
+type oauth2Authenticator struct {
+	clientID string
+	secret   *config.ReloadingFile
+	tokenURL string
+	scopes   []string
+	client   *http.Client
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+func newOAuth2(cfg config.OTLPConfig) (*oauth2Authenticator, error) {
+	secret, err := config.NewReloadingFile(cfg.OAuth2ClientSecretFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OAuth2 client secret: %w", err)
+	}
+
+	// The token endpoint is reached through the HTTP proxy of the endpoint
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.HTTPProxyURL != nil {
+		otlp.ConfigureProxy(transport, cfg)
+	}
+
+	return &oauth2Authenticator{
+		clientID: cfg.OAuth2ClientID,
+		secret:   secret,
+		tokenURL: cfg.OAuth2TokenURL,
+		scopes:   cfg.OAuth2Scopes,
+		client:   &http.Client{Transport: transport, Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Authorization returns the cached token, or requests a new token with the context of the export
+// and the current client secret, so that a rotated secret is used for the next token
+func (a *oauth2Authenticator) Authorization(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.token.Valid() {
+		conf := &clientcredentials.Config{
+			ClientID:     a.clientID,
+			ClientSecret: a.secret.Content(),
+			TokenURL:     a.tokenURL,
+			Scopes:       a.scopes,
+			AuthStyle:    oauth2.AuthStyleInHeader,
+		}
+		token, err := conf.Token(context.WithValue(ctx, oauth2.HTTPClient, a.client))
+		if err != nil {
+			return "", fmt.Errorf("failed to get OAuth2 token: %w", err)
+		}
+		a.token = token
+	}
+
+	return a.token.Type() + " " + a.token.AccessToken, nil
+}
@@ -10,11 +10,17 @@ import (
 	"github.com/go-logr/logr"
 
 	"github.com/gardener/logging/v1/pkg/client/api"
+	fileclient "github.com/gardener/logging/v1/pkg/client/file"
+	kafkaclient "github.com/gardener/logging/v1/pkg/client/kafka"
+	lokiclient "github.com/gardener/logging/v1/pkg/client/loki"
+	multiclient "github.com/gardener/logging/v1/pkg/client/multi"
 	noopclient "github.com/gardener/logging/v1/pkg/client/noop"
+	opensearchclient "github.com/gardener/logging/v1/pkg/client/opensearch"
 	"github.com/gardener/logging/v1/pkg/client/otlp"
 	"github.com/gardener/logging/v1/pkg/client/otlp/otlpgrpc"
 	"github.com/gardener/logging/v1/pkg/client/otlp/otlphttp"
 	stdoutclient "github.com/gardener/logging/v1/pkg/client/stdout"
+	syslogclient "github.com/gardener/logging/v1/pkg/client/syslog"
 	"github.com/gardener/logging/v1/pkg/config"
 	"github.com/gardener/logging/v1/pkg/metrics"
 	"github.com/gardener/logging/v1/pkg/targets"
@@ -101,8 +107,25 @@ func NewClient(ctx context.Context, cfg config.Config, opts ...Option) (api.Outp
 		return otlphttp.New(ctx, cfg, logger, options.metrics, options.metricsSetup)
 	case types.STDOUT:
 		return stdoutclient.New(ctx, cfg, logger, options.metrics)
+	case types.LOKI:
+		return lokiclient.New(ctx, cfg, logger, options.metrics)
+	case types.OPENSEARCH:
+		return opensearchclient.New(ctx, cfg, logger, options.metrics)
+	case types.KAFKA:
+		return kafkaclient.New(ctx, cfg, logger, options.metrics)
+	case types.SYSLOG:
+		return syslogclient.New(ctx, cfg, logger, options.metrics)
+	case types.FILE:
+		return fileclient.New(ctx, cfg, logger, options.metrics)
 	case types.NOOP:
 		return noopclient.New(ctx, cfg, logger, options.metrics)
+	case types.MULTI:
+		// The destinations are created like seed clients, their configuration sets SeedType to the destination type
+		destinationOpts := append(append([]Option{}, opts...), WithTarget(targets.Seed))
+
+		return multiclient.New(ctx, cfg, logger, options.metrics, func(ctx context.Context, destCfg config.Config) (api.Output, error) {
+			return NewClient(ctx, destCfg, destinationOpts...)
+		})
 	default:
 		return nil, fmt.Errorf("unknown client type: %v", t)
 	}
@@ -10,7 +10,9 @@ import (
 	ginkgov2 "github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 
+	multiclient "github.com/gardener/logging/v1/pkg/client/multi"
 	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
 )
 
 var _ = ginkgov2.Describe("Client", func() {
@@ -50,5 +52,25 @@ var _ = ginkgov2.Describe("Client", func() {
 			gomega.Expect(err).ToNot(gomega.HaveOccurred())
 			gomega.Expect(c).ToNot(gomega.BeNil())
 		})
+
+		ginkgov2.It("should create a multi client with the destination clients", func() {
+			cfg, err := config.ParseConfig(map[string]any{
+				"SeedType":     "multi",
+				"DQueDir":      ginkgov2.GinkgoT().TempDir(),
+				"Destinations": `[{"name": "a", "type": "noop", "Endpoint": "a:4317"}, {"name": "b", "type": "stdout", "Endpoint": "b:4317"}]`,
+			})
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+			c, err := NewClient(
+				context.Background(),
+				*cfg,
+				WithLogger(logger),
+				WithMetrics(metrics.RegisterFluentBitGardenerMetrics(metrics.NewRegistry())),
+			)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(c).To(gomega.BeAssignableToTypeOf(&multiclient.Client{}))
+			gomega.Expect(c.Endpoint()).To(gomega.Equal("a:4317,b:4317"))
+			c.StopWait()
+		})
 	})
 })
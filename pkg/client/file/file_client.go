@@ -0,0 +1,226 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/api"
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	stdoutclient "github.com/gardener/logging/v1/pkg/client/stdout"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+const componentFileName = "file"
+
+// Client is an implementation of Output that writes all records to a local file
+type Client struct {
+	ctx     context.Context
+	logger  logr.Logger
+	config  config.Config
+	path    string
+	writer  *rotatingWriter
+	metrics *metrics.FluentBitGardenerMetrics
+
+	// Used by the otlp_json format only
+	mu             sync.Mutex
+	loggerProvider *sdklog.LoggerProvider
+	groupLoggers   *otlp.GroupLoggers
+	encoder        *jsonProcessor
+
+	stopOnce sync.Once
+}
+
+var _ api.Output = &Client{}
+
+// New creates a new file client writing to the configured path
+func New(ctx context.Context, cfg config.Config, logger logr.Logger, m *metrics.FluentBitGardenerMetrics) (*Client, error) {
+	if cfg.FileConfig.Path == "" {
+		return nil, errors.New("FilePath is required for the file client")
+	}
+
+	writer, err := acquireWriter(cfg.FileConfig, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file output: %w", err)
+	}
+
+	client := &Client{
+		ctx:     ctx,
+		logger:  logger.WithValues("path", cfg.FileConfig.Path, "component", componentFileName),
+		config:  cfg,
+		path:    cfg.FileConfig.Path,
+		writer:  writer,
+		metrics: m,
+	}
+
+	if cfg.FileConfig.Format == config.FileFormatOTLPJSON {
+		client.encoder = &jsonProcessor{writer: writer}
+
+		resource := otlp.NewResourceAttributesBuilder().
+			WithHostname(cfg).
+			Build()
+
+		client.loggerProvider = sdklog.NewLoggerProvider(
+			sdklog.WithResource(resource),
+			sdklog.WithProcessor(client.encoder),
+		)
+
+		scopeOptions := otlp.NewScopeAttributesBuilder().
+			WithVersion(otlp.PluginVersion()).
+			WithSchemaURL(otlp.SchemaURL).
+			Build()
+
+		client.groupLoggers = otlp.NewGroupLoggers(client.encoder,
+			client.loggerProvider.Logger(otlp.PluginName, scopeOptions...), resource, scopeOptions...)
+	}
+
+	logger.V(1).Info(fmt.Sprintf("%s created", componentFileName),
+		"path", cfg.FileConfig.Path,
+		"format", cfg.FileConfig.Format,
+	)
+
+	return client, nil
+}
+
+// Handle writes the log entry to the file while incrementing metrics
+func (c *Client) Handle(entry types.OutputEntry) error {
+	var err error
+	if c.encoder != nil {
+		err = c.writeOTLPJSON(entry)
+	} else {
+		err = c.writeJSON(entry)
+	}
+
+	if err != nil {
+		c.logger.Error(err, "failed to write log entry")
+		c.metrics.Errors.WithLabelValues(metrics.ErrorSendRecord).Inc()
+
+		return err
+	}
+
+	c.metrics.OutputClientLogs.WithLabelValues(c.path).Inc()
+
+	return nil
+}
+
+func (c *Client) writeJSON(entry types.OutputEntry) error {
+	data, err := stdoutclient.MarshalEntry(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal log entry: %w", err)
+	}
+
+	if _, err := c.writer.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write to %s: %w", c.path, err)
+	}
+
+	return nil
+}
+
+func (c *Client) writeOTLPJSON(entry types.OutputEntry) error {
+	builder := otlp.NewLogRecordBuilder().
+		WithConfig(c.config).
+		WithTimestamp(entry.Timestamp).
+		WithSeverity(entry.Record).
+		WithBody(entry.Record).
+		WithTraceContext(entry.Record).
+		WithAttributes(entry)
+	logRecord := builder.Build()
+	if builder.StructuredBodyFallback() {
+		c.metrics.StructuredBodyFallbacks.WithLabelValues(c.path).Inc()
+	}
+
+	// The encoder writes synchronously, the lock hands its error back to this record
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.encoder.err = nil
+	c.groupLoggers.Logger(entry.Group, builder.ResourceAttributes()...).Emit(builder.EmitContext(c.ctx), logRecord)
+
+	return c.encoder.err
+}
+
+// Stop closes the file once no other client writes to it
+func (c *Client) Stop() {
+	c.logger.V(2).Info(fmt.Sprintf("stopping %s", componentFileName))
+	c.stop()
+}
+
+// StopWait closes the file once no other client writes to it, records are written synchronously
+func (c *Client) StopWait() {
+	c.logger.V(2).Info(fmt.Sprintf("stopping %s with wait", componentFileName))
+	c.stop()
+}
+
+func (c *Client) stop() {
+	c.stopOnce.Do(func() {
+		if c.loggerProvider != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			if err := c.loggerProvider.Shutdown(ctx); err != nil {
+				c.logger.Error(err, "error during logger provider shutdown")
+			}
+		}
+
+		if err := c.writer.release(); err != nil {
+			c.logger.Error(err, "failed to close file")
+		}
+	})
+}
+
+// Endpoint returns the path of the file
+func (c *Client) Endpoint() string {
+	return c.path
+}
+
+// jsonProcessor is a synchronous log processor writing each record as OTLP/JSON line
+type jsonProcessor struct {
+	writer *rotatingWriter
+	// err is the error of the last record, guarded by the client lock
+	err error
+}
+
+var _ sdklog.Processor = &jsonProcessor{}
+
+// OnEmit encodes and writes the record
+func (p *jsonProcessor) OnEmit(_ context.Context, record *sdklog.Record) error {
+	data, err := otlp.MarshalLogsJSON([]sdklog.Record{*record})
+	if err != nil {
+		p.err = fmt.Errorf("failed to marshal log record: %w", err)
+
+		return p.err
+	}
+
+	if _, err := p.writer.Write(append(data, '\n')); err != nil {
+		p.err = fmt.Errorf("failed to write to %s: %w", p.writer.cfg.Path, err)
+
+		return p.err
+	}
+
+	return nil
+}
+
+// Enabled reports that all records are written
+func (*jsonProcessor) Enabled(context.Context, sdklog.EnabledParameters) bool {
+	return true
+}
+
+// Shutdown is a no-op, the file is closed by the client
+func (*jsonProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush is a no-op, records are written synchronously
+func (*jsonProcessor) ForceFlush(context.Context) error {
+	return nil
+}
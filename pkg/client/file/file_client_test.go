@@ -0,0 +1,278 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/log"
+	"github.com/gardener/logging/v1/pkg/metrics"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+var _ = Describe("FileClient", func() {
+	var (
+		dir         string
+		path        string
+		testMetrics *metrics.FluentBitGardenerMetrics
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		path = filepath.Join(dir, "logs", "output.log")
+		testMetrics = metrics.RegisterFluentBitGardenerMetrics(metrics.NewRegistry())
+	})
+
+	newClient := func(fileConfig map[string]any) *Client {
+		configMap := map[string]any{"FilePath": path}
+		for k, v := range fileConfig {
+			configMap[k] = v
+		}
+		cfg, err := config.ParseConfig(configMap)
+		Expect(err).NotTo(HaveOccurred())
+
+		client, err := New(context.Background(), *cfg, log.NewNoop(), testMetrics)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.StopWait)
+
+		return client
+	}
+
+	entry := func(log string) types.OutputEntry {
+		return types.OutputEntry{
+			Timestamp: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+			Record: map[string]any{
+				"log":      log,
+				"severity": "warn",
+				"kubernetes": map[string]any{
+					"namespace_name": "garden",
+					"pod_name":       "app-0",
+				},
+			},
+		}
+	}
+
+	readLines := func(name string) []string {
+		f, err := os.Open(name)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = f.Close() }()
+
+		var r io.Reader = f
+		if strings.HasSuffix(name, ".gz") {
+			gz, err := gzip.NewReader(f)
+			Expect(err).NotTo(HaveOccurred())
+			r = gz
+		}
+
+		var lines []string
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		Expect(scanner.Err()).NotTo(HaveOccurred())
+
+		return lines
+	}
+
+	rotatedFiles := func() []string {
+		matches, err := filepath.Glob(path + ".*")
+		Expect(err).NotTo(HaveOccurred())
+
+		return matches
+	}
+
+	It("should require a file path", func() {
+		_, err := New(context.Background(), config.Config{}, log.NewNoop(), testMetrics)
+		Expect(err).To(MatchError(ContainSubstring("FilePath is required")))
+	})
+
+	It("should write records as JSON lines", func() {
+		client := newClient(nil)
+		Expect(client.Endpoint()).To(Equal(path))
+
+		Expect(client.Handle(entry("first"))).To(Succeed())
+		Expect(client.Handle(entry("second"))).To(Succeed())
+
+		lines := readLines(path)
+		Expect(lines).To(HaveLen(2))
+
+		var record map[string]any
+		Expect(json.Unmarshal([]byte(lines[0]), &record)).To(Succeed())
+		Expect(record).To(HaveKeyWithValue("timestamp", "2024-01-02T15:04:05.000000Z"))
+		Expect(record).To(HaveKeyWithValue("record", HaveKeyWithValue("log", "first")))
+		Expect(testutil.ToFloat64(testMetrics.OutputClientLogs.WithLabelValues(path))).To(Equal(2.0))
+	})
+
+	It("should append to an existing file", func() {
+		Expect(os.MkdirAll(filepath.Dir(path), 0o750)).To(Succeed())
+		Expect(os.WriteFile(path, []byte("existing\n"), 0o600)).To(Succeed())
+
+		client := newClient(nil)
+		Expect(client.Handle(entry("appended"))).To(Succeed())
+
+		lines := readLines(path)
+		Expect(lines).To(HaveLen(2))
+		Expect(lines[0]).To(Equal("existing"))
+	})
+
+	It("should write records as OTLP/JSON lines", func() {
+		client := newClient(map[string]any{"FileFormat": "otlp_json"})
+
+		Expect(client.Handle(entry("first"))).To(Succeed())
+		Expect(client.Handle(entry("second"))).To(Succeed())
+
+		lines := readLines(path)
+		Expect(lines).To(HaveLen(2))
+
+		var request struct {
+			ResourceLogs []struct {
+				ScopeLogs []struct {
+					Scope struct {
+						Name string `json:"name"`
+					} `json:"scope"`
+					LogRecords []struct {
+						TimeUnixNano   string `json:"timeUnixNano"`
+						SeverityNumber int    `json:"severityNumber"`
+						Body           struct {
+							StringValue string `json:"stringValue"`
+						} `json:"body"`
+					} `json:"logRecords"`
+				} `json:"scopeLogs"`
+			} `json:"resourceLogs"`
+		}
+		Expect(json.Unmarshal([]byte(lines[1]), &request)).To(Succeed())
+		Expect(request.ResourceLogs).To(HaveLen(1))
+		Expect(request.ResourceLogs[0].ScopeLogs).To(HaveLen(1))
+
+		scopeLogs := request.ResourceLogs[0].ScopeLogs[0]
+		Expect(scopeLogs.Scope.Name).NotTo(BeEmpty())
+		Expect(scopeLogs.LogRecords).To(HaveLen(1))
+		Expect(scopeLogs.LogRecords[0].Body.StringValue).To(Equal("second"))
+		Expect(scopeLogs.LogRecords[0].TimeUnixNano).To(Equal("1704207845000000000"))
+		Expect(scopeLogs.LogRecords[0].SeverityNumber).To(Equal(13))
+	})
+
+	It("should rotate the file by size and keep the configured number of files", func() {
+		client := newClient(map[string]any{"FileMaxSize": "200", "FileMaxFiles": "2"})
+
+		for range 20 {
+			Expect(client.Handle(entry("a log line which fills the file"))).To(Succeed())
+		}
+		client.StopWait()
+
+		Expect(rotatedFiles()).To(HaveLen(2))
+		for _, name := range append(rotatedFiles(), path) {
+			info, err := os.Stat(name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Size()).To(BeNumerically("<=", 200))
+			Expect(readLines(name)).NotTo(BeEmpty())
+		}
+	})
+
+	It("should compress rotated files", func() {
+		client := newClient(map[string]any{"FileMaxSize": "200", "FileMaxFiles": "0", "FileCompress": "true"})
+
+		for range 10 {
+			Expect(client.Handle(entry("a log line which fills the file"))).To(Succeed())
+		}
+		client.StopWait()
+
+		rotated := rotatedFiles()
+		Expect(rotated).NotTo(BeEmpty())
+
+		total := len(readLines(path))
+		for _, name := range rotated {
+			Expect(name).To(HaveSuffix(".gz"))
+			total += len(readLines(name))
+		}
+		Expect(total).To(Equal(10))
+	})
+
+	It("should keep appending and back off when the rotation fails", func() {
+		var renames int
+		rename = func(string, string) error {
+			renames++
+
+			return os.ErrPermission
+		}
+		DeferCleanup(func() { rename = os.Rename })
+
+		client := newClient(map[string]any{"FileMaxSize": "1000"})
+		for range 40 {
+			Expect(client.Handle(entry("a log line which fills the file"))).To(Succeed())
+		}
+		client.StopWait()
+
+		Expect(rotatedFiles()).To(BeEmpty())
+		Expect(readLines(path)).To(HaveLen(40))
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		// One rotation is attempted per 1000 written bytes, not on every write above the limit
+		Expect(renames).To(BeNumerically(">", 0))
+		Expect(renames).To(BeNumerically("<=", info.Size()/1000+1))
+	})
+
+	It("should rotate the file by age", func() {
+		client := newClient(map[string]any{"FileRotationInterval": "20ms"})
+
+		Expect(client.Handle(entry("first"))).To(Succeed())
+		time.Sleep(30 * time.Millisecond)
+		Expect(client.Handle(entry("second"))).To(Succeed())
+		client.StopWait()
+
+		rotated := rotatedFiles()
+		Expect(rotated).To(HaveLen(1))
+		Expect(readLines(rotated[0])).To(HaveLen(1))
+		Expect(readLines(path)).To(HaveLen(1))
+	})
+
+	It("should share the file between clients and close it with the last client", func() {
+		first := newClient(nil)
+		second := newClient(nil)
+		Expect(first.writer).To(BeIdenticalTo(second.writer))
+
+		Expect(first.Handle(entry("first"))).To(Succeed())
+		first.Stop()
+		Expect(second.Handle(entry("second"))).To(Succeed())
+		second.Stop()
+
+		Expect(readLines(path)).To(HaveLen(2))
+		Expect(second.Handle(entry("closed"))).To(HaveOccurred())
+		Expect(testutil.ToFloat64(testMetrics.Errors.WithLabelValues(metrics.ErrorSendRecord))).To(Equal(1.0))
+	})
+
+	It("should reject clients sharing the file with different settings", func() {
+		newClient(map[string]any{"FileMaxSize": "1000"})
+
+		for _, fileConfig := range []map[string]any{
+			{"FileMaxSize": "2000"},
+			{"FileMaxSize": "1000", "FileMaxFiles": "1"},
+			{"FileMaxSize": "1000", "FileCompress": "true"},
+			{"FileMaxSize": "1000", "FileFormat": "otlp_json"},
+		} {
+			fileConfig["FilePath"] = path
+			cfg, err := config.ParseConfig(fileConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = New(context.Background(), *cfg, log.NewNoop(), testMetrics)
+			Expect(err).To(MatchError(ContainSubstring("is already written with different settings")))
+		}
+
+		same := newClient(map[string]any{"FileMaxSize": "1000", "FilePath": filepath.Join(dir, "logs", ".", "output.log")})
+		Expect(same.Handle(entry("shared"))).To(Succeed())
+	})
+})
@@ -0,0 +1,290 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+// rotatedSuffixLayout is the layout of the timestamp appended to the names of rotated files.
+// It sorts lexicographically in chronological order.
+const rotatedSuffixLayout = "20060102T150405.000000000"
+
+var (
+	// rename renames the file on rotation, it is replaced in tests to simulate failing rotations
+	rename = os.Rename
+
+	// writers holds the writers shared by all clients writing to the same path
+	writers   = make(map[string]*rotatingWriter)
+	writersMu sync.Mutex
+)
+
+// rotatingWriter appends to a file which is rotated by size and age.
+// Rotated files are renamed with a timestamp suffix, optionally compressed with gzip,
+// and the oldest rotated files beyond the retention limit are removed.
+type rotatingWriter struct {
+	cfg    config.FileConfig
+	logger logr.Logger
+	refs   int
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	// failedSize is the size of the file when its last rotation failed. The next rotation by size
+	// is attempted when the file has grown by another MaxSize, instead of on every write.
+	failedSize int64
+
+	// housekeepingMu serializes compression and removal of rotated files
+	housekeepingMu sync.Mutex
+	housekeeping   sync.WaitGroup
+}
+
+// acquireWriter returns the writer of the configured path, creating it when no other client writes to the path.
+// Clients sharing a path must use the same file settings.
+func acquireWriter(cfg config.FileConfig, logger logr.Logger) (*rotatingWriter, error) {
+	path := filepath.Clean(cfg.Path)
+	cfg.Path = path
+
+	writersMu.Lock()
+	defer writersMu.Unlock()
+
+	if w, ok := writers[path]; ok {
+		if w.cfg != cfg {
+			return nil, fmt.Errorf("%s is already written with different settings %+v, got %+v", path, w.cfg, cfg)
+		}
+		w.refs++
+
+		return w, nil
+	}
+
+	w := &rotatingWriter{cfg: cfg, logger: logger, refs: 1}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create directory of %s: %w", path, err)
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	writers[path] = w
+
+	return w, nil
+}
+
+// release closes the writer when it is no longer used by any client
+func (w *rotatingWriter) release() error {
+	writersMu.Lock()
+	defer writersMu.Unlock()
+
+	w.refs--
+	if w.refs > 0 {
+		return nil
+	}
+	delete(writers, w.cfg.Path)
+
+	return w.close()
+}
+
+// Write writes p to the file, rotating the file before if p exceeds the size limit or the file its maximum age
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if w.shouldRotate(len(p)) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+
+	return n, err
+}
+
+func (w *rotatingWriter) shouldRotate(n int) bool {
+	if w.size == 0 {
+		return false
+	}
+	if w.cfg.MaxSize > 0 && w.size-w.failedSize+int64(n) > w.cfg.MaxSize {
+		return true
+	}
+
+	return w.cfg.RotationInterval > 0 && time.Since(w.openedAt) >= w.cfg.RotationInterval
+}
+
+func (w *rotatingWriter) open() error {
+	f, err := os.OpenFile(w.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", w.cfg.Path, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("failed to stat %s: %w", w.cfg.Path, err)
+	}
+
+	w.file, w.size, w.openedAt = f, info.Size(), time.Now()
+
+	return nil
+}
+
+func (w *rotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		w.logger.Error(err, "failed to close file before rotation", "path", w.cfg.Path)
+	}
+	w.file = nil
+
+	rotated := w.cfg.Path + "." + time.Now().UTC().Format(rotatedSuffixLayout)
+	renameErr := rename(w.cfg.Path, rotated)
+
+	if err := w.open(); err != nil {
+		return err
+	}
+
+	if renameErr != nil {
+		// Keep appending to the current file rather than losing records. Reopening the file restarted
+		// the rotation interval, the size limit is applied from the current size.
+		w.logger.Error(renameErr, "failed to rotate file", "path", w.cfg.Path)
+		w.failedSize = w.size
+
+		return nil
+	}
+	w.failedSize = 0
+
+	w.housekeeping.Add(1)
+	go func() {
+		defer w.housekeeping.Done()
+		w.housekeepingMu.Lock()
+		defer w.housekeepingMu.Unlock()
+
+		if w.cfg.Compress {
+			if err := compressFile(rotated); err != nil {
+				w.logger.Error(err, "failed to compress rotated file", "path", rotated)
+			}
+		}
+		w.removeExpired()
+	}()
+
+	return nil
+}
+
+// removeExpired removes the oldest rotated files exceeding the configured number of retained files
+func (w *rotatingWriter) removeExpired() {
+	if w.cfg.MaxFiles <= 0 {
+		return
+	}
+
+	rotated, err := w.rotatedFiles()
+	if err != nil {
+		w.logger.Error(err, "failed to list rotated files", "path", w.cfg.Path)
+
+		return
+	}
+
+	for len(rotated) > w.cfg.MaxFiles {
+		if err := os.Remove(rotated[0]); err != nil && !errors.Is(err, os.ErrNotExist) {
+			w.logger.Error(err, "failed to remove rotated file", "path", rotated[0])
+		}
+		rotated = rotated[1:]
+	}
+}
+
+// rotatedFiles returns the rotated files of the writer, oldest first
+func (w *rotatingWriter) rotatedFiles() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Dir(w.cfg.Path))
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := filepath.Base(w.cfg.Path) + "."
+	var rotated []string
+	for _, entry := range entries {
+		name := entry.Name()
+		suffix, ok := strings.CutPrefix(name, prefix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		timestamp := strings.TrimSuffix(suffix, ".gz")
+		if _, err := time.Parse(rotatedSuffixLayout, timestamp); err != nil {
+			continue
+		}
+		rotated = append(rotated, filepath.Join(filepath.Dir(w.cfg.Path), name))
+	}
+	slices.Sort(rotated)
+
+	return rotated, nil
+}
+
+func (w *rotatingWriter) close() error {
+	w.mu.Lock()
+	var err error
+	if w.file != nil {
+		err = w.file.Close()
+		w.file = nil
+	}
+	w.mu.Unlock()
+
+	w.housekeeping.Wait()
+
+	return err
+}
+
+// compressFile compresses the file with gzip and removes the original
+func compressFile(path string) (err error) {
+	src, err := os.Open(path) // #nosec G304 -- path of a rotated file
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	tmp := path + ".gz.tmp"
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp)
+		}
+	}()
+
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err != nil {
+		_ = dst.Close()
+
+		return err
+	}
+	if err = gz.Close(); err != nil {
+		_ = dst.Close()
+
+		return err
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, path+".gz"); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/client/retry"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
+)
+
+// exporter is a blocking sdklog.Exporter producing each record as message.
+// Messages failed with retryable errors are retried, messages rejected by the brokers are dropped.
+type exporter struct {
+	client   *kgo.Client
+	timeout  time.Duration
+	topic    topicTemplate
+	key      string
+	encode   valueEncoder
+	retry    *config.RetryConfig
+	endpoint string
+	logger   logr.Logger
+	metrics  *metrics.FluentBitGardenerMetrics
+}
+
+var _ sdklog.Exporter = &exporter{}
+
+// newExporter creates an exporter from the TLS, timeout, compression, retry and Kafka configuration.
+// Connections use TLS unless Insecure is set, keys select the partition like the default partitioner of the Java client.
+func newExporter(cfg config.Config, logger logr.Logger, m *metrics.FluentBitGardenerMetrics) (*exporter, error) {
+	kafka := cfg.KafkaConfig
+
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(kafka.Brokers...),
+		kgo.ClientID(otlp.PluginName),
+		kgo.WithLogger(kgoLogger{logger: logger}),
+		kgo.DialTimeout(cfg.OTLPConfig.Timeout),
+	}
+	if kafka.AllowAutoTopicCreation {
+		opts = append(opts, kgo.AllowAutoTopicCreation())
+	}
+	// Idempotent writes, which keep retries from writing duplicates, require the acknowledgement of all in-sync replicas
+	switch kafka.RequiredAcks {
+	case 0:
+		opts = append(opts, kgo.RequiredAcks(kgo.NoAck()), kgo.DisableIdempotentWrite())
+	case 1:
+		opts = append(opts, kgo.RequiredAcks(kgo.LeaderAck()), kgo.DisableIdempotentWrite())
+	default:
+		opts = append(opts, kgo.RequiredAcks(kgo.AllISRAcks()))
+	}
+	switch cfg.OTLPConfig.Compression {
+	case config.CompressionGzip:
+		opts = append(opts, kgo.ProducerBatchCompression(kgo.GzipCompression()))
+	case config.CompressionZstd:
+		opts = append(opts, kgo.ProducerBatchCompression(kgo.ZstdCompression()))
+	case config.CompressionSnappy:
+		opts = append(opts, kgo.ProducerBatchCompression(kgo.SnappyCompression()))
+	default:
+		opts = append(opts, kgo.ProducerBatchCompression(kgo.NoCompression()))
+	}
+
+	tlsConfig := cfg.OTLPConfig.TLSConfig
+	if tlsConfig == nil && !cfg.OTLPConfig.Insecure {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if tlsConfig != nil {
+		opts = append(opts, kgo.DialTLSConfig(tlsConfig))
+	}
+
+	if kafka.SASLMechanism != "" {
+		mechanism, err := saslMechanism(&kafka)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, kgo.SASL(mechanism))
+	}
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+
+	encode := protoEncoder
+	if kafka.Encoding == config.KafkaEncodingOTLPJSON {
+		encode = jsonEncoder
+	}
+
+	return &exporter{
+		client:   client,
+		timeout:  cfg.OTLPConfig.Timeout,
+		topic:    newTopicTemplate(kafka.Topic),
+		key:      kafka.Key,
+		encode:   encode,
+		retry:    cfg.OTLPConfig.RetryConfig,
+		endpoint: strings.Join(kafka.Brokers, ","),
+		logger:   logger,
+		metrics:  m,
+	}, nil
+}
+
+// saslMechanism returns the SASL mechanism authenticating with the configured credentials
+func saslMechanism(cfg *config.KafkaConfig) (sasl.Mechanism, error) {
+	switch cfg.SASLMechanism {
+	case config.KafkaSASLPlain:
+		return plain.Auth{User: cfg.SASLUsername, Pass: cfg.SASLPassword}.AsMechanism(), nil
+	case config.KafkaSASLScramSHA256:
+		return scram.Auth{User: cfg.SASLUsername, Pass: cfg.SASLPassword}.AsSha256Mechanism(), nil
+	case config.KafkaSASLScramSHA512:
+		return scram.Auth{User: cfg.SASLUsername, Pass: cfg.SASLPassword}.AsSha512Mechanism(), nil
+	default:
+		return nil, fmt.Errorf("unsupported SASL mechanism %q", cfg.SASLMechanism)
+	}
+}
+
+// kgoLogger logs the errors and warnings of the Kafka client, like unreachable brokers or failed authentications
+type kgoLogger struct {
+	logger logr.Logger
+}
+
+func (kgoLogger) Level() kgo.LogLevel {
+	return kgo.LogLevelWarn
+}
+
+func (l kgoLogger) Log(level kgo.LogLevel, msg string, keyvals ...any) {
+	if level == kgo.LogLevelError {
+		l.logger.Error(nil, msg, keyvals...)
+
+		return
+	}
+	l.logger.Info(msg, keyvals...)
+}
+
+// Export produces the records. Each retry only sends the messages which failed with a retryable error.
+func (e *exporter) Export(ctx context.Context, records []sdklog.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	pending := make([]*kgo.Record, 0, len(records))
+	for i := range records {
+		m, err := e.message(&records[i])
+		if err != nil {
+			e.logger.Error(err, "dropping record")
+			e.metrics.DroppedLogs.WithLabelValues(e.endpoint, "marshal_error").Inc()
+
+			continue
+		}
+		pending = append(pending, m)
+	}
+
+	return retry.Do(ctx, e.retry, e.logger, func(ctx context.Context) error {
+		// Each attempt gives up on the messages not produced within the timeout, they are retried by the next attempt
+		if e.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, e.timeout)
+			defer cancel()
+		}
+
+		var (
+			failed    []*kgo.Record
+			failErr   error
+			rejected  int
+			rejectErr error
+		)
+		for _, result := range e.client.ProduceSync(ctx, pending...) {
+			switch {
+			case result.Err == nil:
+			case isRejected(result.Err):
+				rejected++
+				if rejectErr == nil {
+					rejectErr = fmt.Errorf("topic %s: %w", result.Record.Topic, result.Err)
+				}
+			default:
+				failed = append(failed, result.Record)
+				if failErr == nil {
+					failErr = result.Err
+				}
+			}
+		}
+
+		if rejected > 0 {
+			e.logger.Error(rejectErr, "messages rejected", "count", rejected)
+			e.metrics.DroppedLogs.WithLabelValues(e.endpoint, "rejected").Add(float64(rejected))
+		}
+		if len(failed) > 0 {
+			err := retry.Retryable(fmt.Errorf("%d of %d messages failed: %w", len(failed), len(pending), failErr), 0)
+			pending = failed
+
+			return err
+		}
+
+		return nil
+	})
+}
+
+// isRejected reports whether the brokers rejected the message with an error which is not resolved by retrying,
+// like MESSAGE_TOO_LARGE or TOPIC_AUTHORIZATION_FAILED. Failed authentications are retried, the credentials may change.
+func isRejected(err error) bool {
+	var kafkaErr *kerr.Error
+
+	return errors.As(err, &kafkaErr) && !kafkaErr.Retriable &&
+		!errors.Is(err, kerr.SaslAuthenticationFailed) && !errors.Is(err, kerr.UnsupportedSaslMechanism) &&
+		!errors.Is(err, kerr.IllegalSaslState)
+}
+
+// message encodes the record and selects its topic and key
+func (e *exporter) message(r *sdklog.Record) (*kgo.Record, error) {
+	value, err := e.encode(*r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode record: %w", err)
+	}
+
+	m := &kgo.Record{
+		Topic:     e.topic.topic(r),
+		Value:     value,
+		Timestamp: r.Timestamp(),
+	}
+	if m.Timestamp.IsZero() {
+		m.Timestamp = r.ObservedTimestamp()
+	}
+	if e.key != "" {
+		if key := attributeValue(r, e.key); key != "" {
+			m.Key = []byte(key)
+		}
+	}
+
+	return m, nil
+}
+
+// Shutdown closes the broker connections
+func (e *exporter) Shutdown(context.Context) error {
+	e.client.Close()
+
+	return nil
+}
+
+// ForceFlush is a no-op, the exporter does not buffer records
+func (*exporter) ForceFlush(context.Context) error {
+	return nil
+}
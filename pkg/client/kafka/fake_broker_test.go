@@ -0,0 +1,356 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// API keys of the requests served by the fake broker
+const (
+	produceKey          int16 = 0
+	metadataKey         int16 = 3
+	saslHandshakeKey    int16 = 17
+	apiVersionsKey      int16 = 18
+	initProducerIDKey   int16 = 22
+	saslAuthenticateKey int16 = 36
+)
+
+// Compression codecs of record batches
+const (
+	gzipCodec = 1
+	zstdCodec = 4
+)
+
+// producedMessage is a message received by the fake broker
+type producedMessage struct {
+	Topic     string
+	Partition int32
+	Key       string
+	Value     []byte
+	Timestamp time.Time
+}
+
+// fakeBroker is an in-process single node cluster serving the metadata, SASL and produce requests of the producer
+type fakeBroker struct {
+	listener   net.Listener
+	partitions int32
+
+	mu       sync.Mutex
+	requests map[int16]int
+	messages []producedMessage
+	// produceErrors are the partition error codes returned for the first produce requests
+	produceErrors []int16
+	// topicErrors are the metadata error codes of topics
+	topicErrors map[string]int16
+	// credentials enable SASL PLAIN authentication
+	credentials map[string]string
+	// autoTopicCreation records whether the metadata requests allowed the creation of missing topics
+	autoTopicCreation []bool
+}
+
+func newFakeBroker(partitions int32) *fakeBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+
+	b := &fakeBroker{
+		listener:    listener,
+		partitions:  partitions,
+		requests:    make(map[int16]int),
+		topicErrors: make(map[string]int16),
+	}
+	go b.serve()
+	DeferCleanup(func() { _ = listener.Close() })
+
+	return b
+}
+
+func (b *fakeBroker) addr() string {
+	return b.listener.Addr().String()
+}
+
+// configure changes the behavior of the broker
+func (b *fakeBroker) configure(fn func(b *fakeBroker)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	fn(b)
+}
+
+// requestCount returns the number of requests received with the API key
+func (b *fakeBroker) requestCount(key int16) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.requests[key]
+}
+
+func (b *fakeBroker) producedMessages() []producedMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]producedMessage(nil), b.messages...)
+}
+
+func (b *fakeBroker) serve() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go b.handleConn(conn)
+	}
+}
+
+func (b *fakeBroker) handleConn(conn net.Conn) {
+	defer GinkgoRecover()
+	defer func() { _ = conn.Close() }()
+
+	b.mu.Lock()
+	authenticated := b.credentials == nil
+	b.mu.Unlock()
+	for {
+		size := make([]byte, 4)
+		if _, err := io.ReadFull(conn, size); err != nil {
+			return
+		}
+		data := make([]byte, binary.BigEndian.Uint32(size))
+		if _, err := io.ReadFull(conn, data); err != nil {
+			return
+		}
+
+		// Request header v1: api key, version, correlation ID and nullable client ID
+		key := int16(binary.BigEndian.Uint16(data))         // #nosec G115 -- test data
+		version := int16(binary.BigEndian.Uint16(data[2:])) // #nosec G115 -- test data
+		correlationID := binary.BigEndian.Uint32(data[4:])
+		clientIDLength := int16(binary.BigEndian.Uint16(data[8:])) // #nosec G115 -- test data
+		body := data[10:]
+		if clientIDLength > 0 {
+			body = body[clientIDLength:]
+		}
+
+		req := kmsg.RequestForKey(key)
+		Expect(req).NotTo(BeNil())
+		req.SetVersion(version)
+		if req.IsFlexible() {
+			// Only the ApiVersions request is flexible, its header v2 ends with empty tagged fields
+			Expect(key).To(Equal(apiVersionsKey))
+			Expect(body[0]).To(BeZero())
+			body = body[1:]
+		}
+		Expect(req.ReadFrom(body)).To(Succeed())
+
+		b.mu.Lock()
+		b.requests[key]++
+		var resp kmsg.Response
+		switch r := req.(type) {
+		case *kmsg.ApiVersionsRequest:
+			resp = b.apiVersions()
+		case *kmsg.SASLHandshakeRequest:
+			resp = b.handshake(r)
+		case *kmsg.SASLAuthenticateRequest:
+			resp = b.authenticate(r, &authenticated)
+		case *kmsg.MetadataRequest:
+			Expect(authenticated).To(BeTrue())
+			resp = b.metadata(r)
+		case *kmsg.InitProducerIDRequest:
+			Expect(authenticated).To(BeTrue())
+			resp = b.initProducerID()
+		case *kmsg.ProduceRequest:
+			Expect(authenticated).To(BeTrue())
+			resp = b.produce(r)
+		default:
+			Fail("unexpected request key " + strconv.Itoa(int(key)))
+		}
+		b.mu.Unlock()
+
+		if resp == nil {
+			continue
+		}
+		resp.SetVersion(version)
+		out := binary.BigEndian.AppendUint32(make([]byte, 4), correlationID)
+		out = resp.AppendTo(out)
+		binary.BigEndian.PutUint32(out, uint32(len(out)-4)) // #nosec G115 -- test data
+		if _, err := conn.Write(out); err != nil {
+			return
+		}
+	}
+}
+
+// apiVersions advertises the versions served by the broker. All of them except ApiVersions are not flexible,
+// and the ApiVersions response always has header v0.
+func (b *fakeBroker) apiVersions() kmsg.Response {
+	resp := kmsg.NewPtrApiVersionsResponse()
+	for key, maxVersion := range map[int16]int16{
+		produceKey:          7,
+		metadataKey:         7,
+		saslHandshakeKey:    1,
+		apiVersionsKey:      3,
+		initProducerIDKey:   1,
+		saslAuthenticateKey: 1,
+	} {
+		apiKey := kmsg.NewApiVersionsResponseApiKey()
+		apiKey.ApiKey = key
+		apiKey.MaxVersion = maxVersion
+		resp.ApiKeys = append(resp.ApiKeys, apiKey)
+	}
+
+	return resp
+}
+
+func (b *fakeBroker) handshake(req *kmsg.SASLHandshakeRequest) kmsg.Response {
+	resp := kmsg.NewPtrSASLHandshakeResponse()
+	resp.SupportedMechanisms = []string{"PLAIN"}
+	if req.Mechanism != "PLAIN" {
+		resp.ErrorCode = kerr.UnsupportedSaslMechanism.Code
+	}
+
+	return resp
+}
+
+func (b *fakeBroker) authenticate(req *kmsg.SASLAuthenticateRequest, authenticated *bool) kmsg.Response {
+	resp := kmsg.NewPtrSASLAuthenticateResponse()
+	parts := bytes.Split(req.SASLAuthBytes, []byte{0})
+	if len(parts) != 3 || b.credentials[string(parts[1])] != string(parts[2]) {
+		resp.ErrorCode = kerr.SaslAuthenticationFailed.Code
+		resp.ErrorMessage = kmsg.StringPtr("invalid credentials")
+
+		return resp
+	}
+	*authenticated = true
+
+	return resp
+}
+
+// initProducerID assigns the producer ID of idempotent writes
+func (b *fakeBroker) initProducerID() kmsg.Response {
+	resp := kmsg.NewPtrInitProducerIDResponse()
+	resp.ProducerID = 1
+
+	return resp
+}
+
+func (b *fakeBroker) metadata(req *kmsg.MetadataRequest) kmsg.Response {
+	if len(req.Topics) > 0 {
+		b.autoTopicCreation = append(b.autoTopicCreation, req.AllowAutoTopicCreation)
+	}
+	host, port, err := net.SplitHostPort(b.addr())
+	Expect(err).NotTo(HaveOccurred())
+	portNumber, err := strconv.Atoi(port)
+	Expect(err).NotTo(HaveOccurred())
+
+	resp := kmsg.NewPtrMetadataResponse()
+	broker := kmsg.NewMetadataResponseBroker()
+	broker.NodeID = 1
+	broker.Host = host
+	broker.Port = int32(portNumber) // #nosec G115 -- test data
+	resp.Brokers = append(resp.Brokers, broker)
+
+	for _, requested := range req.Topics {
+		topic := kmsg.NewMetadataResponseTopic()
+		topic.Topic = requested.Topic
+		if code, ok := b.topicErrors[*requested.Topic]; ok {
+			topic.ErrorCode = code
+			resp.Topics = append(resp.Topics, topic)
+
+			continue
+		}
+		for i := range b.partitions {
+			part := kmsg.NewMetadataResponseTopicPartition()
+			part.Partition = i
+			part.Leader = broker.NodeID
+			topic.Partitions = append(topic.Partitions, part)
+		}
+		resp.Topics = append(resp.Topics, topic)
+	}
+
+	return resp
+}
+
+func (b *fakeBroker) produce(req *kmsg.ProduceRequest) kmsg.Response {
+	var code int16
+	if n := b.requests[req.Key()]; n <= len(b.produceErrors) {
+		code = b.produceErrors[n-1]
+	}
+
+	resp := kmsg.NewPtrProduceResponse()
+	for _, topic := range req.Topics {
+		respTopic := kmsg.NewProduceResponseTopic()
+		respTopic.Topic = topic.Topic
+		for _, part := range topic.Partitions {
+			respPart := kmsg.NewProduceResponseTopicPartition()
+			respPart.Partition = part.Partition
+			respPart.ErrorCode = code
+			respTopic.Partitions = append(respTopic.Partitions, respPart)
+			if code == 0 {
+				b.messages = append(b.messages, decodeRecordBatch(topic.Topic, part.Partition, part.Records)...)
+			}
+		}
+		resp.Topics = append(resp.Topics, respTopic)
+	}
+
+	if req.Acks == 0 {
+		return nil
+	}
+
+	return resp
+}
+
+// decodeRecordBatch verifies the checksum and decodes the messages of the record batch
+func decodeRecordBatch(topic string, partition int32, data []byte) []producedMessage {
+	var batch kmsg.RecordBatch
+	Expect(batch.ReadFrom(data)).To(Succeed())
+	Expect(batch.Magic).To(Equal(int8(2)))
+	Expect(int(batch.Length)).To(Equal(len(data) - 12))
+	Expect(uint32(batch.CRC)).To(Equal(crc32.Checksum(data[21:], crc32.MakeTable(crc32.Castagnoli)))) // #nosec G115 -- test data
+
+	records := batch.Records
+	switch batch.Attributes & 0x7 {
+	case gzipCodec:
+		gz, err := gzip.NewReader(bytes.NewReader(records))
+		Expect(err).NotTo(HaveOccurred())
+		records, err = io.ReadAll(gz)
+		Expect(err).NotTo(HaveOccurred())
+	case zstdCodec:
+		decoder, err := zstd.NewReader(nil)
+		Expect(err).NotTo(HaveOccurred())
+		defer decoder.Close()
+		records, err = decoder.DecodeAll(records, nil)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	var messages []producedMessage
+	for range batch.NumRecords {
+		length, n := binary.Varint(records)
+		Expect(n).To(BeNumerically(">", 0))
+		var record kmsg.Record
+		Expect(record.ReadFrom(records[:n+int(length)])).To(Succeed())
+		records = records[n+int(length):]
+
+		messages = append(messages, producedMessage{
+			Topic:     topic,
+			Partition: partition,
+			Key:       string(record.Key),
+			Value:     record.Value,
+			Timestamp: time.UnixMilli(batch.FirstTimestamp + record.TimestampDelta64),
+		})
+	}
+	Expect(records).To(BeEmpty())
+
+	return messages
+}
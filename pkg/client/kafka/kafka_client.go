@@ -0,0 +1,33 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-logr/logr"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
+)
+
+const componentKafkaName = "kafka"
+
+// New creates a new client producing logs to Kafka topics. Records are built like for the OTLP clients and batched by
+// the configured batch processor, the exporter produces each record as message to the topic rendered from its attributes.
+// The endpoint of the client are the configured brokers.
+func New(ctx context.Context, cfg config.Config, logger logr.Logger, m *metrics.FluentBitGardenerMetrics) (*otlp.ExporterClient, error) {
+	if len(cfg.KafkaConfig.Brokers) == 0 {
+		return nil, errors.New("KafkaBrokers is required for the kafka client")
+	}
+
+	return otlp.NewExporterClient(ctx, cfg, logger, m, componentKafkaName, strings.Join(cfg.KafkaConfig.Brokers, ","),
+		func(context.Context, *otlp.Limiter) (sdklog.Exporter, error) {
+			return newExporter(cfg, logger, m)
+		})
+}
@@ -0,0 +1,230 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/twmb/franz-go/pkg/kerr"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp/otlptest"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/log"
+	"github.com/gardener/logging/v1/pkg/metrics"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+var _ = Describe("Kafka client", func() {
+	var (
+		broker      *fakeBroker
+		testMetrics *metrics.FluentBitGardenerMetrics
+	)
+
+	BeforeEach(func() {
+		broker = newFakeBroker(4)
+		testMetrics = metrics.RegisterFluentBitGardenerMetrics(metrics.NewRegistry())
+	})
+
+	parseConfig := func(configMap map[string]any) config.Config {
+		base := map[string]any{
+			"KafkaBrokers":         broker.addr(),
+			"Insecure":             "true",
+			"DQueDir":              GinkgoT().TempDir(),
+			"UseSDKBatchProcessor": "true",
+			"RetryInitialInterval": "10ms",
+			"RetryMaxInterval":     "20ms",
+			"RetryMaxElapsedTime":  "1s",
+		}
+		for k, v := range configMap {
+			base[k] = v
+		}
+		cfg, err := config.ParseConfig(base)
+		Expect(err).NotTo(HaveOccurred())
+
+		return *cfg
+	}
+
+	entry := func(namespace, podUID, log string) types.OutputEntry {
+		return types.OutputEntry{
+			Timestamp: time.Now(),
+			Record: map[string]any{
+				"log": log,
+				"kubernetes": map[string]any{
+					"namespace_name": namespace,
+					"pod_name":       "app",
+					"pod_id":         podUID,
+				},
+			},
+		}
+	}
+
+	// exportEntries builds records like the client and exports them in one batch
+	exportEntries := func(cfg config.Config, entries ...types.OutputEntry) error {
+		records := otlptest.EntryRecords(cfg, entries...)
+
+		exporter, err := newExporter(cfg, log.NewNoop(), testMetrics)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = exporter.Shutdown(context.Background()) }()
+
+		return exporter.Export(context.Background(), records)
+	}
+
+	It("should produce OTLP protobuf messages to the topic of the namespace keyed by the pod UID", func() {
+		cfg := parseConfig(map[string]any{"KafkaTopic": "logs.{k8s.namespace.name}"})
+		client, err := New(context.Background(), cfg, log.NewNoop(), testMetrics)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.Handle(entry("shoot--dev--a", "uid-1", "first"))).To(Succeed())
+		Expect(client.Handle(entry("shoot--dev--a", "uid-1", "second"))).To(Succeed())
+		Expect(client.Handle(entry("shoot--dev--b", "uid-2", "other"))).To(Succeed())
+		client.StopWait()
+
+		messages := broker.producedMessages()
+		Expect(messages).To(HaveLen(3))
+		byBody := make(map[string]producedMessage)
+		for _, m := range messages {
+			var request collogspb.ExportLogsServiceRequest
+			Expect(proto.Unmarshal(m.Value, &request)).To(Succeed())
+			Expect(request.ResourceLogs).To(HaveLen(1))
+			records := request.ResourceLogs[0].ScopeLogs[0].LogRecords
+			Expect(records).To(HaveLen(1))
+			byBody[records[0].Body.GetStringValue()] = m
+		}
+
+		Expect(byBody["first"].Topic).To(Equal("logs.shoot--dev--a"))
+		Expect(byBody["first"].Key).To(Equal("uid-1"))
+		// The Java client hashes the key uid-1 to partition 3 of 4
+		Expect(byBody["first"].Partition).To(Equal(int32(3)))
+		Expect(byBody["second"].Partition).To(Equal(byBody["first"].Partition))
+		Expect(byBody["other"].Topic).To(Equal("logs.shoot--dev--b"))
+		Expect(byBody["other"].Key).To(Equal("uid-2"))
+		Expect(testutil.ToFloat64(testMetrics.OutputClientLogs.WithLabelValues(client.Endpoint()))).To(Equal(3.0))
+	})
+
+	It("should produce gzip compressed OTLP/JSON messages", func() {
+		cfg := parseConfig(map[string]any{"KafkaEncoding": "otlp_json", "Compression": "1", "KafkaKey": ""})
+
+		Expect(exportEntries(cfg, entry("garden", "uid-1", "line"))).To(Succeed())
+
+		messages := broker.producedMessages()
+		Expect(messages).To(HaveLen(1))
+		Expect(messages[0].Topic).To(Equal(config.DefaultKafkaTopic))
+		Expect(messages[0].Key).To(BeEmpty())
+		var decoded map[string]any
+		Expect(json.Unmarshal(messages[0].Value, &decoded)).To(Succeed())
+		Expect(decoded).To(HaveKey("resourceLogs"))
+	})
+
+	It("should produce zstd compressed messages", func() {
+		cfg := parseConfig(map[string]any{"Compression": "zstd"})
+
+		Expect(exportEntries(cfg, entry("garden", "uid-1", "line"))).To(Succeed())
+		Expect(broker.producedMessages()).To(HaveLen(1))
+	})
+
+	It("should write idempotently only when all in-sync replicas acknowledge", func() {
+		Expect(exportEntries(parseConfig(nil), entry("garden", "uid-1", "line"))).To(Succeed())
+		Expect(broker.requestCount(initProducerIDKey)).To(Equal(1))
+
+		Expect(exportEntries(parseConfig(map[string]any{"KafkaRequiredAcks": "1"}), entry("garden", "uid-1", "line"))).To(Succeed())
+		Expect(broker.requestCount(initProducerIDKey)).To(Equal(1))
+		Expect(broker.producedMessages()).To(HaveLen(2))
+	})
+
+	It("should only allow the creation of missing topics when enabled", func() {
+		Expect(exportEntries(parseConfig(nil), entry("garden", "uid-1", "line"))).To(Succeed())
+		broker.configure(func(b *fakeBroker) {
+			Expect(b.autoTopicCreation).NotTo(BeEmpty())
+			Expect(b.autoTopicCreation).NotTo(ContainElement(true))
+			b.autoTopicCreation = nil
+		})
+
+		Expect(exportEntries(parseConfig(map[string]any{"KafkaAllowAutoTopicCreation": "true"}), entry("garden", "uid-1", "line"))).To(Succeed())
+		broker.configure(func(b *fakeBroker) {
+			Expect(b.autoTopicCreation).NotTo(BeEmpty())
+			Expect(b.autoTopicCreation).NotTo(ContainElement(false))
+		})
+	})
+
+	It("should authenticate with SASL PLAIN", func() {
+		broker.configure(func(b *fakeBroker) { b.credentials = map[string]string{"fluent-bit": "secret"} })
+
+		cfg := parseConfig(map[string]any{
+			"KafkaSASLMechanism": "plain",
+			"KafkaSASLUsername":  "fluent-bit",
+			"KafkaSASLPassword":  "secret",
+		})
+		Expect(exportEntries(cfg, entry("garden", "uid-1", "line"))).To(Succeed())
+		Expect(broker.producedMessages()).To(HaveLen(1))
+
+		cfg = parseConfig(map[string]any{
+			"KafkaSASLMechanism":  "PLAIN",
+			"KafkaSASLUsername":   "fluent-bit",
+			"KafkaSASLPassword":   "wrong",
+			"Timeout":             "200ms",
+			"RetryMaxElapsedTime": "50ms",
+		})
+		authentications := broker.requestCount(saslAuthenticateKey)
+		Expect(exportEntries(cfg, entry("garden", "uid-1", "line"))).To(MatchError(ContainSubstring("giving up")))
+		Expect(broker.requestCount(saslAuthenticateKey)).To(BeNumerically(">", authentications))
+		Expect(broker.producedMessages()).To(HaveLen(1))
+	})
+
+	It("should refresh the metadata and retry messages failed with retryable errors", func() {
+		broker.configure(func(b *fakeBroker) { b.produceErrors = []int16{kerr.NotLeaderForPartition.Code} })
+		cfg := parseConfig(nil)
+
+		Expect(exportEntries(cfg, entry("garden", "uid-1", "line"))).To(Succeed())
+
+		Expect(broker.requestCount(produceKey)).To(Equal(2))
+		Expect(broker.requestCount(metadataKey)).To(BeNumerically(">=", 2))
+		Expect(broker.producedMessages()).To(HaveLen(1))
+	})
+
+	It("should drop messages rejected by the broker", func() {
+		broker.configure(func(b *fakeBroker) {
+			b.produceErrors = []int16{kerr.MessageTooLarge.Code}
+			b.topicErrors["logs.invalid"] = kerr.TopicAuthorizationFailed.Code
+		})
+		cfg := parseConfig(map[string]any{"KafkaTopic": "logs.{k8s.namespace.name}"})
+
+		Expect(exportEntries(cfg, entry("garden", "uid-1", "too large"), entry("invalid", "uid-2", "unauthorized"))).To(Succeed())
+
+		Expect(broker.requestCount(produceKey)).To(Equal(1))
+		Expect(broker.producedMessages()).To(BeEmpty())
+		Expect(testutil.ToFloat64(testMetrics.DroppedLogs.WithLabelValues(broker.addr(), "rejected"))).To(Equal(2.0))
+	})
+
+	It("should give up when no broker is reachable", func() {
+		cfg := parseConfig(map[string]any{"KafkaBrokers": "127.0.0.1:1", "Timeout": "100ms", "RetryMaxElapsedTime": "50ms"})
+
+		err := exportEntries(cfg, entry("garden", "uid-1", "line"))
+		Expect(err).To(MatchError(ContainSubstring("giving up")))
+	})
+
+	It("should require brokers", func() {
+		cfg := parseConfig(nil)
+		cfg.KafkaConfig.Brokers = nil
+
+		_, err := New(context.Background(), cfg, log.NewNoop(), testMetrics)
+		Expect(err).To(MatchError("KafkaBrokers is required for the kafka client"))
+	})
+
+	It("should render topics with missing and invalid attribute values", func() {
+		records := otlptest.EntryRecords(parseConfig(nil),
+			entry("a/b c", "", "line"), types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": "line"}})
+
+		template := newTopicTemplate("logs.{k8s.namespace.name}")
+		Expect(template.topic(&records[0])).To(Equal("logs.a_b_c"))
+		Expect(template.topic(&records[1])).To(Equal("logs.unknown"))
+		Expect(newTopicTemplate("logs").topic(&records[0])).To(Equal("logs"))
+	})
+})
@@ -0,0 +1,104 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"regexp"
+
+	"go.opentelemetry.io/otel/attribute"
+	otlplog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+)
+
+const (
+	// maxTopicLength is the maximum length of a Kafka topic name
+	maxTopicLength = 249
+	// missingTopicValue replaces placeholders of attributes missing on the record
+	missingTopicValue = "unknown"
+)
+
+var (
+	// topicPlaceholder matches the {attribute} placeholders of a topic template
+	topicPlaceholder = regexp.MustCompile(`\{([^{}]+)\}`)
+	// invalidTopicChars matches the characters not allowed in topic names
+	invalidTopicChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+)
+
+// topicTemplate renders topic names from record and resource attributes
+type topicTemplate struct {
+	template string
+	keys     []string
+}
+
+func newTopicTemplate(template string) topicTemplate {
+	t := topicTemplate{template: template}
+	for _, match := range topicPlaceholder.FindAllStringSubmatch(template, -1) {
+		t.keys = append(t.keys, match[1])
+	}
+
+	return t
+}
+
+// topic replaces the placeholders with the attribute values of the record.
+// Characters not allowed in topic names are replaced with underscores.
+func (t topicTemplate) topic(r *sdklog.Record) string {
+	if len(t.keys) == 0 {
+		return t.template
+	}
+
+	topic := topicPlaceholder.ReplaceAllStringFunc(t.template, func(placeholder string) string {
+		value := attributeValue(r, placeholder[1:len(placeholder)-1])
+		if value == "" {
+			return missingTopicValue
+		}
+
+		return invalidTopicChars.ReplaceAllString(value, "_")
+	})
+	if len(topic) > maxTopicLength {
+		topic = topic[:maxTopicLength]
+	}
+
+	return topic
+}
+
+// attributeValue returns the string value of the record attribute, or of the resource attribute with the key
+func attributeValue(r *sdklog.Record, key string) string {
+	var value string
+	r.WalkAttributes(func(kv otlplog.KeyValue) bool {
+		if kv.Key != key {
+			return true
+		}
+		if kv.Value.Kind() == otlplog.KindString {
+			value = kv.Value.AsString()
+		} else {
+			value = kv.Value.String()
+		}
+
+		return false
+	})
+	if value != "" {
+		return value
+	}
+
+	if res := r.Resource(); res != nil {
+		if v, ok := res.Set().Value(attribute.Key(key)); ok {
+			return v.Emit()
+		}
+	}
+
+	return ""
+}
+
+// valueEncoder encodes one record as message value
+type valueEncoder func(r sdklog.Record) ([]byte, error)
+
+func protoEncoder(r sdklog.Record) ([]byte, error) {
+	return otlp.MarshalLogsProto([]sdklog.Record{r})
+}
+
+func jsonEncoder(r sdklog.Record) ([]byte, error) {
+	return otlp.MarshalLogsJSON([]sdklog.Record{r})
+}
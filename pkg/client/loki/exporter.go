@@ -0,0 +1,141 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package loki
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/klauspost/compress/snappy"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/client/retry"
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+// maxErrorBodySize limits the response body included in push errors
+const maxErrorBodySize = 1024
+
+// exporter is a blocking sdklog.Exporter pushing records to the Loki push API as snappy compressed protobuf
+type exporter struct {
+	url      string
+	client   *http.Client
+	headers  map[string]string
+	tenantID string
+	retry    *config.RetryConfig
+	streams  streamBuilder
+	logger   logr.Logger
+}
+
+var _ sdklog.Exporter = &exporter{}
+
+// newExporter creates an exporter from the endpoint, TLS, header, timeout, retry and Loki configuration
+func newExporter(cfg config.Config, logger logr.Logger, limiter *otlp.Limiter) *exporter {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.OTLPConfig.TLSConfig != nil {
+		transport.TLSClientConfig = cfg.OTLPConfig.TLSConfig
+	}
+
+	return &exporter{
+		url:      pushURL(cfg),
+		client:   &http.Client{Transport: otlp.NewThrottleTransport(transport, limiter), Timeout: cfg.OTLPConfig.Timeout},
+		headers:  cfg.OTLPConfig.Headers,
+		tenantID: cfg.LokiConfig.TenantID,
+		retry:    cfg.OTLPConfig.RetryConfig,
+		streams: streamBuilder{
+			labels:             cfg.LokiConfig.Labels,
+			structuredMetadata: cfg.LokiConfig.StructuredMetadata,
+		},
+		logger: logger,
+	}
+}
+
+// pushURL returns the EndpointURL if configured, otherwise the push path on the Endpoint.
+// Insecure selects plain HTTP for the Endpoint.
+func pushURL(cfg config.Config) string {
+	if cfg.OTLPConfig.EndpointURL != "" {
+		return cfg.OTLPConfig.EndpointURL
+	}
+
+	scheme := "https://"
+	if cfg.OTLPConfig.Insecure {
+		scheme = "http://"
+	}
+
+	return scheme + strings.TrimSuffix(cfg.OTLPConfig.Endpoint, "/") + cfg.LokiConfig.PushPath
+}
+
+// Export pushes the records grouped into streams, retrying on network errors, 429 and 5xx responses
+func (e *exporter) Export(ctx context.Context, records []sdklog.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	body := snappy.Encode(nil, marshalPushRequest(e.streams.build(records)))
+
+	return retry.Do(ctx, e.retry, e.logger, func(ctx context.Context) error {
+		return e.push(ctx, body)
+	})
+}
+
+func (e *exporter) push(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create push request: %w", err)
+	}
+
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	if e.tenantID != "" {
+		req.Header.Set("X-Scope-OrgID", e.tenantID)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+
+		return retry.Retryable(err, 0)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+
+	err = fmt.Errorf("push to %s failed with status %d: %s", e.url, resp.StatusCode, strings.TrimSpace(string(message)))
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode/100 == 5 {
+		return retry.Retryable(err, retry.After(resp.Header.Get("Retry-After")))
+	}
+	if resp.StatusCode == http.StatusRequestEntityTooLarge {
+		return fmt.Errorf("%w: %w", otlp.ErrBatchTooLarge, err)
+	}
+
+	return err
+}
+
+// Shutdown closes idle connections
+func (e *exporter) Shutdown(context.Context) error {
+	e.client.CloseIdleConnections()
+
+	return nil
+}
+
+// ForceFlush is a no-op, the exporter does not buffer records
+func (*exporter) ForceFlush(context.Context) error {
+	return nil
+}
@@ -0,0 +1,340 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package loki
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp/otlptest"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/log"
+	"github.com/gardener/logging/v1/pkg/metrics"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+// pushedStream is a decoded stream of a push request
+type pushedStream struct {
+	Labels  string
+	Entries []pushedEntry
+}
+
+// pushedEntry is a decoded entry of a push request
+type pushedEntry struct {
+	Timestamp time.Time
+	Line      string
+	Metadata  map[string]string
+}
+
+// lokiStandIn records the push requests received by the httptest server
+type lokiStandIn struct {
+	server   *httptest.Server
+	mu       sync.Mutex
+	requests []*http.Request
+	streams  []pushedStream
+	// statuses are returned for the first requests, later requests succeed
+	statuses []int
+}
+
+func newLokiStandIn() *lokiStandIn {
+	s := &lokiStandIn{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer GinkgoRecover()
+
+		body, err := io.ReadAll(r.Body)
+		Expect(err).NotTo(HaveOccurred())
+		data, err := snappy.Decode(nil, body)
+		Expect(err).NotTo(HaveOccurred())
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.requests = append(s.requests, r)
+		if len(s.requests) <= len(s.statuses) {
+			w.WriteHeader(s.statuses[len(s.requests)-1])
+
+			return
+		}
+		s.streams = append(s.streams, decodePushRequest(data)...)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	DeferCleanup(s.server.Close)
+
+	return s
+}
+
+func (s *lokiStandIn) pushedStreams() []pushedStream {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]pushedStream(nil), s.streams...)
+}
+
+func decodePushRequest(data []byte) []pushedStream {
+	var streams []pushedStream
+	forEachField(data, func(num protowire.Number, v []byte) {
+		Expect(num).To(Equal(protowire.Number(1)))
+		var s pushedStream
+		forEachField(v, func(num protowire.Number, v []byte) {
+			switch num {
+			case 1:
+				s.Labels = string(v)
+			case 2:
+				s.Entries = append(s.Entries, decodeEntry(v))
+			default:
+				Fail("unexpected stream field")
+			}
+		})
+		streams = append(streams, s)
+	})
+
+	return streams
+}
+
+func decodeEntry(data []byte) pushedEntry {
+	var e pushedEntry
+	forEachField(data, func(num protowire.Number, v []byte) {
+		switch num {
+		case 1:
+			var seconds, nanos uint64
+			for len(v) > 0 {
+				n, _, l := protowire.ConsumeTag(v)
+				v = v[l:]
+				x, l := protowire.ConsumeVarint(v)
+				Expect(l).To(BeNumerically(">", 0))
+				v = v[l:]
+				if n == 1 {
+					seconds = x
+				} else {
+					nanos = x
+				}
+			}
+			e.Timestamp = time.Unix(int64(seconds), int64(nanos)) // #nosec G115 -- test data
+		case 2:
+			e.Line = string(v)
+		case 3:
+			var name, value string
+			forEachField(v, func(num protowire.Number, v []byte) {
+				if num == 1 {
+					name = string(v)
+				} else {
+					value = string(v)
+				}
+			})
+			if e.Metadata == nil {
+				e.Metadata = map[string]string{}
+			}
+			e.Metadata[name] = value
+		default:
+			Fail("unexpected entry field")
+		}
+	})
+
+	return e
+}
+
+// forEachField calls fn with the length delimited fields of the message
+func forEachField(data []byte, fn func(protowire.Number, []byte)) {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		Expect(n).To(BeNumerically(">", 0))
+		Expect(typ).To(Equal(protowire.BytesType))
+		data = data[n:]
+		v, n := protowire.ConsumeBytes(data)
+		Expect(n).To(BeNumerically(">", 0))
+		data = data[n:]
+		fn(num, v)
+	}
+}
+
+var _ = Describe("Loki client", func() {
+	var (
+		standIn     *lokiStandIn
+		testMetrics *metrics.FluentBitGardenerMetrics
+	)
+
+	BeforeEach(func() {
+		standIn = newLokiStandIn()
+		testMetrics = metrics.RegisterFluentBitGardenerMetrics(metrics.NewRegistry())
+	})
+
+	parseConfig := func(configMap map[string]any) config.Config {
+		base := map[string]any{
+			"EndpointURL":          standIn.server.URL + "/vali/api/v1/push",
+			"DQueDir":              GinkgoT().TempDir(),
+			"RetryInitialInterval": "10ms",
+			"RetryMaxInterval":     "20ms",
+			"RetryMaxElapsedTime":  "1s",
+		}
+		for k, v := range configMap {
+			base[k] = v
+		}
+		cfg, err := config.ParseConfig(base)
+		Expect(err).NotTo(HaveOccurred())
+
+		return *cfg
+	}
+
+	entry := func(pod, log string, ts time.Time) types.OutputEntry {
+		return types.OutputEntry{
+			Timestamp: ts,
+			Record: map[string]any{
+				"log":    log,
+				"stream": "stdout",
+				"kubernetes": map[string]any{
+					"namespace_name": "shoot--dev--test",
+					"pod_name":       pod,
+					"container_name": "app",
+					"host":           "node-1",
+				},
+			},
+		}
+	}
+
+	// exportEntries builds records like the client and exports them in one batch
+	exportEntries := func(cfg config.Config, entries ...types.OutputEntry) error {
+		records := otlptest.EntryRecords(cfg, entries...)
+
+		return newExporter(cfg, log.NewNoop(), nil).Export(context.Background(), records)
+	}
+
+	It("should push snappy compressed protobuf streams labeled from the kubernetes metadata", func() {
+		cfg := parseConfig(map[string]any{"LokiTenantID": "dev", "Headers": `{"Authorization": "Bearer token"}`})
+		now := time.Now()
+
+		Expect(exportEntries(cfg,
+			entry("app-0", "second", now.Add(time.Second)),
+			entry("app-1", "other pod", now),
+			entry("app-0", "first", now),
+		)).To(Succeed())
+
+		Expect(standIn.requests).To(HaveLen(1))
+		req := standIn.requests[0]
+		Expect(req.URL.Path).To(Equal("/vali/api/v1/push"))
+		Expect(req.Header.Get("Content-Type")).To(Equal("application/x-protobuf"))
+		Expect(req.Header.Get("Content-Encoding")).To(Equal("snappy"))
+		Expect(req.Header.Get("X-Scope-OrgID")).To(Equal("dev"))
+		Expect(req.Header.Get("Authorization")).To(Equal("Bearer token"))
+
+		streams := standIn.pushedStreams()
+		Expect(streams).To(HaveLen(2))
+		Expect(streams[0].Labels).To(Equal(`{container_name="app", namespace_name="shoot--dev--test", nodename="node-1", pod_name="app-0"}`))
+		Expect(streams[0].Entries).To(HaveLen(2))
+		Expect(streams[0].Entries[0].Line).To(Equal("first"))
+		Expect(streams[0].Entries[0].Timestamp.Equal(now)).To(BeTrue())
+		Expect(streams[0].Entries[0].Metadata).To(BeEmpty())
+		Expect(streams[0].Entries[1].Line).To(Equal("second"))
+		Expect(streams[1].Labels).To(ContainSubstring(`pod_name="app-1"`))
+	})
+
+	It("should apply the configured labels and send structured metadata", func() {
+		cfg := parseConfig(map[string]any{
+			"LokiLabels":             `{"k8s.namespace.name": "namespace", "stream": "stream"}`,
+			"LokiStructuredMetadata": "true",
+		})
+
+		Expect(exportEntries(cfg, entry("app-0", "line", time.Now()))).To(Succeed())
+
+		streams := standIn.pushedStreams()
+		Expect(streams).To(HaveLen(1))
+		Expect(streams[0].Labels).To(Equal(`{namespace="shoot--dev--test", stream="stdout"}`))
+		Expect(streams[0].Entries[0].Metadata).To(HaveKeyWithValue("k8s.pod.name", "app-0"))
+		Expect(streams[0].Entries[0].Metadata).NotTo(HaveKey("stream"))
+	})
+
+	It("should take labels from the resource with resource grouping", func() {
+		cfg := parseConfig(map[string]any{"ResourceGrouping": "true", "UseSDKBatchProcessor": "true"})
+		client, err := New(context.Background(), cfg, log.NewNoop(), testMetrics)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.Handle(entry("app-0", "line", time.Now()))).To(Succeed())
+		client.StopWait()
+
+		streams := standIn.pushedStreams()
+		Expect(streams).To(HaveLen(1))
+		Expect(streams[0].Labels).To(Equal(`{container_name="app", namespace_name="shoot--dev--test", nodename="node-1", pod_name="app-0"}`))
+		Expect(testutil.ToFloat64(testMetrics.OutputClientLogs.WithLabelValues(client.Endpoint()))).To(Equal(1.0))
+	})
+
+	It("should use a fallback label for records without labels", func() {
+		cfg := parseConfig(nil)
+
+		Expect(exportEntries(cfg, types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": "no metadata"}})).To(Succeed())
+
+		streams := standIn.pushedStreams()
+		Expect(streams).To(HaveLen(1))
+		Expect(streams[0].Labels).To(Equal(`{job="fluent-bit"}`))
+		Expect(streams[0].Entries[0].Line).To(Equal("no metadata"))
+	})
+
+	It("should retry server errors and rate limiting", func() {
+		standIn.statuses = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}
+		cfg := parseConfig(nil)
+
+		Expect(exportEntries(cfg, entry("app-0", "line", time.Now()))).To(Succeed())
+
+		Expect(standIn.requests).To(HaveLen(3))
+		Expect(standIn.pushedStreams()).To(HaveLen(1))
+	})
+
+	It("should lower an adaptive limiter when the push is rate limited", func() {
+		standIn.statuses = []int{http.StatusTooManyRequests}
+		cfg := parseConfig(map[string]any{
+			"UseSDKBatchProcessor":   "true",
+			"ThrottleEnabled":        "true",
+			"ThrottleRequestsPerSec": "100",
+			"ThrottleAdaptive":       "true",
+		})
+		client, err := New(context.Background(), cfg, log.NewNoop(), testMetrics)
+		Expect(err).NotTo(HaveOccurred())
+		limit := testMetrics.ThrottleLimit.WithLabelValues(client.Endpoint(), "records")
+		Expect(testutil.ToFloat64(limit)).To(Equal(100.0))
+
+		Expect(client.Handle(entry("app-0", "line", time.Now()))).To(Succeed())
+		client.StopWait()
+
+		Expect(standIn.pushedStreams()).To(HaveLen(1))
+		Expect(testutil.ToFloat64(limit)).To(Equal(50.0))
+	})
+
+	It("should not retry client errors", func() {
+		standIn.statuses = []int{http.StatusBadRequest}
+		cfg := parseConfig(nil)
+
+		err := exportEntries(cfg, entry("app-0", "line", time.Now()))
+		Expect(err).To(MatchError(ContainSubstring("status 400")))
+		Expect(standIn.requests).To(HaveLen(1))
+	})
+
+	It("should give up after the maximum retry time", func() {
+		standIn.statuses = []int{500, 500, 500, 500, 500, 500, 500, 500, 500, 500}
+		cfg := parseConfig(map[string]any{"RetryMaxElapsedTime": "50ms"})
+
+		err := exportEntries(cfg, entry("app-0", "line", time.Now()))
+		Expect(err).To(MatchError(ContainSubstring("giving up")))
+		Expect(len(standIn.requests)).To(BeNumerically("<", 10))
+	})
+
+	It("should build the push URL from the endpoint", func() {
+		cfg := config.Config{
+			OTLPConfig: config.OTLPConfig{Endpoint: "vali.garden:3100", Insecure: true},
+			LokiConfig: config.DefaultLokiConfig,
+		}
+		Expect(pushURL(cfg)).To(Equal("http://vali.garden:3100/loki/api/v1/push"))
+
+		cfg.OTLPConfig.Insecure = false
+		cfg.LokiConfig.PushPath = "/vali/api/v1/push"
+		Expect(pushURL(cfg)).To(Equal("https://vali.garden:3100/vali/api/v1/push"))
+	})
+})
@@ -0,0 +1,226 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package loki
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	otlplog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+)
+
+// fallbackLabel is the label of streams whose records have none of the configured label attributes,
+// since Loki rejects streams without labels
+var fallbackLabel = labelPair{name: "job", value: "fluent-bit"}
+
+// labelPair is a label or structured metadata name and value
+type labelPair struct {
+	name  string
+	value string
+}
+
+// stream holds the entries of one label set
+type stream struct {
+	labels  string
+	entries []entry
+}
+
+// entry is a log line of a stream
+type entry struct {
+	timestamp time.Time
+	line      string
+	metadata  []labelPair
+}
+
+// streamBuilder groups records into streams by their label set
+type streamBuilder struct {
+	labels             map[string]string
+	structuredMetadata bool
+}
+
+// build groups the records into streams, ordered by their first record.
+// Entries of a stream are sorted by their timestamp, since older Loki and Vali versions reject out of order entries.
+func (b *streamBuilder) build(records []sdklog.Record) []*stream {
+	streams := make([]*stream, 0, 1)
+	index := make(map[string]*stream)
+
+	for i := range records {
+		labels, e := b.entry(&records[i])
+		s, ok := index[labels]
+		if !ok {
+			s = &stream{labels: labels}
+			index[labels] = s
+			streams = append(streams, s)
+		}
+		s.entries = append(s.entries, e)
+	}
+
+	for _, s := range streams {
+		sort.SliceStable(s.entries, func(i, j int) bool {
+			return s.entries[i].timestamp.Before(s.entries[j].timestamp)
+		})
+	}
+
+	return streams
+}
+
+// entry returns the label set and the entry of the record.
+// Labels are taken from the record attributes and the resource attributes, record attributes take precedence.
+func (b *streamBuilder) entry(r *sdklog.Record) (string, entry) {
+	found := make(map[string]string, len(b.labels))
+	var metadata []labelPair
+
+	r.WalkAttributes(func(kv otlplog.KeyValue) bool {
+		if name, ok := b.labels[kv.Key]; ok {
+			found[name] = valueString(kv.Value)
+		} else if b.structuredMetadata {
+			metadata = append(metadata, labelPair{name: kv.Key, value: valueString(kv.Value)})
+		}
+
+		return true
+	})
+
+	if res := r.Resource(); res != nil {
+		iter := res.Iter()
+		for iter.Next() {
+			kv := iter.Attribute()
+			name, ok := b.labels[string(kv.Key)]
+			if _, exists := found[name]; !ok || exists {
+				continue
+			}
+			found[name] = kv.Value.Emit()
+		}
+	}
+
+	if b.structuredMetadata {
+		if traceID := r.TraceID(); traceID.IsValid() {
+			metadata = append(metadata, labelPair{name: "trace_id", value: traceID.String()})
+		}
+		if spanID := r.SpanID(); spanID.IsValid() {
+			metadata = append(metadata, labelPair{name: "span_id", value: spanID.String()})
+		}
+	}
+
+	timestamp := r.Timestamp()
+	if timestamp.IsZero() {
+		timestamp = r.ObservedTimestamp()
+	}
+
+	return formatLabels(found), entry{timestamp: timestamp, line: valueString(r.Body()), metadata: metadata}
+}
+
+// formatLabels returns the label set in the Prometheus text format, e.g. {namespace_name="garden", pod_name="vali-0"}
+func formatLabels(labels map[string]string) string {
+	pairs := make([]labelPair, 0, len(labels))
+	for name, value := range labels {
+		if value != "" {
+			pairs = append(pairs, labelPair{name: name, value: value})
+		}
+	}
+	if len(pairs) == 0 {
+		pairs = append(pairs, fallbackLabel)
+	}
+	slices.SortFunc(pairs, func(a, b labelPair) int { return strings.Compare(a.name, b.name) })
+
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, p := range pairs {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(p.name)
+		sb.WriteByte('=')
+		sb.WriteString(strconv.Quote(p.value))
+	}
+	sb.WriteByte('}')
+
+	return sb.String()
+}
+
+// valueString returns string values as is and encodes other values as JSON
+func valueString(v otlplog.Value) string {
+	if v.Kind() == otlplog.KindString {
+		return v.AsString()
+	}
+
+	data, err := json.Marshal(otlp.ValueToAny(v))
+	if err != nil {
+		return v.String()
+	}
+
+	return string(data)
+}
+
+// The functions below encode the logproto.PushRequest message of the Loki push API:
+//
+//	message PushRequest { repeated Stream streams = 1; }
+//	message Stream { string labels = 1; repeated Entry entries = 2; }
+//	message Entry { google.protobuf.Timestamp timestamp = 1; string line = 2; repeated LabelPair structuredMetadata = 3; }
+//	message LabelPair { string name = 1; string value = 2; }
+
+// marshalPushRequest encodes the streams as protobuf PushRequest
+func marshalPushRequest(streams []*stream) []byte {
+	var buf []byte
+	for _, s := range streams {
+		buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, marshalStream(s))
+	}
+
+	return buf
+}
+
+func marshalStream(s *stream) []byte {
+	buf := protowire.AppendTag(nil, 1, protowire.BytesType)
+	buf = protowire.AppendString(buf, s.labels)
+	for i := range s.entries {
+		buf = protowire.AppendTag(buf, 2, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, marshalEntry(&s.entries[i]))
+	}
+
+	return buf
+}
+
+func marshalEntry(e *entry) []byte {
+	buf := protowire.AppendTag(nil, 1, protowire.BytesType)
+	buf = protowire.AppendBytes(buf, marshalTimestamp(e.timestamp))
+	buf = protowire.AppendTag(buf, 2, protowire.BytesType)
+	buf = protowire.AppendString(buf, e.line)
+	for _, m := range e.metadata {
+		buf = protowire.AppendTag(buf, 3, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, marshalLabelPair(m))
+	}
+
+	return buf
+}
+
+func marshalTimestamp(t time.Time) []byte {
+	var buf []byte
+	if seconds := t.Unix(); seconds != 0 {
+		buf = protowire.AppendTag(buf, 1, protowire.VarintType)
+		buf = protowire.AppendVarint(buf, uint64(seconds)) // #nosec G115 -- negative seconds are encoded as two's complement like protobuf int64
+	}
+	if nanos := t.Nanosecond(); nanos != 0 {
+		buf = protowire.AppendTag(buf, 2, protowire.VarintType)
+		buf = protowire.AppendVarint(buf, uint64(nanos)) // #nosec G115 -- nanoseconds are within [0, 1e9)
+	}
+
+	return buf
+}
+
+func marshalLabelPair(p labelPair) []byte {
+	buf := protowire.AppendTag(nil, 1, protowire.BytesType)
+	buf = protowire.AppendString(buf, p.name)
+	buf = protowire.AppendTag(buf, 2, protowire.BytesType)
+	buf = protowire.AppendString(buf, p.value)
+
+	return buf
+}
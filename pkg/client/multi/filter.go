@@ -0,0 +1,79 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package multi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+// matches reports whether the record matches the filter, a nil filter matches all records
+func matches(filter *config.Filter, record map[string]any) bool {
+	if filter == nil {
+		return true
+	}
+
+	for _, group := range filter.Groups {
+		if matchesAll(group, record) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesAll reports whether the record matches all conditions
+func matchesAll(conditions []config.FilterCondition, record map[string]any) bool {
+	for _, condition := range conditions {
+		value, ok := fieldValue(record, condition.Field)
+
+		var matched bool
+		switch condition.Operator {
+		case config.FilterEqual:
+			matched = ok && value == condition.Value
+		case config.FilterNotEqual:
+			matched = !ok || value != condition.Value
+		case config.FilterMatch:
+			matched = ok && condition.Regex.MatchString(value)
+		case config.FilterNotMatch:
+			matched = !ok || !condition.Regex.MatchString(value)
+		default:
+			matched = false
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fieldValue returns the string value of the field, nested fields are addressed by their path separated with "."
+func fieldValue(record map[string]any, path string) (string, bool) {
+	m := record
+	for {
+		if value, ok := m[path]; ok {
+			switch v := value.(type) {
+			case string:
+				return v, true
+			case []byte:
+				return string(v), true
+			default:
+				return fmt.Sprintf("%v", v), true
+			}
+		}
+
+		head, rest, found := strings.Cut(path, ".")
+		if !found {
+			return "", false
+		}
+		next, ok := m[head].(map[string]any)
+		if !ok {
+			return "", false
+		}
+		m, path = next, rest
+	}
+}
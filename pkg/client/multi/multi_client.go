@@ -0,0 +1,140 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package multi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+
+	"github.com/gardener/logging/v1/pkg/client/api"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+const componentMultiName = "multi"
+
+// Results of the records handled by a destination, the values of the result label of DestinationLogs
+const (
+	resultSent     = "sent"
+	resultFiltered = "filtered"
+	resultFailed   = "failed"
+)
+
+// Factory creates the client of a destination from its configuration
+type Factory func(ctx context.Context, cfg config.Config) (api.Output, error)
+
+// destination is a named client receiving the records matching its filter
+type destination struct {
+	name   string
+	filter *config.Filter
+	client api.Output
+}
+
+// Client is an implementation of Output that fans out records to several destination clients.
+// The destinations are isolated, a failing destination does not prevent sending to the others.
+type Client struct {
+	logger       logr.Logger
+	destinations []destination
+	metrics      *metrics.FluentBitGardenerMetrics
+}
+
+var _ api.Output = &Client{}
+
+// New creates a multi client with a client per configured destination created by newClient.
+// Each destination gets its own queue, named after the queue of the multi client and the destination.
+func New(ctx context.Context, cfg config.Config, logger logr.Logger, m *metrics.FluentBitGardenerMetrics, newClient Factory) (*Client, error) {
+	if len(cfg.MultiConfig.Destinations) == 0 {
+		return nil, errors.New("Destinations are required for the multi client")
+	}
+
+	client := &Client{
+		logger:  logger,
+		metrics: m,
+	}
+	for _, d := range cfg.MultiConfig.Destinations {
+		destCfg := *d.Config
+		destCfg.OTLPConfig.DQueConfig.DQueName = cfg.OTLPConfig.DQueConfig.DQueName + "-" + d.Name
+
+		output, err := newClient(ctx, destCfg)
+		if err != nil {
+			client.Stop()
+
+			return nil, fmt.Errorf("failed to create client of destination %q: %w", d.Name, err)
+		}
+		client.destinations = append(client.destinations, destination{name: d.Name, filter: d.Filter, client: output})
+	}
+
+	client.logger.V(1).Info(fmt.Sprintf("%s created", componentMultiName),
+		"destinations", len(client.destinations), "endpoint", client.Endpoint())
+
+	return client, nil
+}
+
+// Handle sends the log entry to all destinations whose filter matches the record.
+// It only fails if all matching destinations failed, the failures of single destinations are logged and counted.
+func (c *Client) Handle(entry types.OutputEntry) error {
+	var errs []error
+	matched := 0
+	for _, d := range c.destinations {
+		if !matches(d.filter, entry.Record) {
+			c.metrics.DestinationLogs.WithLabelValues(d.name, resultFiltered).Inc()
+
+			continue
+		}
+		matched++
+
+		if err := d.client.Handle(entry); err != nil {
+			c.metrics.DestinationLogs.WithLabelValues(d.name, resultFailed).Inc()
+			c.logger.V(2).Info("failed to send record to destination", "destination", d.name, "error", err.Error())
+			errs = append(errs, fmt.Errorf("destination %q: %w", d.name, err))
+
+			continue
+		}
+		c.metrics.DestinationLogs.WithLabelValues(d.name, resultSent).Inc()
+	}
+
+	if matched > 0 && len(errs) == matched {
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
+
+// Stop shuts down all destination clients immediately
+func (c *Client) Stop() {
+	c.logger.V(2).Info(fmt.Sprintf("stopping %s", componentMultiName))
+	c.each(api.Output.Stop)
+}
+
+// StopWait stops all destination clients, waiting for them to send their saved logs
+func (c *Client) StopWait() {
+	c.logger.V(2).Info(fmt.Sprintf("stopping %s with wait", componentMultiName))
+	c.each(api.Output.StopWait)
+}
+
+// Endpoint returns the comma separated endpoints of the destinations.
+// They are read on each call, the active endpoint of a destination with failover endpoints changes.
+func (c *Client) Endpoint() string {
+	endpoints := make([]string, 0, len(c.destinations))
+	for _, d := range c.destinations {
+		endpoints = append(endpoints, d.client.Endpoint())
+	}
+
+	return strings.Join(endpoints, ",")
+}
+
+// each calls stop for all destination clients concurrently, a slow destination does not delay stopping the others
+func (c *Client) each(stop func(api.Output)) {
+	var wg sync.WaitGroup
+	for _, d := range c.destinations {
+		wg.Go(func() { stop(d.client) })
+	}
+	wg.Wait()
+}
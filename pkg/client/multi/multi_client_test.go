@@ -0,0 +1,241 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package multi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/gardener/logging/v1/pkg/client/api"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/log"
+	"github.com/gardener/logging/v1/pkg/metrics"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+var _ = Describe("Multi client", func() {
+	var (
+		testMetrics *metrics.FluentBitGardenerMetrics
+		outputs     map[string]*fakeOutput
+		factory     Factory
+	)
+
+	BeforeEach(func() {
+		testMetrics = metrics.RegisterFluentBitGardenerMetrics(metrics.NewRegistry())
+		outputs = map[string]*fakeOutput{}
+		factory = func(_ context.Context, cfg config.Config) (api.Output, error) {
+			output := &fakeOutput{endpoint: cfg.OTLPConfig.Endpoint, queue: cfg.OTLPConfig.DQueConfig.DQueName}
+			outputs[cfg.OTLPConfig.Endpoint] = output
+
+			return output, nil
+		}
+	})
+
+	parseConfig := func(destinations string) config.Config {
+		cfg, err := config.ParseConfig(map[string]any{
+			"SeedType":     "multi",
+			"DQueName":     "seed",
+			"Destinations": destinations,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		return *cfg
+	}
+
+	entry := func(namespace, level string) types.OutputEntry {
+		return types.OutputEntry{
+			Timestamp: time.Now(),
+			Record: map[string]any{
+				"log":   "line",
+				"level": level,
+				"kubernetes": map[string]any{
+					"namespace_name": namespace,
+				},
+			},
+		}
+	}
+
+	destinationLogs := func(name, result string) float64 {
+		return testutil.ToFloat64(testMetrics.DestinationLogs.WithLabelValues(name, result))
+	}
+
+	It("should send the records to the destinations matching the filters", func() {
+		cfg := parseConfig(`[
+			{"name": "vali", "type": "loki", "Endpoint": "vali"},
+			{"name": "shoots", "type": "otlp_grpc", "Endpoint": "otlp", "filter": "kubernetes.namespace_name =~ ^shoot-- && level != debug"}
+		]`)
+		client, err := New(context.Background(), cfg, log.NewNoop(), testMetrics, factory)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.Endpoint()).To(Equal("vali,otlp"))
+		outputs["otlp"].endpoint = "otlp-failover"
+		Expect(client.Endpoint()).To(Equal("vali,otlp-failover"))
+		Expect(outputs["vali"].queue).To(Equal("seed-vali"))
+		Expect(outputs["otlp"].queue).To(Equal("seed-shoots"))
+
+		Expect(client.Handle(entry("garden", "info"))).To(Succeed())
+		Expect(client.Handle(entry("shoot--dev--a", "info"))).To(Succeed())
+		Expect(client.Handle(entry("shoot--dev--a", "debug"))).To(Succeed())
+
+		Expect(outputs["vali"].handled()).To(HaveLen(3))
+		Expect(outputs["otlp"].handled()).To(HaveLen(1))
+		Expect(outputs["otlp"].handled()[0].Record["kubernetes"]).To(HaveKeyWithValue("namespace_name", "shoot--dev--a"))
+		Expect(destinationLogs("vali", resultSent)).To(Equal(3.0))
+		Expect(destinationLogs("shoots", resultSent)).To(Equal(1.0))
+		Expect(destinationLogs("shoots", resultFiltered)).To(Equal(2.0))
+	})
+
+	It("should isolate failing destinations", func() {
+		cfg := parseConfig(`[{"name": "old", "type": "loki", "Endpoint": "old"}, {"name": "new", "type": "otlp_grpc", "Endpoint": "new"}]`)
+		client, err := New(context.Background(), cfg, log.NewNoop(), testMetrics, factory)
+		Expect(err).NotTo(HaveOccurred())
+		outputs["new"].setErr(errors.New("queue full"))
+
+		Expect(client.Handle(entry("garden", "info"))).To(Succeed())
+		Expect(outputs["old"].handled()).To(HaveLen(1))
+		Expect(destinationLogs("old", resultSent)).To(Equal(1.0))
+		Expect(destinationLogs("new", resultFailed)).To(Equal(1.0))
+
+		outputs["old"].setErr(errors.New("connection refused"))
+		err = client.Handle(entry("garden", "info"))
+		Expect(err).To(MatchError(ContainSubstring(`destination "old": connection refused`)))
+		Expect(err).To(MatchError(ContainSubstring(`destination "new": queue full`)))
+	})
+
+	It("should not fail for records matching no destination", func() {
+		cfg := parseConfig(`[{"name": "audit", "type": "syslog", "Endpoint": "audit", "filter": "kubernetes.namespace_name == kube-system"}]`)
+		client, err := New(context.Background(), cfg, log.NewNoop(), testMetrics, factory)
+		Expect(err).NotTo(HaveOccurred())
+		outputs["audit"].setErr(errors.New("unreachable"))
+
+		Expect(client.Handle(entry("garden", "info"))).To(Succeed())
+		Expect(destinationLogs("audit", resultFiltered)).To(Equal(1.0))
+	})
+
+	It("should stop the created destinations if a destination cannot be created", func() {
+		cfg := parseConfig(`[{"name": "a", "type": "loki", "Endpoint": "a"}, {"name": "b", "type": "loki", "Endpoint": "b"}]`)
+		failing := func(ctx context.Context, cfg config.Config) (api.Output, error) {
+			if cfg.OTLPConfig.Endpoint == "b" {
+				return nil, errors.New("invalid endpoint")
+			}
+
+			return factory(ctx, cfg)
+		}
+
+		_, err := New(context.Background(), cfg, log.NewNoop(), testMetrics, failing)
+		Expect(err).To(MatchError(ContainSubstring(`failed to create client of destination "b": invalid endpoint`)))
+		Expect(outputs["a"].stopped()).To(Equal("stop"))
+	})
+
+	It("should stop all destinations", func() {
+		cfg := parseConfig(`[{"name": "a", "type": "loki", "Endpoint": "a"}, {"name": "b", "type": "loki", "Endpoint": "b"}]`)
+		client, err := New(context.Background(), cfg, log.NewNoop(), testMetrics, factory)
+		Expect(err).NotTo(HaveOccurred())
+
+		client.StopWait()
+		Expect(outputs["a"].stopped()).To(Equal("stopwait"))
+		Expect(outputs["b"].stopped()).To(Equal("stopwait"))
+	})
+
+	DescribeTable("should match records against filter expressions",
+		func(expression string, expected bool) {
+			filter, err := config.ParseFilter(expression)
+			Expect(err).NotTo(HaveOccurred())
+			record := map[string]any{
+				"level":   "error",
+				"count":   3,
+				"raw":     []byte("bytes"),
+				"k8s.pod": "flat",
+				"kubernetes": map[string]any{
+					"namespace_name": "shoot--dev--a",
+					"labels":         map[string]any{"app": "etcd"},
+				},
+			}
+			Expect(matches(filter, record)).To(Equal(expected))
+		},
+		Entry("equal", "level == error", true),
+		Entry("not equal", "level != error", false),
+		Entry("nested field", "kubernetes.labels.app == etcd", true),
+		Entry("field name containing a dot", "k8s.pod == flat", true),
+		Entry("number", "count == 3", true),
+		Entry("bytes", "raw =~ ^byt", true),
+		Entry("regex", "kubernetes.namespace_name =~ ^shoot--", true),
+		Entry("not regex", "kubernetes.namespace_name !~ ^shoot--", false),
+		Entry("missing field equal", "missing == x", false),
+		Entry("missing field not equal", "missing != x", true),
+		Entry("missing field not regex", "missing !~ x", true),
+		Entry("and", "level == error && count == 4", false),
+		Entry("or", "level == info || count == 3", true),
+		Entry("and binds stronger", "level == info && count == 3 || raw == bytes", true),
+	)
+})
+
+// fakeOutput is an output recording the handled entries and how it was stopped
+type fakeOutput struct {
+	endpoint string
+	queue    string
+
+	mu      sync.Mutex
+	entries []types.OutputEntry
+	err     error
+	stop    string
+}
+
+var _ api.Output = &fakeOutput{}
+
+func (o *fakeOutput) Handle(entry types.OutputEntry) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.err != nil {
+		return o.err
+	}
+	o.entries = append(o.entries, entry)
+
+	return nil
+}
+
+func (o *fakeOutput) Stop() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.stop = "stop"
+}
+
+func (o *fakeOutput) StopWait() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.stop = "stopwait"
+}
+
+func (o *fakeOutput) Endpoint() string {
+	return o.endpoint
+}
+
+func (o *fakeOutput) setErr(err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.err = err
+}
+
+func (o *fakeOutput) handled() []types.OutputEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return append([]types.OutputEntry(nil), o.entries...)
+}
+
+func (o *fakeOutput) stopped() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.stop
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package opensearch
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otlplog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+)
+
+// document is the indexed representation of a log record, following the field names of the OTLP/JSON encoding
+type document struct {
+	Timestamp            string         `json:"@timestamp"`
+	ObservedTimestamp    string         `json:"observedTimestamp,omitempty"`
+	Body                 any            `json:"body,omitempty"`
+	SeverityText         string         `json:"severityText,omitempty"`
+	SeverityNumber       int            `json:"severityNumber,omitempty"`
+	TraceID              string         `json:"traceId,omitempty"`
+	SpanID               string         `json:"spanId,omitempty"`
+	Attributes           map[string]any `json:"attributes,omitempty"`
+	Resource             map[string]any `json:"resource,omitempty"`
+	InstrumentationScope *scope         `json:"instrumentationScope,omitempty"`
+}
+
+type scope struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// bulkItem is a document with its bulk create action
+type bulkItem struct {
+	action []byte
+	source []byte
+}
+
+// newBulkItem encodes the record as document and the create action into the index of the record date.
+// The document ID is derived from the index and the document, so retried documents are not indexed twice.
+func newBulkItem(r *sdklog.Record, indexTemplate string) (bulkItem, error) {
+	doc := toDocument(r)
+	source, err := json.Marshal(doc)
+	if err != nil {
+		return bulkItem{}, fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	timestamp := r.Timestamp()
+	if timestamp.IsZero() {
+		timestamp = r.ObservedTimestamp()
+	}
+	index := indexName(indexTemplate, timestamp)
+
+	h := fnv.New128a()
+	_, _ = h.Write([]byte(index))
+	_, _ = h.Write(source)
+
+	action, err := json.Marshal(map[string]any{
+		"create": map[string]string{"_index": index, "_id": hex.EncodeToString(h.Sum(nil))},
+	})
+	if err != nil {
+		return bulkItem{}, fmt.Errorf("failed to marshal bulk action: %w", err)
+	}
+
+	return bulkItem{action: action, source: source}, nil
+}
+
+// indexName replaces %Y, %m and %d of the template with the UTC date
+func indexName(template string, t time.Time) string {
+	t = t.UTC()
+
+	return strings.NewReplacer(
+		"%Y", t.Format("2006"),
+		"%m", t.Format("01"),
+		"%d", t.Format("02"),
+	).Replace(template)
+}
+
+func toDocument(r *sdklog.Record) document {
+	doc := document{
+		SeverityText:   r.SeverityText(),
+		SeverityNumber: int(r.Severity()),
+		Body:           otlp.ValueToAny(r.Body()),
+	}
+
+	timestamp := r.Timestamp()
+	if timestamp.IsZero() {
+		timestamp = r.ObservedTimestamp()
+	}
+	doc.Timestamp = timestamp.UTC().Format(time.RFC3339Nano)
+	if observed := r.ObservedTimestamp(); !observed.IsZero() {
+		doc.ObservedTimestamp = observed.UTC().Format(time.RFC3339Nano)
+	}
+
+	if traceID := r.TraceID(); traceID.IsValid() {
+		doc.TraceID = traceID.String()
+	}
+	if spanID := r.SpanID(); spanID.IsValid() {
+		doc.SpanID = spanID.String()
+	}
+
+	if n := r.AttributesLen(); n > 0 {
+		doc.Attributes = make(map[string]any, n)
+		r.WalkAttributes(func(kv otlplog.KeyValue) bool {
+			doc.Attributes[kv.Key] = otlp.ValueToAny(kv.Value)
+
+			return true
+		})
+	}
+
+	if res := r.Resource(); res != nil && res.Len() > 0 {
+		doc.Resource = make(map[string]any, res.Len())
+		iter := res.Iter()
+		for iter.Next() {
+			kv := iter.Attribute()
+			doc.Resource[string(kv.Key)] = attributeToAny(kv.Value)
+		}
+	}
+
+	if s := r.InstrumentationScope(); s.Name != "" {
+		doc.InstrumentationScope = &scope{Name: s.Name, Version: s.Version}
+	}
+
+	return doc
+}
+
+func attributeToAny(v attribute.Value) any {
+	if v.Type() == attribute.INVALID {
+		return nil
+	}
+
+	return v.AsInterface()
+}
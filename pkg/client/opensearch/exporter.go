@@ -0,0 +1,270 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package opensearch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/client/retry"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
+)
+
+const (
+	// bulkPath is the path of the bulk API
+	bulkPath = "/_bulk"
+	// maxErrorBodySize limits the response body included in bulk errors
+	maxErrorBodySize = 1024
+)
+
+// exporter is a blocking sdklog.Exporter indexing records with the bulk API.
+// Documents rejected with retryable statuses are retried, other rejected documents are dropped.
+type exporter struct {
+	url      string
+	client   *http.Client
+	headers  map[string]string
+	cfg      config.OpenSearchConfig
+	compress bool
+	retry    *config.RetryConfig
+	endpoint string
+	logger   logr.Logger
+	metrics  *metrics.FluentBitGardenerMetrics
+}
+
+var _ sdklog.Exporter = &exporter{}
+
+// newExporter creates an exporter from the endpoint, TLS, header, timeout, compression, retry and OpenSearch configuration
+func newExporter(cfg config.Config, logger logr.Logger, m *metrics.FluentBitGardenerMetrics, limiter *otlp.Limiter) *exporter {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.OTLPConfig.TLSConfig != nil {
+		transport.TLSClientConfig = cfg.OTLPConfig.TLSConfig
+	}
+
+	return &exporter{
+		url:      bulkURL(cfg),
+		client:   &http.Client{Transport: otlp.NewThrottleTransport(transport, limiter), Timeout: cfg.OTLPConfig.Timeout},
+		headers:  cfg.OTLPConfig.Headers,
+		cfg:      cfg.OpenSearchConfig,
+		compress: cfg.OTLPConfig.Compression == config.CompressionGzip,
+		retry:    cfg.OTLPConfig.RetryConfig,
+		endpoint: cfg.OTLPConfig.Endpoint,
+		logger:   logger,
+		metrics:  m,
+	}
+}
+
+// bulkURL returns the EndpointURL if configured, otherwise the bulk API on the Endpoint.
+// Insecure selects plain HTTP for the Endpoint.
+func bulkURL(cfg config.Config) string {
+	if cfg.OTLPConfig.EndpointURL != "" {
+		return cfg.OTLPConfig.EndpointURL
+	}
+
+	scheme := "https://"
+	if cfg.OTLPConfig.Insecure {
+		scheme = "http://"
+	}
+
+	return scheme + strings.TrimSuffix(cfg.OTLPConfig.Endpoint, "/") + bulkPath
+}
+
+// Export indexes the records. Each retry only sends the documents which failed with a retryable status.
+func (e *exporter) Export(ctx context.Context, records []sdklog.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	pending := make([]bulkItem, 0, len(records))
+	for i := range records {
+		item, err := newBulkItem(&records[i], e.cfg.Index)
+		if err != nil {
+			e.logger.Error(err, "dropping record")
+			e.metrics.DroppedLogs.WithLabelValues(e.endpoint, "marshal_error").Inc()
+
+			continue
+		}
+		pending = append(pending, item)
+	}
+
+	return retry.Do(ctx, e.retry, e.logger, func(ctx context.Context) error {
+		failed, err := e.bulk(ctx, pending)
+		if err != nil {
+			return err
+		}
+		if len(failed) > 0 {
+			err = retry.Retryable(fmt.Errorf("%d of %d documents were rejected with a retryable status", len(failed), len(pending)), 0)
+			pending = failed
+
+			return err
+		}
+
+		return nil
+	})
+}
+
+// bulk sends the items and returns the items to retry
+func (e *exporter) bulk(ctx context.Context, items []bulkItem) ([]bulkItem, error) {
+	body, err := e.body(items)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bulk request: %w", err)
+	}
+	e.setHeaders(req)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		return nil, retry.Retryable(err, 0)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		err = fmt.Errorf("bulk request to %s failed with status %d: %s", e.url, resp.StatusCode, strings.TrimSpace(string(message)))
+		if retryableStatus(resp.StatusCode) {
+			return nil, retry.Retryable(err, retry.After(resp.Header.Get("Retry-After")))
+		}
+		if resp.StatusCode == http.StatusRequestEntityTooLarge {
+			return nil, fmt.Errorf("%w: %w", otlp.ErrBatchTooLarge, err)
+		}
+
+		return nil, err
+	}
+
+	var result bulkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode bulk response: %w", err)
+	}
+	if !result.Errors {
+		return nil, nil
+	}
+	if len(result.Items) != len(items) {
+		return nil, fmt.Errorf("bulk response has %d items for %d documents", len(result.Items), len(items))
+	}
+
+	var failed []bulkItem
+	for i, item := range result.Items {
+		status, reason := item.result()
+		switch {
+		case status/100 == 2, status == http.StatusConflict:
+			// A conflict means the document was indexed by an earlier attempt
+		case retryableStatus(status):
+			failed = append(failed, items[i])
+		default:
+			e.logger.Error(nil, "document rejected", "status", status, "reason", reason)
+			e.metrics.DroppedLogs.WithLabelValues(e.endpoint, "rejected").Inc()
+		}
+	}
+
+	return failed, nil
+}
+
+// body encodes the items as NDJSON, gzip compressed if configured
+func (e *exporter) body(items []bulkItem) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var gz *gzip.Writer
+	if e.compress {
+		gz = gzip.NewWriter(&buf)
+		w = gz
+	}
+
+	for _, item := range items {
+		for _, line := range [][]byte{item.action, item.source} {
+			if _, err := w.Write(line); err != nil {
+				return nil, err
+			}
+			if _, err := w.Write([]byte{'\n'}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress bulk request: %w", err)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (e *exporter) setHeaders(req *http.Request) {
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if e.compress {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	switch {
+	case e.cfg.APIKey != "":
+		req.Header.Set("Authorization", "ApiKey "+e.cfg.APIKey)
+	case e.cfg.Username != "":
+		req.SetBasicAuth(e.cfg.Username, e.cfg.Password)
+	default:
+	}
+}
+
+// Shutdown closes idle connections
+func (e *exporter) Shutdown(context.Context) error {
+	e.client.CloseIdleConnections()
+
+	return nil
+}
+
+// ForceFlush is a no-op, the exporter does not buffer records
+func (*exporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status/100 == 5
+}
+
+// bulkResponse is the part of the bulk API response needed to find rejected documents
+type bulkResponse struct {
+	Errors bool                 `json:"errors"`
+	Items  []bulkResponseAction `json:"items"`
+}
+
+// bulkResponseAction holds the result of one document by action name
+type bulkResponseAction map[string]struct {
+	Status int `json:"status"`
+	Error  *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error,omitempty"`
+}
+
+func (a bulkResponseAction) result() (int, string) {
+	for _, r := range a {
+		if r.Error != nil {
+			return r.Status, r.Error.Type + ": " + r.Error.Reason
+		}
+
+		return r.Status, ""
+	}
+
+	return 0, "empty bulk response item"
+}
@@ -0,0 +1,26 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package opensearch
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
+)
+
+const componentOpenSearchName = "opensearch"
+
+// New creates a new client indexing logs with the Elasticsearch/OpenSearch bulk API. Records are built like for the
+// OTLP clients and batched by the configured batch processor, the exporter indexes them as documents into the daily index of the record.
+func New(ctx context.Context, cfg config.Config, logger logr.Logger, m *metrics.FluentBitGardenerMetrics) (*otlp.ExporterClient, error) {
+	return otlp.NewExporterClient(ctx, cfg, logger, m, componentOpenSearchName, cfg.OTLPConfig.Endpoint,
+		func(_ context.Context, limiter *otlp.Limiter) (sdklog.Exporter, error) {
+			return newExporter(cfg, logger, m, limiter), nil
+		})
+}
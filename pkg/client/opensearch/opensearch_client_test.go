@@ -0,0 +1,283 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package opensearch
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/log"
+	"github.com/gardener/logging/v1/pkg/metrics"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+// indexedDocument is a document received with its create action
+type indexedDocument struct {
+	Index  string
+	ID     string
+	Source map[string]any
+}
+
+// openSearchStandIn records the bulk requests received by the httptest server
+type openSearchStandIn struct {
+	server   *httptest.Server
+	mu       sync.Mutex
+	requests []*http.Request
+	// batches holds the documents of each request
+	batches [][]indexedDocument
+	// itemStatuses are the document statuses returned for the first requests, later documents are created
+	itemStatuses [][]int
+	// statuses are returned for the first requests, later requests succeed
+	statuses []int
+}
+
+func newOpenSearchStandIn() *openSearchStandIn {
+	s := &openSearchStandIn{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer GinkgoRecover()
+
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			body = gz
+		}
+		docs := decodeBulkRequest(body)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.requests = append(s.requests, r)
+		n := len(s.requests)
+		if n <= len(s.statuses) {
+			w.WriteHeader(s.statuses[n-1])
+
+			return
+		}
+		s.batches = append(s.batches, docs)
+
+		var statuses []int
+		if n <= len(s.statuses)+len(s.itemStatuses) {
+			statuses = s.itemStatuses[n-len(s.statuses)-1]
+		}
+		writeBulkResponse(w, len(docs), statuses)
+	}))
+	DeferCleanup(s.server.Close)
+
+	return s
+}
+
+func (s *openSearchStandIn) receivedBatches() [][]indexedDocument {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([][]indexedDocument(nil), s.batches...)
+}
+
+func decodeBulkRequest(body io.Reader) []indexedDocument {
+	var docs []indexedDocument
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		var action map[string]struct {
+			Index string `json:"_index"`
+			ID    string `json:"_id"`
+		}
+		Expect(json.Unmarshal(scanner.Bytes(), &action)).To(Succeed())
+		Expect(action).To(HaveKey("create"))
+
+		Expect(scanner.Scan()).To(BeTrue())
+		doc := indexedDocument{Index: action["create"].Index, ID: action["create"].ID}
+		Expect(json.Unmarshal(scanner.Bytes(), &doc.Source)).To(Succeed())
+		docs = append(docs, doc)
+	}
+	Expect(scanner.Err()).NotTo(HaveOccurred())
+
+	return docs
+}
+
+// writeBulkResponse writes a bulk response with the statuses, missing statuses are 201
+func writeBulkResponse(w http.ResponseWriter, n int, statuses []int) {
+	type result struct {
+		Status int            `json:"status"`
+		Error  map[string]any `json:"error,omitempty"`
+	}
+	resp := struct {
+		Errors bool                `json:"errors"`
+		Items  []map[string]result `json:"items"`
+	}{}
+	for i := range n {
+		r := result{Status: http.StatusCreated}
+		if i < len(statuses) {
+			r.Status = statuses[i]
+		}
+		if r.Status/100 != 2 {
+			resp.Errors = true
+			r.Error = map[string]any{"type": "test_exception", "reason": "rejected by test"}
+		}
+		resp.Items = append(resp.Items, map[string]result{"create": r})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	Expect(json.NewEncoder(w).Encode(resp)).To(Succeed())
+}
+
+var _ = Describe("OpenSearch client", func() {
+	var (
+		standIn     *openSearchStandIn
+		testMetrics *metrics.FluentBitGardenerMetrics
+	)
+
+	BeforeEach(func() {
+		standIn = newOpenSearchStandIn()
+		testMetrics = metrics.RegisterFluentBitGardenerMetrics(metrics.NewRegistry())
+	})
+
+	newClient := func(configMap map[string]any) *otlp.ExporterClient {
+		base := map[string]any{
+			"EndpointURL":          standIn.server.URL + "/_bulk",
+			"DQueDir":              GinkgoT().TempDir(),
+			"UseSDKBatchProcessor": "true",
+			"RetryInitialInterval": "10ms",
+			"RetryMaxInterval":     "20ms",
+			"RetryMaxElapsedTime":  "1s",
+		}
+		for k, v := range configMap {
+			base[k] = v
+		}
+		cfg, err := config.ParseConfig(base)
+		Expect(err).NotTo(HaveOccurred())
+
+		client, err := New(context.Background(), *cfg, log.NewNoop(), testMetrics)
+		Expect(err).NotTo(HaveOccurred())
+
+		return client
+	}
+
+	entry := func(log string, ts time.Time) types.OutputEntry {
+		return types.OutputEntry{
+			Timestamp: ts,
+			Record: map[string]any{
+				"log": log,
+				"kubernetes": map[string]any{
+					"namespace_name": "shoot--dev--test",
+					"pod_name":       "app-0",
+				},
+			},
+		}
+	}
+
+	It("should index the records as NDJSON create actions into the daily index", func() {
+		client := newClient(map[string]any{"OpenSearchIndex": "logs-%Y.%m.%d", "OpenSearchAPIKey": "a2V5"})
+		ts := time.Date(2024, 1, 2, 23, 30, 0, 0, time.FixedZone("CET", 3600))
+
+		Expect(client.Handle(entry("first", ts))).To(Succeed())
+		Expect(client.Handle(entry("second", ts))).To(Succeed())
+		client.StopWait()
+
+		Expect(standIn.requests).To(HaveLen(1))
+		req := standIn.requests[0]
+		Expect(req.Method).To(Equal(http.MethodPost))
+		Expect(req.URL.Path).To(Equal("/_bulk"))
+		Expect(req.Header.Get("Content-Type")).To(Equal("application/x-ndjson"))
+		Expect(req.Header.Get("Authorization")).To(Equal("ApiKey a2V5"))
+
+		batches := standIn.receivedBatches()
+		Expect(batches).To(HaveLen(1))
+		Expect(batches[0]).To(HaveLen(2))
+		doc := batches[0][0]
+		Expect(doc.Index).To(Equal("logs-2024.01.02"))
+		Expect(doc.ID).NotTo(BeEmpty())
+		Expect(doc.ID).NotTo(Equal(batches[0][1].ID))
+		Expect(doc.Source).To(HaveKeyWithValue("@timestamp", "2024-01-02T22:30:00Z"))
+		Expect(doc.Source).To(HaveKeyWithValue("body", "first"))
+		Expect(doc.Source).To(HaveKeyWithValue("attributes", HaveKeyWithValue("k8s.pod.name", "app-0")))
+		Expect(testutil.ToFloat64(testMetrics.OutputClientLogs.WithLabelValues(client.Endpoint()))).To(Equal(2.0))
+	})
+
+	It("should use basic authentication and gzip compression", func() {
+		client := newClient(map[string]any{
+			"OpenSearchUsername": "fluent-bit",
+			"OpenSearchPassword": "secret",
+			"Compression":        "1",
+		})
+
+		Expect(client.Handle(entry("line", time.Now()))).To(Succeed())
+		client.StopWait()
+
+		Expect(standIn.requests).To(HaveLen(1))
+		username, password, ok := standIn.requests[0].BasicAuth()
+		Expect(ok).To(BeTrue())
+		Expect(username).To(Equal("fluent-bit"))
+		Expect(password).To(Equal("secret"))
+		Expect(standIn.requests[0].Header.Get("Content-Encoding")).To(Equal("gzip"))
+		Expect(standIn.receivedBatches()).To(HaveLen(1))
+	})
+
+	It("should retry only the documents rejected with a retryable status", func() {
+		standIn.itemStatuses = [][]int{{http.StatusCreated, http.StatusTooManyRequests, http.StatusServiceUnavailable}}
+		client := newClient(nil)
+
+		for _, line := range []string{"first", "second", "third"} {
+			Expect(client.Handle(entry(line, time.Now()))).To(Succeed())
+		}
+		client.StopWait()
+
+		batches := standIn.receivedBatches()
+		Expect(batches).To(HaveLen(2))
+		Expect(batches[0]).To(HaveLen(3))
+		Expect(batches[1]).To(HaveLen(2))
+		Expect(batches[1][0].ID).To(Equal(batches[0][1].ID))
+		Expect(batches[1][0].Source).To(HaveKeyWithValue("body", "second"))
+		Expect(batches[1][1].Source).To(HaveKeyWithValue("body", "third"))
+	})
+
+	It("should drop rejected documents and treat conflicts as indexed", func() {
+		standIn.itemStatuses = [][]int{{http.StatusBadRequest, http.StatusConflict}}
+		client := newClient(nil)
+
+		Expect(client.Handle(entry("invalid", time.Now()))).To(Succeed())
+		Expect(client.Handle(entry("duplicate", time.Now()))).To(Succeed())
+		client.StopWait()
+
+		Expect(standIn.receivedBatches()).To(HaveLen(1))
+		Expect(testutil.ToFloat64(testMetrics.DroppedLogs.WithLabelValues(client.Endpoint(), "rejected"))).To(Equal(1.0))
+	})
+
+	It("should retry failed bulk requests", func() {
+		standIn.statuses = []int{http.StatusServiceUnavailable}
+		client := newClient(nil)
+
+		Expect(client.Handle(entry("line", time.Now()))).To(Succeed())
+		client.StopWait()
+
+		Expect(standIn.requests).To(HaveLen(2))
+		Expect(standIn.receivedBatches()).To(HaveLen(1))
+	})
+
+	It("should build the bulk URL from the endpoint", func() {
+		cfg := config.Config{OTLPConfig: config.OTLPConfig{Endpoint: "opensearch.garden:9200/", Insecure: true}}
+		Expect(bulkURL(cfg)).To(Equal("http://opensearch.garden:9200/_bulk"))
+
+		cfg.OTLPConfig.Insecure = false
+		Expect(bulkURL(cfg)).To(Equal("https://opensearch.garden:9200/_bulk"))
+	})
+
+	It("should replace the date placeholders of the index template", func() {
+		ts := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+		Expect(indexName("fluent-bit-%Y.%m.%d", ts)).To(Equal("fluent-bit-2024.01.02"))
+		Expect(indexName("fluent-bit", ts)).To(Equal("fluent-bit"))
+	})
+})
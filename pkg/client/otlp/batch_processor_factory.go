@@ -111,6 +111,8 @@ func (f *BatchProcessorFactory) createDQueProcessor(
 		WithDQueueSync(cfg.OTLPConfig.DQueConfig.DQueSync),
 		WithMaxQueueSize(cfg.OTLPConfig.DQueBatchProcessorMaxQueueSize),
 		WithMaxBatchSize(cfg.OTLPConfig.DQueBatchProcessorMaxBatchSize),
+		WithMaxBatchBytes(cfg.OTLPConfig.DQueBatchProcessorMaxBatchBytes),
+		WithDeadLetterBytes(cfg.OTLPConfig.DQueBatchProcessorDeadLetterMaxBytes),
 		WithExportTimeout(cfg.OTLPConfig.DQueBatchProcessorExportTimeout),
 		WithExportInterval(cfg.OTLPConfig.DQueBatchProcessorExportInterval),
 	)
@@ -123,6 +125,7 @@ func (f *BatchProcessorFactory) createDQueProcessor(
 		"clientName", clientName,
 		"maxQueueSize", cfg.OTLPConfig.DQueBatchProcessorMaxQueueSize,
 		"maxBatchSize", cfg.OTLPConfig.DQueBatchProcessorMaxBatchSize,
+		"maxBatchBytes", cfg.OTLPConfig.DQueBatchProcessorMaxBatchBytes,
 	)
 
 	return processor, nil
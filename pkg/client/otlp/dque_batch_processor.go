@@ -43,6 +43,8 @@ var (
 const (
 	defaultMaxQueueSize      = 100
 	defaultMaxBatchSize      = 10
+	defaultMaxBatchBytes     = 3 << 20
+	defaultDeadLetterBytes   = 64 << 20
 	defaultExportTimeout     = 30 * time.Second
 	defaultExportInterval    = 5 * time.Second
 	defaultDQueueSegmentSize = 100
@@ -53,6 +55,8 @@ const (
 type dqueBatchProcessorConfig struct {
 	maxQueueSize      int
 	maxBatchSize      int
+	maxBatchBytes     int
+	deadLetterBytes   int
 	exportTimeout     time.Duration
 	exportInterval    time.Duration
 	dqueueDir         string
@@ -79,6 +83,21 @@ func WithMaxBatchSize(size int) DQueBatchProcessorOption {
 	}
 }
 
+// WithMaxBatchBytes sets the maximum estimated size of a batch in bytes, 0 disables the limit.
+// The size of a record is estimated from its serialized form in the dque.
+func WithMaxBatchBytes(size int) DQueBatchProcessorOption {
+	return func(c *dqueBatchProcessorConfig) {
+		c.maxBatchBytes = size
+	}
+}
+
+// WithDeadLetterBytes sets the maximum size of the dead letter file in bytes, 0 drops the records instead
+func WithDeadLetterBytes(size int) DQueBatchProcessorOption {
+	return func(c *dqueBatchProcessorConfig) {
+		c.deadLetterBytes = size
+	}
+}
+
 // WithExportTimeout sets the timeout for export operations
 func WithExportTimeout(timeout time.Duration) DQueBatchProcessorOption {
 	return func(c *dqueBatchProcessorConfig) {
@@ -137,6 +156,9 @@ type DQueBatchProcessor struct {
 	endpoint string
 	metrics  *metrics.FluentBitGardenerMetrics
 
+	// deadLetter stores the records exceeding the maximum batch size on their own
+	deadLetter *deadLetter
+
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
@@ -155,23 +177,34 @@ type logRecordItem struct {
 	Severity             int               `json:"severity"`
 	SeverityText         string            `json:"severity_text"`
 	Body                 string            `json:"body"`
+	StructuredBody       *valueItem        `json:"structured_body,omitempty"`
 	Attributes           []attributeItem   `json:"attributes"`
 	TraceID              []byte            `json:"trace_id,omitempty"`
 	SpanID               []byte            `json:"span_id,omitempty"`
 	TraceFlags           uint8             `json:"trace_flags"`
 	Resource             []attributeItem   `json:"resource"`
+	ResourceSchemaURL    string            `json:"resource_schema_url,omitempty"`
 	InstrumentationScope map[string]string `json:"instrumentation_scope"`
+	ScopeAttributes      []attributeItem   `json:"scope_attributes,omitempty"`
 }
 
 // attributeItem stores an attribute with explicit type information for JSON serialization
 type attributeItem struct {
-	Key       string  `json:"key"`
-	ValueType string  `json:"value_type"` // "string", "int64", "float64", "bool", "bytes", "other"
-	StrValue  string  `json:"str_value,omitempty"`
-	IntValue  int64   `json:"int_value,omitempty"`
-	FltValue  float64 `json:"flt_value,omitempty"`
-	BoolValue bool    `json:"bool_value,omitempty"`
-	ByteValue []byte  `json:"byte_value,omitempty"`
+	Key string `json:"key"`
+	valueItem
+}
+
+// valueItem stores a value with explicit type information for JSON serialization.
+// Maps and slices are stored recursively.
+type valueItem struct {
+	ValueType  string          `json:"value_type"` // "string", "int64", "float64", "bool", "bytes", "map", "slice", "empty", "other"
+	StrValue   string          `json:"str_value,omitempty"`
+	IntValue   int64           `json:"int_value,omitempty"`
+	FltValue   float64         `json:"flt_value,omitempty"`
+	BoolValue  bool            `json:"bool_value,omitempty"`
+	ByteValue  []byte          `json:"byte_value,omitempty"`
+	MapValue   []attributeItem `json:"map_value,omitempty"`
+	SliceValue []valueItem     `json:"slice_value,omitempty"`
 }
 
 // dqueJSONWrapper wraps logRecordItem with JSON marshaling for dque persistence
@@ -205,6 +238,8 @@ func NewDQueBatchProcessor(
 	config := dqueBatchProcessorConfig{
 		maxQueueSize:      defaultMaxQueueSize,
 		maxBatchSize:      defaultMaxBatchSize,
+		maxBatchBytes:     defaultMaxBatchBytes,
+		deadLetterBytes:   defaultDeadLetterBytes,
 		exportTimeout:     defaultExportTimeout,
 		exportInterval:    defaultExportInterval,
 		dqueueSegmentSize: defaultDQueueSegmentSize,
@@ -261,12 +296,16 @@ func NewDQueBatchProcessor(
 	processorCtx, cancel := context.WithCancel(ctx)
 
 	processor := &DQueBatchProcessor{
-		logger:      logger.WithValues("path", strings.Join([]string{config.dqueueDir, config.dqueueName}, "/"), "endpoint", config.endpoint),
-		config:      config,
-		exporter:    exporter,
-		queue:       queue,
-		endpoint:    config.endpoint,
-		metrics:     m,
+		logger:   logger.WithValues("path", strings.Join([]string{config.dqueueDir, config.dqueueName}, "/"), "endpoint", config.endpoint),
+		config:   config,
+		exporter: exporter,
+		queue:    queue,
+		endpoint: config.endpoint,
+		metrics:  m,
+		deadLetter: &deadLetter{
+			path:     path.Join(config.dqueueDir, config.dqueueName+"-dead-letter.jsonl"),
+			maxBytes: int64(config.deadLetterBytes),
+		},
 		ctx:         processorCtx,
 		cancel:      cancel,
 		newRecordCh: make(chan struct{}, 1), // buffered to avoid blocking OnEmit
@@ -279,6 +318,7 @@ func NewDQueBatchProcessor(
 	logger.Info("DQue batch processor started",
 		"dque_max_queue_size", config.maxQueueSize,
 		"dque_max_batch_size", config.maxBatchSize,
+		"dque_max_batch_bytes", config.maxBatchBytes,
 		"dque_export_interval", config.exportInterval,
 		"dque_dir", config.dqueueDir,
 		"dque_sync", config.dqueueSync,
@@ -295,6 +335,12 @@ func validateProcessorConfig(cfg dqueBatchProcessorConfig) error {
 	if cfg.maxBatchSize <= 0 {
 		return errors.New("max batch size must be positive")
 	}
+	if cfg.maxBatchBytes < 0 {
+		return errors.New("max batch bytes must not be negative")
+	}
+	if cfg.deadLetterBytes < 0 {
+		return errors.New("dead letter bytes must not be negative")
+	}
 	if cfg.exportTimeout <= 0 {
 		return errors.New("export timeout must be positive")
 	}
@@ -331,47 +377,19 @@ func recordToItem(record sdklog.Record) *logRecordItem {
 		InstrumentationScope: make(map[string]string),
 	}
 
-	// Extract body
-	if record.Body().Kind() != 0 {
-		item.Body = record.Body().AsString()
+	// Extract body, structured bodies keep their nested values
+	switch body := record.Body(); body.Kind() {
+	case otlplog.KindEmpty:
+	case otlplog.KindMap, otlplog.KindSlice:
+		value := logValueToItem(body)
+		item.StructuredBody = &value
+	default:
+		item.Body = body.AsString()
 	}
 
-	// Extract attributes - store in explicit struct to preserve types through gob
+	// Extract attributes - store in explicit struct to preserve types, maps and slices are kept recursively
 	record.WalkAttributes(func(kv otlplog.KeyValue) bool {
-		attr := attributeItem{Key: kv.Key}
-		val := kv.Value
-
-		switch val.Kind() {
-		case otlplog.KindString:
-			attr.ValueType = "string"
-			attr.StrValue = val.AsString()
-		case otlplog.KindInt64:
-			attr.ValueType = "int64"
-			attr.IntValue = val.AsInt64()
-		case otlplog.KindFloat64:
-			attr.ValueType = "float64"
-			attr.FltValue = val.AsFloat64()
-		case otlplog.KindBool:
-			attr.ValueType = "bool"
-			attr.BoolValue = val.AsBool()
-		case otlplog.KindBytes:
-			attr.ValueType = "bytes"
-			attr.ByteValue = val.AsBytes()
-		case otlplog.KindMap:
-			// For maps, convert to string representation for simplicity
-			attr.ValueType = "other"
-			attr.StrValue = fmt.Sprintf("%v", val.AsMap())
-		case otlplog.KindSlice:
-			// For slices, convert to string representation for simplicity
-			attr.ValueType = "other"
-			attr.StrValue = fmt.Sprintf("%v", val.AsSlice())
-		default:
-			// For other types, convert to string
-			attr.ValueType = "other"
-			attr.StrValue = fmt.Sprintf("%v", val)
-		}
-
-		item.Attributes = append(item.Attributes, attr)
+		item.Attributes = append(item.Attributes, attributeItem{Key: kv.Key, valueItem: logValueToItem(kv.Value)})
 
 		return true
 	})
@@ -392,28 +410,9 @@ func recordToItem(record sdklog.Record) *logRecordItem {
 	// Extract resource attributes
 	if res := record.Resource(); res != nil {
 		for _, attr := range res.Attributes() {
-			resAttr := attributeItem{Key: string(attr.Key)}
-
-			switch attr.Value.Type() {
-			case attribute.STRING:
-				resAttr.ValueType = "string"
-				resAttr.StrValue = attr.Value.AsString()
-			case attribute.INT64:
-				resAttr.ValueType = "int64"
-				resAttr.IntValue = attr.Value.AsInt64()
-			case attribute.FLOAT64:
-				resAttr.ValueType = "float64"
-				resAttr.FltValue = attr.Value.AsFloat64()
-			case attribute.BOOL:
-				resAttr.ValueType = "bool"
-				resAttr.BoolValue = attr.Value.AsBool()
-			default:
-				resAttr.ValueType = "other"
-				resAttr.StrValue = attr.Value.AsString()
-			}
-
-			item.Resource = append(item.Resource, resAttr)
+			item.Resource = append(item.Resource, attributeToItem(attr))
 		}
+		item.ResourceSchemaURL = res.SchemaURL()
 	}
 
 	// Extract instrumentation scope
@@ -421,28 +420,133 @@ func recordToItem(record sdklog.Record) *logRecordItem {
 	item.InstrumentationScope["name"] = scope.Name
 	item.InstrumentationScope["version"] = scope.Version
 	item.InstrumentationScope["schemaURL"] = scope.SchemaURL
+	for _, attr := range scope.Attributes.ToSlice() {
+		item.ScopeAttributes = append(item.ScopeAttributes, attributeToItem(attr))
+	}
+
+	return item
+}
+
+// attributeToItem converts a resource or scope attribute to a serializable attributeItem
+func attributeToItem(attr attribute.KeyValue) attributeItem {
+	item := attributeItem{Key: string(attr.Key)}
+
+	switch attr.Value.Type() {
+	case attribute.STRING:
+		item.ValueType = "string"
+		item.StrValue = attr.Value.AsString()
+	case attribute.INT64:
+		item.ValueType = "int64"
+		item.IntValue = attr.Value.AsInt64()
+	case attribute.FLOAT64:
+		item.ValueType = "float64"
+		item.FltValue = attr.Value.AsFloat64()
+	case attribute.BOOL:
+		item.ValueType = "bool"
+		item.BoolValue = attr.Value.AsBool()
+	default:
+		item.ValueType = "other"
+		item.StrValue = attr.Value.AsString()
+	}
 
 	return item
 }
 
+// itemToAttribute converts a serialized attributeItem back to a resource or scope attribute
+func itemToAttribute(item attributeItem) attribute.KeyValue {
+	//nolint:revive // identical-switch-branches: default fallback improves readability
+	switch item.ValueType {
+	case "string":
+		return attribute.String(item.Key, item.StrValue)
+	case "int64":
+		return attribute.Int64(item.Key, item.IntValue)
+	case "float64":
+		return attribute.Float64(item.Key, item.FltValue)
+	case "bool":
+		return attribute.Bool(item.Key, item.BoolValue)
+	default:
+		return attribute.String(item.Key, item.StrValue)
+	}
+}
+
+// logValueToItem converts a log value to a serializable valueItem
+func logValueToItem(val otlplog.Value) valueItem {
+	var item valueItem
+
+	switch val.Kind() {
+	case otlplog.KindString:
+		item.ValueType = "string"
+		item.StrValue = val.AsString()
+	case otlplog.KindInt64:
+		item.ValueType = "int64"
+		item.IntValue = val.AsInt64()
+	case otlplog.KindFloat64:
+		item.ValueType = "float64"
+		item.FltValue = val.AsFloat64()
+	case otlplog.KindBool:
+		item.ValueType = "bool"
+		item.BoolValue = val.AsBool()
+	case otlplog.KindBytes:
+		item.ValueType = "bytes"
+		item.ByteValue = val.AsBytes()
+	case otlplog.KindMap:
+		item.ValueType = "map"
+		for _, kv := range val.AsMap() {
+			item.MapValue = append(item.MapValue, attributeItem{Key: kv.Key, valueItem: logValueToItem(kv.Value)})
+		}
+	case otlplog.KindSlice:
+		item.ValueType = "slice"
+		for _, v := range val.AsSlice() {
+			item.SliceValue = append(item.SliceValue, logValueToItem(v))
+		}
+	case otlplog.KindEmpty:
+		item.ValueType = "empty"
+	default:
+		item.ValueType = "other"
+		item.StrValue = val.String()
+	}
+
+	return item
+}
+
+// itemToLogValue converts a serialized valueItem back to a log value
+func itemToLogValue(item valueItem) otlplog.Value {
+	switch item.ValueType {
+	case "string", "other":
+		return otlplog.StringValue(item.StrValue)
+	case "int64":
+		return otlplog.Int64Value(item.IntValue)
+	case "float64":
+		return otlplog.Float64Value(item.FltValue)
+	case "bool":
+		return otlplog.BoolValue(item.BoolValue)
+	case "bytes":
+		return otlplog.BytesValue(item.ByteValue)
+	case "map":
+		kvs := make([]otlplog.KeyValue, 0, len(item.MapValue))
+		for _, kv := range item.MapValue {
+			kvs = append(kvs, otlplog.KeyValue{Key: kv.Key, Value: itemToLogValue(kv.valueItem)})
+		}
+
+		return otlplog.MapValue(kvs...)
+	case "slice":
+		values := make([]otlplog.Value, 0, len(item.SliceValue))
+		for _, v := range item.SliceValue {
+			values = append(values, itemToLogValue(v))
+		}
+
+		return otlplog.SliceValue(values...)
+	default:
+		return otlplog.Value{}
+	}
+}
+
 // itemToRecord converts a logRecordItem back to an sdklog.Record using RecordFactory
 func itemToRecord(item *logRecordItem) sdklog.Record {
 	// Build attributes from explicit attributeItem slice
 	attrs := make([]otlplog.KeyValue, 0, len(item.Attributes))
 	for _, attr := range item.Attributes {
-		switch attr.ValueType {
-		case "string":
-			attrs = append(attrs, otlplog.String(attr.Key, attr.StrValue))
-		case "int64":
-			attrs = append(attrs, otlplog.Int64(attr.Key, attr.IntValue))
-		case "float64":
-			attrs = append(attrs, otlplog.Float64(attr.Key, attr.FltValue))
-		case "bool":
-			attrs = append(attrs, otlplog.Bool(attr.Key, attr.BoolValue))
-		case "bytes":
-			attrs = append(attrs, otlplog.Bytes(attr.Key, attr.ByteValue))
-		default:
-		}
+		attrs = append(attrs, otlplog.KeyValue{Key: attr.Key, Value: itemToLogValue(attr.valueItem)})
 	}
 
 	// Build resource attributes from item
@@ -450,21 +554,13 @@ func itemToRecord(item *logRecordItem) sdklog.Record {
 	if len(item.Resource) > 0 {
 		resAttrs := make([]attribute.KeyValue, len(item.Resource))
 		for i, attr := range item.Resource {
-			//nolint:revive // identical-switch-branches: default fallback improves readability
-			switch attr.ValueType {
-			case "string":
-				resAttrs[i] = attribute.String(attr.Key, attr.StrValue)
-			case "int64":
-				resAttrs[i] = attribute.Int64(attr.Key, attr.IntValue)
-			case "float64":
-				resAttrs[i] = attribute.Float64(attr.Key, attr.FltValue)
-			case "bool":
-				resAttrs[i] = attribute.Bool(attr.Key, attr.BoolValue)
-			default:
-				resAttrs[i] = attribute.String(attr.Key, attr.StrValue)
-			}
+			resAttrs[i] = itemToAttribute(attr)
 		}
-		resource = sdkresource.NewWithAttributes(semconv.SchemaURL, resAttrs...)
+		schemaURL := item.ResourceSchemaURL
+		if schemaURL == "" {
+			schemaURL = semconv.SchemaURL
+		}
+		resource = sdkresource.NewWithAttributes(schemaURL, resAttrs...)
 	}
 
 	// Build instrumentation scope from item
@@ -475,6 +571,18 @@ func itemToRecord(item *logRecordItem) sdklog.Record {
 			Version:   item.InstrumentationScope["version"],
 			SchemaURL: item.InstrumentationScope["schemaURL"],
 		}
+		if len(item.ScopeAttributes) > 0 {
+			scopeAttrs := make([]attribute.KeyValue, len(item.ScopeAttributes))
+			for i, attr := range item.ScopeAttributes {
+				scopeAttrs[i] = itemToAttribute(attr)
+			}
+			scope.Attributes = attribute.NewSet(scopeAttrs...)
+		}
+	}
+
+	body := otlplog.StringValue(item.Body)
+	if item.StructuredBody != nil {
+		body = itemToLogValue(*item.StructuredBody)
 	}
 
 	// Use RecordFactory to create a proper record (this ensures AsString() works correctly)
@@ -483,7 +591,7 @@ func itemToRecord(item *logRecordItem) sdklog.Record {
 		ObservedTimestamp:    item.ObservedTimestamp,
 		Severity:             otlplog.Severity(item.Severity),
 		SeverityText:         item.SeverityText,
-		Body:                 otlplog.StringValue(item.Body),
+		Body:                 body,
 		Attributes:           attrs,
 		Resource:             resource,
 		InstrumentationScope: scope,
@@ -613,6 +721,67 @@ func (p *DQueBatchProcessor) OnEmit(_ context.Context, record *sdklog.Record) er
 	return nil
 }
 
+// recordBatch is a batch of records with their estimated size in bytes
+type recordBatch struct {
+	records []sdklog.Record
+	bytes   int
+}
+
+func (b *recordBatch) reset() {
+	b.records = b.records[:0]
+	b.bytes = 0
+}
+
+// flush exports the batch if it is not empty
+func (p *DQueBatchProcessor) flush(batch *recordBatch) {
+	if len(batch.records) > 0 {
+		p.exportBatch(batch.records)
+		batch.reset()
+	}
+}
+
+// addToBatch adds the record to the batch, exporting the batch when it is full by count or by bytes.
+// A batch which would exceed the maximum bytes with the record is exported first, so batches are split
+// before they become too large. A record exceeding the maximum bytes on its own can never be exported,
+// it is moved to the dead letter file instead of being requeued forever.
+func (p *DQueBatchProcessor) addToBatch(batch *recordBatch, record sdklog.Record, data []byte) {
+	size := len(data)
+	if p.config.maxBatchBytes > 0 {
+		if size > p.config.maxBatchBytes {
+			p.moveToDeadLetter(data)
+
+			return
+		}
+		if batch.bytes+size > p.config.maxBatchBytes {
+			p.flush(batch)
+		}
+	}
+
+	batch.records = append(batch.records, record)
+	batch.bytes += size
+
+	if len(batch.records) >= p.config.maxBatchSize {
+		p.flush(batch)
+	}
+}
+
+// moveToDeadLetter writes a serialized record exceeding the maximum batch bytes or rejected as too large by the backend
+// to the dead letter file
+func (p *DQueBatchProcessor) moveToDeadLetter(data []byte) {
+	if err := p.deadLetter.write(data); err != nil {
+		if !errors.Is(err, errDeadLetterFull) {
+			p.logger.Error(err, "failed to write oversized record to dead letter file")
+		}
+		p.metrics.DroppedLogs.WithLabelValues(p.endpoint, "oversized").Inc()
+
+		return
+	}
+
+	p.metrics.DroppedLogs.WithLabelValues(p.endpoint, "dead_letter").Inc()
+	p.logger.V(1).Info("moved oversized record to dead letter file",
+		"size", len(data), "max_batch_bytes", p.config.maxBatchBytes, "file", p.deadLetter.path)
+}
+
 // processLoop continuously dequeues and exports batches
 func (p *DQueBatchProcessor) processLoop() {
 	defer p.wg.Done()
@@ -624,25 +793,20 @@ func (p *DQueBatchProcessor) processLoop() {
 	metricsTicker := time.NewTicker(30 * time.Second)
 	defer metricsTicker.Stop()
 
-	batch := make([]sdklog.Record, 0, p.config.maxBatchSize)
+	batch := &recordBatch{records: make([]sdklog.Record, 0, p.config.maxBatchSize)}
 
 	for {
 		select {
 		case <-p.ctx.Done():
 			p.logger.V(2).Info("process loop stopping")
 			// Final flush on shutdown
-			if len(batch) > 0 {
-				p.exportBatch(batch)
-			}
+			p.flush(batch)
 
 			return
 
 		case <-exportTicker.C:
 			// Periodic batch export
-			if len(batch) > 0 {
-				p.exportBatch(batch)
-				batch = batch[:0]
-			}
+			p.flush(batch)
 
 		case <-metricsTicker.C:
 			// Report queue size to metrics
@@ -657,7 +821,7 @@ func (p *DQueBatchProcessor) processLoop() {
 
 		case <-p.newRecordCh:
 			// New record signal received, try to dequeue immediately
-			record, err := p.dequeue()
+			record, data, err := p.dequeue()
 			if err != nil && !errors.Is(err, dque.ErrEmpty) {
 				// increase error count
 				wrapped := errors.Unwrap(err)
@@ -670,25 +834,17 @@ func (p *DQueBatchProcessor) processLoop() {
 				continue
 			}
 			if errors.Is(err, dque.ErrEmpty) {
-				if len(batch) > 0 {
-					p.exportBatch(batch)
-					batch = batch[:0]
-				}
+				p.flush(batch)
 
 				continue
 			}
 
-			batch = append(batch, record)
-
 			// Export when batch is full
-			if len(batch) >= p.config.maxBatchSize {
-				p.exportBatch(batch)
-				batch = batch[:0]
-			}
+			p.addToBatch(batch, record, data)
 
 		default:
 			// Try to dequeue a record (blocking with timeout)
-			record, err := p.dequeue()
+			record, data, err := p.dequeue()
 			if err != nil && !errors.Is(err, dque.ErrEmpty) {
 				// increase error count
 				wrapped := errors.Unwrap(err)
@@ -702,49 +858,41 @@ func (p *DQueBatchProcessor) processLoop() {
 			}
 			if errors.Is(err, dque.ErrEmpty) {
 				time.Sleep(100 * time.Millisecond)
-				if len(batch) > 0 {
-					p.exportBatch(batch)
-					batch = batch[:0]
-				}
+				p.flush(batch)
 
 				continue
 			}
 
-			batch = append(batch, record)
-
 			// Export when batch is full
-			if len(batch) >= p.config.maxBatchSize {
-				p.exportBatch(batch)
-				batch = batch[:0]
-			}
+			p.addToBatch(batch, record, data)
 		}
 	}
 }
 
-// dequeue attempts to dequeue a record with timeout
-func (p *DQueBatchProcessor) dequeue() (sdklog.Record, error) {
+// dequeue attempts to dequeue a record with timeout, it returns the record and its serialized form
+func (p *DQueBatchProcessor) dequeue() (sdklog.Record, []byte, error) {
 	// Use Dequeue (non-blocking) instead of DequeueBlock
 	iface, err := p.queue.Dequeue()
 	if err != nil {
-		return sdklog.Record{}, fmt.Errorf("dequeue error: %w", err)
+		return sdklog.Record{}, nil, fmt.Errorf("dequeue error: %w", err)
 	}
 
 	wrapper, ok := iface.(*dqueJSONWrapper)
 	if !ok {
-		return sdklog.Record{}, fmt.Errorf("invalid item type: %w", errors.New("expected type dqueJSONWrapper"))
+		return sdklog.Record{}, nil, fmt.Errorf("invalid item type: %w", errors.New("expected type dqueJSONWrapper"))
 	}
 
 	// Deserialize from JSON
 	var item logRecordItem
 	if err := json.Unmarshal(wrapper.data, &item); err != nil {
-		return sdklog.Record{}, fmt.Errorf("failed to unmarshal JSON: %w", err)
+		return sdklog.Record{}, nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
 	}
 
 	p.metrics.BufferedLogs.WithLabelValues(p.endpoint).Dec()
 	// Convert item back to record
 	record := itemToRecord(&item)
 
-	return record, nil
+	return record, wrapper.data, nil
 }
 
 // exportBatch exports a batch of log records using the blocking exporter
@@ -758,6 +906,13 @@ func (p *DQueBatchProcessor) exportBatch(batch []sdklog.Record) {
 
 	// Blocking export call (gRPC or HTTP)
 	if err := p.exporter.Export(ctx, batch); err != nil {
+		// A batch rejected for its size is never accepted when requeued, so it is split instead
+		if errors.Is(err, ErrBatchTooLarge) {
+			p.splitBatch(batch, err)
+
+			return
+		}
+
 		p.logger.Error(err, "failed to export batch", "size", len(batch))
 		p.metrics.DroppedLogs.WithLabelValues(p.endpoint, "export_error").Add(float64(len(batch)))
 
@@ -771,6 +926,28 @@ func (p *DQueBatchProcessor) exportBatch(batch []sdklog.Record) {
 	p.logger.V(3).Info("batch exported successfully", "size", len(batch))
 }
 
+// splitBatch exports the halves of a batch the backend rejected for its size.
+// A single record rejected for its size can never be exported, it is moved to the dead letter file.
+func (p *DQueBatchProcessor) splitBatch(batch []sdklog.Record, err error) {
+	if len(batch) > 1 {
+		p.logger.V(1).Info("splitting batch rejected as too large", "size", len(batch), "error", err.Error())
+		p.exportBatch(batch[:len(batch)/2])
+		p.exportBatch(batch[len(batch)/2:])
+
+		return
+	}
+
+	p.logger.Error(err, "record rejected as too large")
+	data, marshalErr := json.Marshal(recordToItem(batch[0]))
+	if marshalErr != nil {
+		p.logger.Error(marshalErr, "failed to marshal record for the dead letter file")
+		p.metrics.DroppedLogs.WithLabelValues(p.endpoint, "oversized").Inc()
+
+		return
+	}
+	p.moveToDeadLetter(data)
+}
+
 // requeueBatch puts failed records back into the queue
 func (p *DQueBatchProcessor) requeueBatch(batch []sdklog.Record) {
 	p.mu.Lock()
@@ -804,41 +981,31 @@ func (p *DQueBatchProcessor) ForceFlush(ctx context.Context) error {
 	p.logger.V(2).Info("force flushing batch processor")
 
 	// Drain the queue and export in batches
-	batch := make([]sdklog.Record, 0, p.config.maxBatchSize)
+	batch := &recordBatch{records: make([]sdklog.Record, 0, p.config.maxBatchSize)}
 
 	for {
 		select {
 		case <-ctx.Done():
-			if len(batch) > 0 {
-				p.exportBatch(batch)
-			}
+			p.flush(batch)
 
 			return ctx.Err()
 		default:
 			// Check if queue is empty
 			if p.queue.Size() == 0 {
-				if len(batch) > 0 {
-					p.exportBatch(batch)
-				}
+				p.flush(batch)
 
 				return nil
 			}
 
-			record, err := p.dequeue()
+			record, data, err := p.dequeue()
 			if err != nil {
 				// Queue is empty
-				if len(batch) > 0 {
-					p.exportBatch(batch)
-				}
+				p.flush(batch)
 
 				return nil
 			}
 
-			batch = append(batch, record)
-			if len(batch) >= p.config.maxBatchSize {
-				p.exportBatch(batch)
-				batch = batch[:0]
-			}
+			p.addToBatch(batch, record, data)
 		}
 	}
 }
@@ -5,20 +5,28 @@ package otlp_test
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel/attribute"
 	otlplog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/sdk/log/logtest"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
 
 	"github.com/gardener/logging/v1/pkg/client/otlp"
 	"github.com/gardener/logging/v1/pkg/metrics"
+	"github.com/gardener/logging/v1/pkg/types"
 )
 
 var _ = Describe("DQue Batch Processor Integration", func() {
@@ -128,6 +136,171 @@ var _ = Describe("DQue Batch Processor Integration", func() {
 			Expect(attrCount).To(Equal(4)) // All 4 attributes should be present
 		}
 	})
+
+	It("should persist and restore the resource and scope of log groups through dque", func() {
+		exporter := &testExporter{}
+
+		ctx := context.Background()
+		processor, err := otlp.NewDQueBatchProcessor(
+			ctx,
+			exporter,
+			logger,
+			testMetrics,
+			otlp.WithDQueueDir(filepath.Join(tempDir, "test-group-queue")),
+			otlp.WithExportInterval(time.Millisecond*1),
+			otlp.WithEndpoint("test-endpoint"),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			_ = processor.Shutdown(context.Background())
+		}()
+
+		factory := logtest.RecordFactory{
+			Timestamp: time.Now(),
+			Body:      otlplog.StringValue("grouped message"),
+			Resource: sdkresource.NewWithAttributes("https://example.com/schema",
+				attribute.String("service.name", "gardener-apiserver"),
+				attribute.Int64("pid", 42),
+			),
+			InstrumentationScope: &instrumentation.Scope{
+				Name:       "audit",
+				Version:    "v1",
+				Attributes: attribute.NewSet(attribute.String("component", "apiserver")),
+			},
+		}
+		record := factory.NewRecord()
+		Expect(processor.OnEmit(ctx, &record)).To(Succeed())
+
+		Eventually(func() int {
+			exporter.mu.Lock()
+			defer exporter.mu.Unlock()
+
+			return len(exporter.exportedRecords)
+		}, "2s", "100ms").Should(BeNumerically(">", 0))
+
+		exporter.mu.Lock()
+		defer exporter.mu.Unlock()
+		exported := exporter.exportedRecords[0]
+		Expect(exported.Resource().Attributes()).To(ConsistOf(
+			attribute.String("service.name", "gardener-apiserver"),
+			attribute.Int64("pid", 42),
+		))
+		Expect(exported.Resource().SchemaURL()).To(Equal("https://example.com/schema"))
+
+		scope := exported.InstrumentationScope()
+		Expect(scope.Name).To(Equal("audit"))
+		Expect(scope.Version).To(Equal("v1"))
+		Expect(scope.Attributes.ToSlice()).To(ConsistOf(attribute.String("component", "apiserver")))
+	})
+
+	It("should persist and restore structured bodies through dque", func() {
+		exporter := &testExporter{}
+
+		ctx := context.Background()
+		processor, err := otlp.NewDQueBatchProcessor(
+			ctx,
+			exporter,
+			logger,
+			testMetrics,
+			otlp.WithDQueueDir(filepath.Join(tempDir, "test-structured-queue")),
+			otlp.WithExportInterval(time.Millisecond*1),
+			otlp.WithEndpoint("test-endpoint"),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			_ = processor.Shutdown(context.Background())
+		}()
+
+		body := otlplog.MapValue(
+			otlplog.String("msg", "request served"),
+			otlplog.Int64("status", 200),
+			otlplog.Map("http", otlplog.String("method", "GET"), otlplog.Float64("duration", 0.5)),
+			otlplog.Slice("tags", otlplog.StringValue("a"), otlplog.BoolValue(true)),
+		)
+		factory := logtest.RecordFactory{
+			Timestamp: time.Now(),
+			Body:      body,
+		}
+		record := factory.NewRecord()
+		Expect(processor.OnEmit(ctx, &record)).To(Succeed())
+
+		Eventually(func() int {
+			exporter.mu.Lock()
+			defer exporter.mu.Unlock()
+
+			return len(exporter.exportedRecords)
+		}, "2s", "100ms").Should(BeNumerically(">", 0))
+
+		exporter.mu.Lock()
+		defer exporter.mu.Unlock()
+		Expect(exporter.exportedRecords[0].Body().Equal(body)).To(BeTrue())
+	})
+
+	It("should round-trip nested record attributes through dque without loss", func() {
+		exporter := &testExporter{}
+
+		ctx := context.Background()
+		processor, err := otlp.NewDQueBatchProcessor(
+			ctx,
+			exporter,
+			logger,
+			testMetrics,
+			otlp.WithDQueueDir(filepath.Join(tempDir, "test-nested-queue")),
+			otlp.WithExportInterval(time.Millisecond*1),
+			otlp.WithEndpoint("test-endpoint"),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(processor))
+		defer func() {
+			_ = provider.Shutdown(context.Background())
+		}()
+
+		entry := types.OutputEntry{
+			Timestamp: time.Now(),
+			Record: map[string]any{
+				"log": "nested attributes",
+				"labels": map[string]any{
+					"app":  "gardener",
+					"tier": "control-plane",
+				},
+				"annotations": map[string]any{
+					"checksum/config": "abc",
+					"owner": map[string]any{
+						"kind":  "Deployment",
+						"ready": true,
+						"ports": []any{int64(80), float64(1.5), []any{"nested"}},
+					},
+				},
+				"tags": []any{"a", map[string]any{"b": int64(1)}},
+			},
+		}
+		record := otlp.NewLogRecordBuilder().WithBody(entry.Record).WithAttributes(entry).Build()
+		provider.Logger("test").Emit(ctx, record)
+
+		Eventually(func() int {
+			exporter.mu.Lock()
+			defer exporter.mu.Unlock()
+
+			return len(exporter.exportedRecords)
+		}, "2s", "100ms").Should(BeNumerically(">", 0))
+
+		exporter.mu.Lock()
+		defer exporter.mu.Unlock()
+
+		received := make(map[string]any)
+		exporter.exportedRecords[0].WalkAttributes(func(kv otlplog.KeyValue) bool {
+			received[kv.Key] = logValueToAny(kv.Value)
+
+			return true
+		})
+
+		Expect(exporter.exportedRecords[0].Body().AsString()).To(Equal("nested attributes"))
+		Expect(received).To(Equal(map[string]any{
+			"labels":      entry.Record["labels"],
+			"annotations": entry.Record["annotations"],
+			"tags":        entry.Record["tags"],
+		}))
+	})
 })
 
 var _ = Describe("DQue Batch Processor with Functional Options", func() {
@@ -292,6 +465,162 @@ var _ = Describe("DQue Batch Processor with Functional Options", func() {
 	})
 })
 
+var _ = Describe("DQue Batch Processor batch bytes", func() {
+	var (
+		queueDir    string
+		testMetrics *metrics.FluentBitGardenerMetrics
+		exporter    *testExporter
+		batchSizes  []int
+		mu          sync.Mutex
+	)
+
+	BeforeEach(func() {
+		testMetrics = metrics.RegisterFluentBitGardenerMetrics(metrics.NewRegistry())
+		queueDir = GinkgoT().TempDir()
+		batchSizes = nil
+		exporter = &testExporter{
+			exportFunc: func(_ context.Context, records []sdklog.Record) error {
+				mu.Lock()
+				defer mu.Unlock()
+				batchSizes = append(batchSizes, len(records))
+
+				return nil
+			},
+		}
+	})
+
+	newProcessor := func(options ...otlp.DQueBatchProcessorOption) *otlp.DQueBatchProcessor {
+		processor, err := otlp.NewDQueBatchProcessor(context.Background(), exporter, logr.Discard(), testMetrics,
+			append([]otlp.DQueBatchProcessorOption{
+				otlp.WithDQueueDir(queueDir),
+				otlp.WithEndpoint("test-endpoint"),
+				otlp.WithMaxBatchSize(100),
+				otlp.WithExportInterval(time.Minute),
+			}, options...)...)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() { _ = processor.Shutdown(context.Background()) })
+
+		return processor
+	}
+
+	emit := func(processor *otlp.DQueBatchProcessor, bodySize int) {
+		record := logtest.RecordFactory{
+			Timestamp: time.Now(),
+			Body:      otlplog.StringValue(strings.Repeat("x", bodySize)),
+		}.NewRecord()
+		Expect(processor.OnEmit(context.Background(), &record)).To(Succeed())
+	}
+
+	exported := func() int {
+		exporter.mu.Lock()
+		defer exporter.mu.Unlock()
+
+		return len(exporter.exportedRecords)
+	}
+
+	It("should split batches exceeding the maximum bytes", func() {
+		processor := newProcessor(otlp.WithMaxBatchBytes(4000))
+
+		for range 10 {
+			emit(processor, 1000)
+		}
+		Expect(processor.ForceFlush(context.Background())).To(Succeed())
+
+		Eventually(exported).Should(Equal(10))
+		mu.Lock()
+		defer mu.Unlock()
+		for _, size := range batchSizes {
+			Expect(size).To(BeNumerically("<=", 3))
+		}
+	})
+
+	It("should move records exceeding the maximum bytes to the dead letter file", func() {
+		processor := newProcessor(otlp.WithMaxBatchBytes(4000))
+
+		emit(processor, 100)
+		emit(processor, 10000)
+		emit(processor, 100)
+
+		Eventually(exported).Should(Equal(2))
+		Eventually(func() float64 {
+			return testutil.ToFloat64(testMetrics.DroppedLogs.WithLabelValues("test-endpoint", "dead_letter"))
+		}).Should(Equal(1.0))
+
+		data, err := os.ReadFile(filepath.Join(queueDir, "dque-dead-letter.jsonl"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Count(string(data), "\n")).To(Equal(1))
+		Expect(string(data)).To(ContainSubstring(strings.Repeat("x", 10000)))
+	})
+
+	It("should drop records exceeding the maximum bytes once the dead letter file is full", func() {
+		processor := newProcessor(otlp.WithMaxBatchBytes(4000), otlp.WithDeadLetterBytes(0))
+
+		emit(processor, 10000)
+		emit(processor, 100)
+
+		Eventually(exported).Should(Equal(1))
+		Expect(testutil.ToFloat64(testMetrics.DroppedLogs.WithLabelValues("test-endpoint", "oversized"))).To(Equal(1.0))
+		Expect(filepath.Join(queueDir, "dque-dead-letter.jsonl")).NotTo(BeAnExistingFile())
+	})
+
+	It("should split batches rejected as too large and move rejected records to the dead letter file", func() {
+		var accepted []int
+		exporter.exportFunc = func(_ context.Context, records []sdklog.Record) error {
+			mu.Lock()
+			defer mu.Unlock()
+			size := 0
+			for _, r := range records {
+				size += len(r.Body().AsString())
+			}
+			if size > 2500 {
+				return fmt.Errorf("%w: status 413", otlp.ErrBatchTooLarge)
+			}
+			for _, r := range records {
+				accepted = append(accepted, len(r.Body().AsString()))
+			}
+
+			return nil
+		}
+		processor := newProcessor(otlp.WithMaxBatchBytes(0))
+
+		emit(processor, 1000)
+		emit(processor, 1000)
+		emit(processor, 5000)
+		emit(processor, 1000)
+		Expect(processor.ForceFlush(context.Background())).To(Succeed())
+
+		Eventually(func() float64 {
+			return testutil.ToFloat64(testMetrics.DroppedLogs.WithLabelValues("test-endpoint", "dead_letter"))
+		}).Should(Equal(1.0))
+		Eventually(func() []int {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return slices.Clone(accepted)
+		}).Should(ConsistOf(1000, 1000, 1000))
+		Expect(testutil.ToFloat64(testMetrics.DroppedLogs.WithLabelValues("test-endpoint", "export_error"))).To(BeZero())
+
+		data, err := os.ReadFile(filepath.Join(queueDir, "dque-dead-letter.jsonl"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Count(string(data), "\n")).To(Equal(1))
+		Expect(string(data)).To(ContainSubstring(strings.Repeat("x", 5000)))
+	})
+
+	It("should not limit the batch bytes when disabled", func() {
+		processor := newProcessor(otlp.WithMaxBatchBytes(0))
+
+		emit(processor, 10000)
+
+		Eventually(exported).Should(Equal(1))
+	})
+
+	It("should reject a negative maximum", func() {
+		_, err := otlp.NewDQueBatchProcessor(context.Background(), exporter, logr.Discard(), testMetrics,
+			otlp.WithDQueueDir(queueDir), otlp.WithEndpoint("test-endpoint"), otlp.WithMaxBatchBytes(-1))
+		Expect(err).To(MatchError("max batch bytes must not be negative"))
+	})
+})
+
 // testExporter is a simple exporter for testing
 type testExporter struct {
 	exportedRecords []sdklog.Record
@@ -319,3 +648,35 @@ func (*testExporter) Shutdown(_ context.Context) error {
 func (*testExporter) ForceFlush(_ context.Context) error {
 	return nil
 }
+
+// logValueToAny converts an exported log value back to the plain Go value of a record
+func logValueToAny(v otlplog.Value) any {
+	switch v.Kind() {
+	case otlplog.KindString:
+		return v.AsString()
+	case otlplog.KindInt64:
+		return v.AsInt64()
+	case otlplog.KindFloat64:
+		return v.AsFloat64()
+	case otlplog.KindBool:
+		return v.AsBool()
+	case otlplog.KindBytes:
+		return v.AsBytes()
+	case otlplog.KindMap:
+		m := make(map[string]any)
+		for _, kv := range v.AsMap() {
+			m[kv.Key] = logValueToAny(kv.Value)
+		}
+
+		return m
+	case otlplog.KindSlice:
+		s := make([]any, 0, len(v.AsSlice()))
+		for _, item := range v.AsSlice() {
+			s = append(s, logValueToAny(item))
+		}
+
+		return s
+	default:
+		return nil
+	}
+}
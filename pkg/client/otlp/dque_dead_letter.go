@@ -0,0 +1,57 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// errDeadLetterFull indicates the dead letter file has reached its maximum size
+var errDeadLetterFull = errors.New("dead letter file is full")
+
+// deadLetter stores records which can never be exported, one serialized logRecordItem per line.
+// The file is kept next to the dque so that the records can be inspected or replayed manually.
+type deadLetter struct {
+	path     string
+	maxBytes int64 // 0 disables the dead letter file
+
+	mu sync.Mutex
+}
+
+// write appends the serialized record to the dead letter file, unless it would exceed the maximum size
+func (d *deadLetter) write(data []byte) error {
+	if d.maxBytes <= 0 {
+		return errDeadLetterFull
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	f, err := os.OpenFile(d.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open dead letter file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat dead letter file: %w", err)
+	}
+	if info.Size()+int64(len(data))+1 > d.maxBytes {
+		return errDeadLetterFull
+	}
+
+	// The line is copied, appending the newline to data could overwrite the backing array of the caller
+	line := make([]byte, len(data)+1)
+	copy(line, data)
+	line[len(data)] = '\n'
+	if _, err = f.Write(line); err != nil {
+		return fmt.Errorf("failed to write dead letter file: %w", err)
+	}
+
+	return nil
+}
@@ -7,3 +7,7 @@ import "errors"
 
 // ErrThrottled is returned when an OTLP client is rate-limited.
 var ErrThrottled = errors.New("client throttled: rate limit exceeded")
+
+// ErrBatchTooLarge is wrapped by the export errors of batches the backend rejected for their size.
+// The dque batch processor splits such batches instead of requeueing them.
+var ErrBatchTooLarge = errors.New("batch too large")
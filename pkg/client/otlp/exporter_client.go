@@ -0,0 +1,218 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/api"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+// ExporterFactory creates the exporter of an ExporterClient. The context is cancelled when the client stops.
+// The limiter is nil without throttling, exporters lower an adaptive limiter when the backend throttles their exports.
+type ExporterFactory func(ctx context.Context, limiter *Limiter) (sdklog.Exporter, error)
+
+// ExporterClient is an implementation of Output shared by the clients of all exporting backends.
+// Records are built from the entries, limited by the throttle configuration and batched by the configured
+// batch processor, which hands them to the exporter of the backend.
+type ExporterClient struct {
+	logger         logr.Logger
+	component      string
+	endpoint       string
+	config         config.Config
+	loggerProvider *sdklog.LoggerProvider
+	groupLoggers   *GroupLoggers // Loggers for records of fluent-bit log groups and kubernetes resources
+	ctx            context.Context
+	cancel         context.CancelFunc
+	limiter        *Limiter // Rate limiter for throttling
+	metrics        *metrics.FluentBitGardenerMetrics
+	exporter       sdklog.Exporter
+	metricsSetup   *MetricsSetup // Shut down with the client if set
+}
+
+var _ api.Output = &ExporterClient{}
+
+// ExporterClientOption configures optional parts of an ExporterClient
+type ExporterClientOption func(c *ExporterClient)
+
+// WithClientMetricsSetup shuts down the OTLP metrics setup together with the client
+func WithClientMetricsSetup(metricsSetup *MetricsSetup) ExporterClientOption {
+	return func(c *ExporterClient) {
+		c.metricsSetup = metricsSetup
+	}
+}
+
+// NewExporterClient creates a client exporting with the exporter created by newExporter.
+// The component names the backend in logs and the batch processor, the endpoint labels the metrics.
+func NewExporterClient(ctx context.Context, cfg config.Config, logger logr.Logger, m *metrics.FluentBitGardenerMetrics,
+	component, endpoint string, newExporter ExporterFactory, opts ...ExporterClientOption) (*ExporterClient, error) {
+	// Use the provided context with cancel capability
+	clientCtx, cancel := context.WithCancel(ctx)
+
+	// An adaptive limiter is lowered by the throttled exports of the exporter
+	limiter := NewLimiter(cfg.OTLPConfig, endpoint, m)
+	if limiter != nil {
+		logger.V(1).Info("throttling enabled",
+			"requests_per_sec", cfg.OTLPConfig.ThrottleRequestsPerSec,
+			"bytes_per_sec", cfg.OTLPConfig.ThrottleBytesPerSec,
+			"adaptive", cfg.OTLPConfig.ThrottleAdaptive)
+	}
+
+	exporter, err := newExporter(clientCtx, limiter)
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	// Create batch processor using factory
+	processorFactory := NewBatchProcessorFactory(logger, m)
+	batchProcessor, err := processorFactory.Create(clientCtx, cfg, exporter, component)
+	if err != nil {
+		cancel()
+
+		return nil, fmt.Errorf("failed to create batch processor: %w", err)
+	}
+
+	// Build resource attributes
+	resource := NewResourceAttributesBuilder().
+		WithHostname(cfg).
+		Build()
+
+	loggerProvider := sdklog.NewLoggerProvider(
+		sdklog.WithResource(resource),
+		sdklog.WithProcessor(batchProcessor),
+	)
+
+	// Build instrumentation scope options
+	scopeOptions := NewScopeAttributesBuilder().
+		WithVersion(PluginVersion()).
+		WithSchemaURL(SchemaURL).
+		Build()
+
+	client := &ExporterClient{
+		logger:         logger.WithValues("endpoint", endpoint, "component", component),
+		component:      component,
+		endpoint:       endpoint,
+		config:         cfg,
+		loggerProvider: loggerProvider,
+		groupLoggers:   NewGroupLoggers(batchProcessor, loggerProvider.Logger(PluginName, scopeOptions...), resource, scopeOptions...),
+		ctx:            clientCtx,
+		cancel:         cancel,
+		limiter:        limiter,
+		metrics:        m,
+		exporter:       exporter,
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	logger.V(1).Info("client created",
+		"component", component,
+		"endpoint", endpoint,
+		"processorType", ProcessorType(cfg),
+	)
+
+	return client, nil
+}
+
+// Handle builds the record of the log entry and hands it to the batch processor
+func (c *ExporterClient) Handle(entry types.OutputEntry) error {
+	// Check if the client's context is cancelled
+	if c.ctx.Err() != nil {
+		return c.ctx.Err()
+	}
+
+	// Check rate limit if throttling is enabled
+	if c.limiter != nil {
+		// Try to acquire the tokens of the record from the rate limiter
+		// Allow returns false if the record would exceed the record or byte limit
+		if !c.limiter.Allow(RecordSize(entry.Record)) {
+			c.metrics.ThrottledLogs.WithLabelValues(c.endpoint).Inc()
+
+			return ErrThrottled
+		}
+	}
+
+	// Build log record using builder pattern
+	builder := NewLogRecordBuilder().
+		WithConfig(c.config).
+		WithTimestamp(entry.Timestamp).
+		WithSeverity(entry.Record).
+		WithBody(entry.Record).
+		WithTraceContext(entry.Record).
+		WithAttributes(entry)
+	logRecord := builder.Build()
+	if builder.StructuredBodyFallback() {
+		c.metrics.StructuredBodyFallbacks.WithLabelValues(c.endpoint).Inc()
+	}
+
+	// Emit the log record using the client's context, carrying the trace context of the record.
+	// Records of a fluent-bit log group keep the resource and scope of their group,
+	// with ResourceGrouping the Kubernetes attributes are added to the resource.
+	c.groupLoggers.Logger(entry.Group, builder.ResourceAttributes()...).Emit(builder.EmitContext(c.ctx), logRecord)
+
+	// Increment the output logs counter
+	c.metrics.OutputClientLogs.WithLabelValues(c.endpoint).Inc()
+
+	return nil
+}
+
+// Stop shuts down the client immediately
+func (c *ExporterClient) Stop() {
+	c.logger.V(2).Info(fmt.Sprintf("stopping %s", c.component))
+	c.shutdown(time.Second, false)
+}
+
+// StopWait stops the client and waits for all logs to be sent
+func (c *ExporterClient) StopWait() {
+	c.logger.V(2).Info(fmt.Sprintf("stopping %s with wait", c.component))
+	c.shutdown(30*time.Second, true)
+}
+
+// shutdown cancels the client and shuts down the logger provider and the metrics setup within the timeout,
+// flushing the batched records first if wait is set
+func (c *ExporterClient) shutdown(timeout time.Duration, wait bool) {
+	c.cancel()
+
+	// Create timeout context from background, not from the cancelled c.ctx
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if wait {
+		if err := c.loggerProvider.ForceFlush(ctx); err != nil {
+			c.logger.Error(err, "error during logger provider force flush")
+		}
+	}
+
+	if err := c.loggerProvider.Shutdown(ctx); err != nil {
+		c.logger.Error(err, "error during logger provider shutdown")
+	}
+
+	// Use singleton metrics setup shutdown (idempotent)
+	if c.metricsSetup == nil {
+		return
+	}
+
+	if err := c.metricsSetup.Shutdown(ctx); err != nil {
+		c.logger.Error(err, "error during meter provider shutdown")
+	}
+}
+
+// Endpoint returns the configured endpoint, or the active endpoint if failover endpoints are configured
+func (c *ExporterClient) Endpoint() string {
+	if failover, ok := c.exporter.(*FailoverExporter); ok {
+		return failover.Active()
+	}
+
+	return c.endpoint
+}
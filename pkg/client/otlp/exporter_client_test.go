@@ -0,0 +1,111 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	otlplog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/log"
+	"github.com/gardener/logging/v1/pkg/metrics"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+var _ = Describe("ExporterClient", func() {
+	var (
+		testMetrics *metrics.FluentBitGardenerMetrics
+		exporter    *testExporter
+		limiter     *otlp.Limiter
+	)
+
+	BeforeEach(func() {
+		testMetrics = metrics.RegisterFluentBitGardenerMetrics(metrics.NewRegistry())
+		exporter = &testExporter{}
+		limiter = nil
+	})
+
+	newClient := func(configMap map[string]any) *otlp.ExporterClient {
+		base := map[string]any{"DQueDir": GinkgoT().TempDir(), "UseSDKBatchProcessor": "true"}
+		for k, v := range configMap {
+			base[k] = v
+		}
+		cfg, err := config.ParseConfig(base)
+		Expect(err).NotTo(HaveOccurred())
+
+		client, err := otlp.NewExporterClient(context.Background(), *cfg, log.NewNoop(), testMetrics, "test", "localhost:4317",
+			func(_ context.Context, l *otlp.Limiter) (sdklog.Exporter, error) {
+				limiter = l
+
+				return exporter, nil
+			})
+		Expect(err).NotTo(HaveOccurred())
+
+		return client
+	}
+
+	entry := func(line string) types.OutputEntry {
+		return types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": line}}
+	}
+
+	It("should build the records of the entries and export them when stopped with wait", func() {
+		client := newClient(nil)
+		Expect(client.Endpoint()).To(Equal("localhost:4317"))
+		Expect(limiter).To(BeNil())
+
+		Expect(client.Handle(entry("first"))).To(Succeed())
+		Expect(client.Handle(entry("second"))).To(Succeed())
+		client.StopWait()
+
+		Expect(exporter.exportedRecords).To(HaveLen(2))
+		Expect(exporter.exportedRecords[0].Body().AsString()).To(Equal("first"))
+		Expect(testutil.ToFloat64(testMetrics.OutputClientLogs.WithLabelValues("localhost:4317"))).To(Equal(2.0))
+
+		Expect(client.Handle(entry("stopped"))).To(MatchError(context.Canceled))
+	})
+
+	It("should throttle the records and bytes and hand the limiter to the exporter", func() {
+		client := newClient(map[string]any{"ThrottleEnabled": "true", "ThrottleBytesPerSec": "100"})
+		defer client.Stop()
+		Expect(limiter).NotTo(BeNil())
+
+		// The burst of twice the limit allows one record of about 120 bytes
+		Expect(client.Handle(entry(strings.Repeat("a", 110)))).To(Succeed())
+		Expect(client.Handle(entry(strings.Repeat("a", 110)))).To(MatchError(otlp.ErrThrottled))
+		Expect(testutil.ToFloat64(testMetrics.ThrottledLogs.WithLabelValues("localhost:4317"))).To(Equal(1.0))
+	})
+
+	It("should count structured bodies sent as strings for their size", func() {
+		client := newClient(map[string]any{"StructuredBody": "true", "MaxStructuredBodySize": "100"})
+
+		Expect(client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": map[string]any{"msg": "short"}}})).To(Succeed())
+		Expect(client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": map[string]any{"msg": strings.Repeat("x", 200)}}})).To(Succeed())
+		client.StopWait()
+
+		Expect(exporter.exportedRecords).To(HaveLen(2))
+		Expect(exporter.exportedRecords[0].Body().Kind()).To(Equal(otlplog.KindMap))
+		Expect(exporter.exportedRecords[1].Body().Kind()).To(Equal(otlplog.KindString))
+		Expect(testutil.ToFloat64(testMetrics.StructuredBodyFallbacks.WithLabelValues("localhost:4317"))).To(Equal(1.0))
+	})
+
+	It("should return the error of the exporter factory", func() {
+		cfg, err := config.ParseConfig(map[string]any{})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = otlp.NewExporterClient(context.Background(), *cfg, log.NewNoop(), testMetrics, "test", "localhost:4317",
+			func(context.Context, *otlp.Limiter) (sdklog.Exporter, error) {
+				return nil, errors.New("no exporter")
+			})
+		Expect(err).To(MatchError("no exporter"))
+	})
+})
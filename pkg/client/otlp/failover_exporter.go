@@ -0,0 +1,207 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/retry"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
+)
+
+// EndpointExporterFactory creates the exporter of a failover endpoint from the client configuration.
+// The exporter marks the errors of transient failures with retry.Retryable, e.g. transport errors, server errors,
+// rate limiting and unavailable collectors. Only transient failures count for the circuit of the endpoint.
+type EndpointExporterFactory func(cfg config.Config, endpoint string) (sdklog.Exporter, error)
+
+// failoverEndpoint is an endpoint of the failover exporter with the state of its circuit
+type failoverEndpoint struct {
+	endpoint string
+	exporter sdklog.Exporter
+	// failures counts the consecutive failed exports, the circuit opens at the failure threshold
+	failures int
+	// openUntil is the time until which the open circuit skips the endpoint
+	openUntil time.Time
+}
+
+// FailoverExporter exports to the first healthy endpoint of an ordered list of endpoints.
+// An endpoint failing the configured number of consecutive exports is skipped for the cooldown,
+// afterwards it is tried again, so that exports fail back to the preferred endpoints once they recover.
+type FailoverExporter struct {
+	logger    logr.Logger
+	primary   string
+	endpoints []*failoverEndpoint
+	threshold int
+	cooldown  time.Duration
+	retry     *config.RetryConfig
+	metrics   *metrics.FluentBitGardenerMetrics
+
+	mu     sync.Mutex
+	active int
+}
+
+var _ sdklog.Exporter = &FailoverExporter{}
+
+// NewFailoverExporter creates an exporter per endpoint with newExporter, the first endpoint is the primary endpoint.
+// The endpoint exporters are created without retries, the failover exporter retries the exports over all endpoints
+// with the retry configuration of the client.
+func NewFailoverExporter(
+	cfg config.Config,
+	endpoints []string,
+	logger logr.Logger,
+	m *metrics.FluentBitGardenerMetrics,
+	newExporter EndpointExporterFactory,
+) (*FailoverExporter, error) {
+	endpointCfg := cfg
+	endpointCfg.OTLPConfig.RetryEnabled = false
+	endpointCfg.OTLPConfig.RetryConfig = &config.RetryConfig{}
+
+	e := &FailoverExporter{
+		logger:    logger.WithValues("primary", endpoints[0]),
+		primary:   endpoints[0],
+		threshold: cfg.OTLPConfig.FailoverFailureThreshold,
+		cooldown:  cfg.OTLPConfig.FailoverCooldown,
+		retry:     cfg.OTLPConfig.RetryConfig,
+		metrics:   m,
+	}
+	for _, endpoint := range endpoints {
+		exporter, err := newExporter(endpointCfg, endpoint)
+		if err != nil {
+			_ = e.Shutdown(context.Background())
+
+			return nil, fmt.Errorf("failed to create exporter for endpoint %s: %w", endpoint, err)
+		}
+		e.endpoints = append(e.endpoints, &failoverEndpoint{endpoint: endpoint, exporter: exporter})
+	}
+	e.setActive(0)
+
+	return e, nil
+}
+
+// Export exports the records to the first endpoint accepting them, retrying with backoff if all endpoints failed
+// transiently. Permanent failures, e.g. rejected records, are returned immediately.
+func (e *FailoverExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	return retry.Do(ctx, e.retry, e.logger, func(ctx context.Context) error {
+		return e.export(ctx, records)
+	})
+}
+
+// export tries the endpoints with closed circuits in order, all endpoints are tried if all circuits are open.
+// A permanent failure is returned without trying further endpoints, since they would reject the records as well,
+// and without changing the circuit of the endpoint, since it is healthy.
+func (e *FailoverExporter) export(ctx context.Context, records []sdklog.Record) error {
+	var errs []error
+	for _, i := range e.candidates() {
+		endpoint := e.endpoints[i]
+		err := endpoint.exporter.Export(ctx, records)
+		if err != nil && !retry.IsRetryable(err) {
+			return fmt.Errorf("%s: %w", endpoint.endpoint, err)
+		}
+		e.record(i, err)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", endpoint.endpoint, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	// The joined errors are retryable, since all of them are
+	return errors.Join(errs...)
+}
+
+// candidates returns the indexes of the endpoints to try in order
+func (e *FailoverExporter) candidates() []int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	candidates := make([]int, 0, len(e.endpoints))
+	for i, endpoint := range e.endpoints {
+		if now.After(endpoint.openUntil) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		for i := range e.endpoints {
+			candidates = append(candidates, i)
+		}
+	}
+
+	return candidates
+}
+
+// record updates the circuit of the endpoint with the result of an export
+func (e *FailoverExporter) record(i int, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	endpoint := e.endpoints[i]
+	if err == nil {
+		endpoint.failures = 0
+		endpoint.openUntil = time.Time{}
+		if i != e.active {
+			e.logger.Info("switching active endpoint", "from", e.endpoints[e.active].endpoint, "to", endpoint.endpoint)
+			e.setActive(i)
+		}
+
+		return
+	}
+
+	endpoint.failures++
+	if endpoint.failures >= e.threshold {
+		endpoint.openUntil = time.Now().Add(e.cooldown)
+		e.logger.V(1).Info("endpoint circuit opened", "endpoint", endpoint.endpoint,
+			"failures", endpoint.failures, "cooldown", e.cooldown, "error", err.Error())
+	}
+}
+
+// setActive sets the active endpoint and the ActiveEndpoint gauge, the caller holds the lock or owns the exporter
+func (e *FailoverExporter) setActive(active int) {
+	e.active = active
+	for i, endpoint := range e.endpoints {
+		value := 0.0
+		if i == active {
+			value = 1
+		}
+		e.metrics.ActiveEndpoint.WithLabelValues(e.primary, endpoint.endpoint).Set(value)
+	}
+}
+
+// Active returns the endpoint which accepted the last export, initially the primary endpoint
+func (e *FailoverExporter) Active() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.endpoints[e.active].endpoint
+}
+
+// Shutdown shuts down the exporters of all endpoints
+func (e *FailoverExporter) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, endpoint := range e.endpoints {
+		errs = append(errs, endpoint.exporter.Shutdown(ctx))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ForceFlush flushes the exporters of all endpoints
+func (e *FailoverExporter) ForceFlush(ctx context.Context) error {
+	var errs []error
+	for _, endpoint := range e.endpoints {
+		errs = append(errs, endpoint.exporter.ForceFlush(ctx))
+	}
+
+	return errors.Join(errs...)
+}
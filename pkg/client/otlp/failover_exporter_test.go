@@ -0,0 +1,186 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/client/retry"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
+)
+
+var _ = Describe("FailoverExporter", func() {
+	var (
+		testMetrics *metrics.FluentBitGardenerMetrics
+		exporters   map[string]*testExporter
+		down        map[string]*atomic.Bool
+		rejected    map[string]*atomic.Bool
+		cfg         config.Config
+	)
+
+	BeforeEach(func() {
+		testMetrics = metrics.RegisterFluentBitGardenerMetrics(metrics.NewRegistry())
+		exporters = map[string]*testExporter{}
+		down = map[string]*atomic.Bool{}
+		rejected = map[string]*atomic.Bool{}
+		cfg = config.Config{OTLPConfig: config.OTLPConfig{
+			FailoverFailureThreshold: 2,
+			FailoverCooldown:         100 * time.Millisecond,
+		}}
+	})
+
+	newExporter := func(endpoints ...string) *otlp.FailoverExporter {
+		exporter, err := otlp.NewFailoverExporter(cfg, endpoints, logr.Discard(), testMetrics,
+			func(endpointCfg config.Config, endpoint string) (sdklog.Exporter, error) {
+				Expect(endpointCfg.OTLPConfig.RetryConfig).NotTo(BeNil())
+				Expect(endpointCfg.OTLPConfig.RetryEnabled).To(BeFalse())
+
+				down[endpoint] = &atomic.Bool{}
+				rejected[endpoint] = &atomic.Bool{}
+				exporters[endpoint] = &testExporter{exportFunc: func(context.Context, []sdklog.Record) error {
+					if down[endpoint].Load() {
+						return retry.Retryable(errors.New(endpoint+" unavailable"), 0)
+					}
+					if rejected[endpoint].Load() {
+						return errors.New(endpoint + " rejected the records")
+					}
+
+					return nil
+				}}
+
+				return exporters[endpoint], nil
+			})
+		Expect(err).NotTo(HaveOccurred())
+
+		return exporter
+	}
+
+	export := func(exporter *otlp.FailoverExporter) error {
+		return exporter.Export(context.Background(), make([]sdklog.Record, 1))
+	}
+
+	active := func(endpoint string) float64 {
+		return testutil.ToFloat64(testMetrics.ActiveEndpoint.WithLabelValues("primary:4317", endpoint))
+	}
+
+	It("should export to the primary endpoint", func() {
+		exporter := newExporter("primary:4317", "secondary:4317")
+
+		Expect(export(exporter)).To(Succeed())
+		Expect(exporters["primary:4317"].exportedRecords).To(HaveLen(1))
+		Expect(exporters["secondary:4317"].exportedRecords).To(BeEmpty())
+		Expect(exporter.Active()).To(Equal("primary:4317"))
+		Expect(active("primary:4317")).To(Equal(1.0))
+		Expect(active("secondary:4317")).To(Equal(0.0))
+	})
+
+	It("should fail over in order and skip endpoints with open circuits", func() {
+		exporter := newExporter("primary:4317", "secondary:4317", "tertiary:4317")
+		down["primary:4317"].Store(true)
+		down["secondary:4317"].Store(true)
+
+		Expect(export(exporter)).To(Succeed())
+		Expect(exporter.Active()).To(Equal("tertiary:4317"))
+		Expect(active("primary:4317")).To(Equal(0.0))
+		Expect(active("tertiary:4317")).To(Equal(1.0))
+
+		// The second failure opens the circuits, afterwards the failed endpoints are skipped
+		Expect(export(exporter)).To(Succeed())
+		Expect(export(exporter)).To(Succeed())
+		Expect(exporters["primary:4317"].exportedRecords).To(HaveLen(2))
+		Expect(exporters["secondary:4317"].exportedRecords).To(HaveLen(2))
+		Expect(exporters["tertiary:4317"].exportedRecords).To(HaveLen(3))
+	})
+
+	It("should fail back to the primary endpoint after the cooldown", func() {
+		exporter := newExporter("primary:4317", "secondary:4317")
+		down["primary:4317"].Store(true)
+
+		Expect(export(exporter)).To(Succeed())
+		Expect(export(exporter)).To(Succeed())
+		Expect(exporter.Active()).To(Equal("secondary:4317"))
+
+		down["primary:4317"].Store(false)
+		Expect(export(exporter)).To(Succeed())
+		Expect(exporter.Active()).To(Equal("secondary:4317"), "the circuit of the primary endpoint is still open")
+
+		Eventually(func() string {
+			Expect(export(exporter)).To(Succeed())
+
+			return exporter.Active()
+		}).WithPolling(20 * time.Millisecond).Should(Equal("primary:4317"))
+		Expect(active("primary:4317")).To(Equal(1.0))
+		Expect(active("secondary:4317")).To(Equal(0.0))
+	})
+
+	It("should try all endpoints if all circuits are open", func() {
+		exporter := newExporter("primary:4317", "secondary:4317")
+		down["primary:4317"].Store(true)
+		down["secondary:4317"].Store(true)
+
+		err := export(exporter)
+		Expect(err).To(MatchError(ContainSubstring("primary:4317 unavailable")))
+		Expect(err).To(MatchError(ContainSubstring("secondary:4317 unavailable")))
+		Expect(export(exporter)).NotTo(Succeed())
+
+		down["secondary:4317"].Store(false)
+		Expect(export(exporter)).To(Succeed())
+		Expect(exporter.Active()).To(Equal("secondary:4317"))
+	})
+
+	It("should retry the exports over all endpoints", func() {
+		cfg.OTLPConfig.RetryConfig = &config.RetryConfig{
+			Enabled:         true,
+			InitialInterval: 10 * time.Millisecond,
+			MaxInterval:     10 * time.Millisecond,
+			MaxElapsedTime:  time.Second,
+		}
+		exporter := newExporter("primary:4317", "secondary:4317")
+		down["primary:4317"].Store(true)
+		down["secondary:4317"].Store(true)
+		time.AfterFunc(50*time.Millisecond, func() { down["secondary:4317"].Store(false) })
+
+		Expect(export(exporter)).To(Succeed())
+		Expect(exporter.Active()).To(Equal("secondary:4317"))
+	})
+
+	It("should return permanent failures without failing over or opening the circuit", func() {
+		cfg.OTLPConfig.RetryConfig = &config.RetryConfig{
+			Enabled:         true,
+			InitialInterval: 10 * time.Millisecond,
+			MaxInterval:     10 * time.Millisecond,
+			MaxElapsedTime:  time.Second,
+		}
+		exporter := newExporter("primary:4317", "secondary:4317")
+		rejected["primary:4317"].Store(true)
+
+		for range 3 {
+			Expect(export(exporter)).To(MatchError("primary:4317: primary:4317 rejected the records"))
+		}
+		Expect(exporters["primary:4317"].exportedRecords).To(HaveLen(3), "permanent failures are not retried")
+		Expect(exporters["secondary:4317"].exportedRecords).To(BeEmpty())
+
+		rejected["primary:4317"].Store(false)
+		Expect(export(exporter)).To(Succeed())
+		Expect(exporter.Active()).To(Equal("primary:4317"))
+		Expect(exporters["primary:4317"].exportedRecords).To(HaveLen(4), "the circuit of the primary endpoint is closed")
+	})
+
+	It("should shut down the exporters of all endpoints", func() {
+		exporter := newExporter("primary:4317", "secondary:4317")
+		Expect(exporter.ForceFlush(context.Background())).To(Succeed())
+		Expect(exporter.Shutdown(context.Background())).To(Succeed())
+	})
+})
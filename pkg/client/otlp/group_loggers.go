@@ -0,0 +1,147 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	otlplog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+// maxGroupProviders bounds the number of distinct log group and kubernetes resources kept at once
+const maxGroupProviders = 1024
+
+// GroupLoggers hands out OTLP loggers for records of fluent-bit log groups
+// and for records grouped per kubernetes resource.
+// Records of the same resource share a LoggerProvider, so the exporter
+// emits them under their resource and instrumentation scope.
+// All providers feed the same processor, which stays owned by the default provider.
+type GroupLoggers struct {
+	processor    sdklog.Processor
+	fallback     otlplog.Logger
+	resource     *sdkresource.Resource
+	scopeOptions []otlplog.LoggerOption
+
+	mu        sync.Mutex
+	providers map[attribute.Distinct]*sdklog.LoggerProvider
+}
+
+// NewGroupLoggers creates GroupLoggers emitting to the given processor.
+// The fallback logger is used for records which neither belong to a log group nor carry resource attributes.
+// Resource attributes of records are added to the given client resource and emitted with the given scope options.
+func NewGroupLoggers(processor sdklog.Processor, fallback otlplog.Logger, resource *sdkresource.Resource,
+	scopeOptions ...otlplog.LoggerOption) *GroupLoggers {
+	if resource == nil {
+		resource = sdkresource.Empty()
+	}
+
+	return &GroupLoggers{
+		processor:    processor,
+		fallback:     fallback,
+		resource:     resource,
+		scopeOptions: scopeOptions,
+		providers:    make(map[attribute.Distinct]*sdklog.LoggerProvider),
+	}
+}
+
+// Logger returns the logger for the given log group and resource attributes.
+// Records of a log group keep the resource and scope of their group, extended by the resource attributes.
+// Other records are emitted under the client resource extended by the resource attributes.
+func (g *GroupLoggers) Logger(group *types.LogGroup, resourceAttrs ...attribute.KeyValue) otlplog.Logger {
+	if group == nil || (len(group.ResourceAttributes) == 0 && group.ScopeName == "") {
+		if len(resourceAttrs) == 0 {
+			return g.fallback
+		}
+
+		resource, err := sdkresource.Merge(g.resource, sdkresource.NewWithAttributes(g.resource.SchemaURL(), resourceAttrs...))
+		if err != nil {
+			// The schema URLs are identical, merging cannot conflict
+			return g.fallback
+		}
+
+		return g.provider(resource).Logger(PluginName, g.scopeOptions...)
+	}
+
+	attrs := append(toAttributes(group.ResourceAttributes), resourceAttrs...)
+	resource := sdkresource.NewWithAttributes(group.ResourceSchemaURL, attrs...)
+
+	scopeName := group.ScopeName
+	if scopeName == "" {
+		scopeName = PluginName
+	}
+
+	return g.provider(resource).Logger(scopeName,
+		otlplog.WithInstrumentationVersion(group.ScopeVersion),
+		otlplog.WithInstrumentationAttributes(toAttributes(group.ScopeAttributes)...),
+		otlplog.WithSchemaURL(group.ScopeSchemaURL),
+	)
+}
+
+func (g *GroupLoggers) provider(resource *sdkresource.Resource) *sdklog.LoggerProvider {
+	key := resource.Equivalent()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if p, ok := g.providers[key]; ok {
+		return p
+	}
+
+	// The providers are only handles on the shared processor, dropping them does not lose records
+	if len(g.providers) >= maxGroupProviders {
+		clear(g.providers)
+	}
+
+	p := sdklog.NewLoggerProvider(
+		sdklog.WithResource(resource),
+		sdklog.WithProcessor(g.processor),
+	)
+	g.providers[key] = p
+
+	return p
+}
+
+// toAttributes converts log group attributes to OpenTelemetry attributes.
+// Nested maps and slices of mixed types are serialized to JSON strings.
+func toAttributes(m map[string]any) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(m))
+	for k, v := range m {
+		attrs = append(attrs, toAttribute(k, v))
+	}
+
+	return attrs
+}
+
+func toAttribute(key string, value any) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case uint64:
+		return attribute.Int64(key, int64(v)) //nolint:gosec // G115: attribute values beyond int64 are not expected
+	case float64:
+		return attribute.Float64(key, v)
+	case []byte:
+		return attribute.String(key, string(v))
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return attribute.String(key, fmt.Sprintf("%v", v))
+		}
+
+		return attribute.String(key, string(data))
+	}
+}
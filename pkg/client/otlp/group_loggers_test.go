@@ -0,0 +1,131 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	otlplog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+var _ = Describe("GroupLoggers", func() {
+	var (
+		exporter *testExporter
+		loggers  *otlp.GroupLoggers
+	)
+
+	BeforeEach(func() {
+		exporter = &testExporter{}
+		processor := sdklog.NewSimpleProcessor(exporter)
+		resource := sdkresource.NewSchemaless(attribute.String("host.name", "node-1"))
+		provider := sdklog.NewLoggerProvider(
+			sdklog.WithResource(resource),
+			sdklog.WithProcessor(processor),
+		)
+		scopeOptions := []otlplog.LoggerOption{otlplog.WithInstrumentationVersion("v1.2.3")}
+		loggers = otlp.NewGroupLoggers(processor, provider.Logger(otlp.PluginName, scopeOptions...), resource, scopeOptions...)
+	})
+
+	emit := func(group *types.LogGroup, resourceAttrs ...attribute.KeyValue) sdklog.Record {
+		var record otlplog.Record
+		record.SetBody(otlplog.StringValue("test"))
+		loggers.Logger(group, resourceAttrs...).Emit(context.Background(), record)
+
+		Expect(exporter.exportedRecords).NotTo(BeEmpty())
+
+		return exporter.exportedRecords[len(exporter.exportedRecords)-1]
+	}
+
+	It("should use the fallback logger for records without a log group", func() {
+		record := emit(nil)
+
+		Expect(record.Resource().Attributes()).To(ConsistOf(attribute.String("host.name", "node-1")))
+		Expect(record.InstrumentationScope().Name).To(Equal(otlp.PluginName))
+	})
+
+	It("should use the fallback logger for empty log groups", func() {
+		record := emit(&types.LogGroup{})
+
+		Expect(record.Resource().Attributes()).To(ConsistOf(attribute.String("host.name", "node-1")))
+	})
+
+	It("should emit records with the resource and scope of their log group", func() {
+		record := emit(&types.LogGroup{
+			ResourceAttributes: map[string]any{
+				"service.name": "gardener-apiserver",
+				"pid":          int64(42),
+				"labels":       map[string]any{"app": "test"},
+			},
+			ResourceSchemaURL: "https://opentelemetry.io/schemas/1.27.0",
+			ScopeName:         "audit",
+			ScopeVersion:      "v1",
+			ScopeAttributes:   map[string]any{"component": "apiserver"},
+		})
+
+		Expect(record.Resource().Attributes()).To(ConsistOf(
+			attribute.String("service.name", "gardener-apiserver"),
+			attribute.Int64("pid", 42),
+			attribute.String("labels", `{"app":"test"}`),
+		))
+		Expect(record.Resource().SchemaURL()).To(Equal("https://opentelemetry.io/schemas/1.27.0"))
+		Expect(record.InstrumentationScope().Name).To(Equal("audit"))
+		Expect(record.InstrumentationScope().Version).To(Equal("v1"))
+		scopeAttrs := record.InstrumentationScope().Attributes
+		Expect(scopeAttrs.ToSlice()).To(ConsistOf(attribute.String("component", "apiserver")))
+	})
+
+	It("should default the scope name to the plugin name", func() {
+		record := emit(&types.LogGroup{ResourceAttributes: map[string]any{"service.name": "test"}})
+
+		Expect(record.InstrumentationScope().Name).To(Equal(otlp.PluginName))
+	})
+
+	It("should share the resource between records of the same group", func() {
+		group := &types.LogGroup{ResourceAttributes: map[string]any{"service.name": "test"}}
+
+		first := emit(group)
+		second := emit(&types.LogGroup{ResourceAttributes: map[string]any{"service.name": "test"}})
+
+		Expect(second.Resource()).To(BeIdenticalTo(first.Resource()))
+	})
+
+	Describe("resource attributes", func() {
+		podAttrs := []attribute.KeyValue{
+			attribute.String("k8s.namespace.name", "garden"),
+			attribute.String("k8s.pod.name", "gardener-apiserver-0"),
+			attribute.String("k8s.container.name", "apiserver"),
+		}
+
+		It("should add the resource attributes to the client resource", func() {
+			record := emit(nil, podAttrs...)
+
+			Expect(record.Resource().Attributes()).To(ConsistOf(append(podAttrs, attribute.String("host.name", "node-1"))))
+			Expect(record.InstrumentationScope().Name).To(Equal(otlp.PluginName))
+			Expect(record.InstrumentationScope().Version).To(Equal("v1.2.3"))
+		})
+
+		It("should group records of the same container under one resource", func() {
+			first := emit(nil, podAttrs...)
+			second := emit(nil, podAttrs...)
+			other := emit(nil, attribute.String("k8s.pod.name", "etcd-main-0"))
+
+			Expect(second.Resource()).To(BeIdenticalTo(first.Resource()))
+			Expect(other.Resource()).NotTo(BeIdenticalTo(first.Resource()))
+		})
+
+		It("should add the resource attributes to the resource of a log group", func() {
+			record := emit(&types.LogGroup{ResourceAttributes: map[string]any{"service.name": "test"}}, podAttrs...)
+
+			Expect(record.Resource().Attributes()).To(ConsistOf(append(podAttrs, attribute.String("service.name", "test"))))
+		})
+	})
+})
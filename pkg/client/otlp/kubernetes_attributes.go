@@ -0,0 +1,184 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	otlplog "go.opentelemetry.io/otel/log"
+
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+var (
+	// generatedPodName matches deployment pod names: <deployment>-<pod-template-hash>-<suffix>.
+	// Kubernetes generates the hash and the suffix from a restricted alphabet without vowels.
+	generatedPodName = regexp.MustCompile(`^(.+)-([bcdfghjklmnpqrstvwxz2456789]{6,10})-[bcdfghjklmnpqrstvwxz2456789]{5}$`)
+	// generatedReplicaSetName matches replica set names: <deployment>-<pod-template-hash>
+	generatedReplicaSetName = regexp.MustCompile(`^(.+)-[bcdfghjklmnpqrstvwxz2456789]{6,10}$`)
+	// scheduledJobName matches job names created by cron jobs: <cronjob>-<scheduled time in minutes>
+	scheduledJobName = regexp.MustCompile(`^(.+)-\d{8,}$`)
+	// statefulSetPodName matches stateful set pod names: <statefulset>-<ordinal>
+	statefulSetPodName = regexp.MustCompile(`^(.+)-\d+$`)
+)
+
+// containerImageAttributes returns the container.image.name and container.image.tag attributes
+// of the image reported by fluent-bit's kubernetes filter
+func containerImageAttributes(k8sData map[string]any) []otlplog.KeyValue {
+	image, ok := k8sData["container_image"].(string)
+	if !ok || image == "" {
+		return nil
+	}
+
+	// Drop the digest, it is not part of the image name
+	image, _, _ = strings.Cut(image, "@")
+
+	name, tag := image, ""
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		name, tag = image[:i], image[i+1:]
+	}
+
+	attrs := []otlplog.KeyValue{otlplog.String("container.image.name", name)}
+	if tag != "" {
+		attrs = append(attrs, otlplog.String("container.image.tag", tag))
+	}
+
+	return attrs
+}
+
+// promotedAttributes returns the entries of the labels or annotations map selected by the filter
+// as attributes with the given prefix. The attributes are sorted by key.
+func promotedAttributes(value any, prefix string, filter config.KeyFilter) []otlplog.KeyValue {
+	if filter.IsEmpty() {
+		return nil
+	}
+
+	m, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	attrs := make([]otlplog.KeyValue, 0, len(m))
+	for k, v := range m {
+		if !filter.Matches(k) {
+			continue
+		}
+		switch val := v.(type) {
+		case string:
+			attrs = append(attrs, otlplog.String(prefix+k, val))
+		default:
+			attrs = append(attrs, otlplog.String(prefix+k, fmt.Sprintf("%v", val)))
+		}
+	}
+	slices.SortFunc(attrs, func(a, b otlplog.KeyValue) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return attrs
+}
+
+// workloadAttributes derives the attributes of the workload owning the pod.
+// Owner references are preferred, then well-known labels, then the generated pod name.
+func workloadAttributes(k8sData map[string]any) []otlplog.KeyValue {
+	if kind, name, ok := controllerReference(k8sData); ok {
+		switch kind {
+		case "ReplicaSet":
+			attrs := []otlplog.KeyValue{otlplog.String("k8s.replicaset.name", name)}
+			if m := generatedReplicaSetName.FindStringSubmatch(name); m != nil {
+				attrs = append(attrs, otlplog.String("k8s.deployment.name", m[1]))
+			}
+
+			return attrs
+		case "StatefulSet":
+			return []otlplog.KeyValue{otlplog.String("k8s.statefulset.name", name)}
+		case "DaemonSet":
+			return []otlplog.KeyValue{otlplog.String("k8s.daemonset.name", name)}
+		case "Job":
+			attrs := []otlplog.KeyValue{otlplog.String("k8s.job.name", name)}
+			if m := scheduledJobName.FindStringSubmatch(name); m != nil {
+				attrs = append(attrs, otlplog.String("k8s.cronjob.name", m[1]))
+			}
+
+			return attrs
+		default:
+			return nil
+		}
+	}
+
+	podName, _ := k8sData["pod_name"].(string)
+	labels, _ := k8sData["labels"].(map[string]any)
+
+	if hash, ok := labels["pod-template-hash"].(string); ok && hash != "" {
+		if deployment, _, found := strings.Cut(podName, "-"+hash+"-"); found && deployment != "" {
+			return []otlplog.KeyValue{
+				otlplog.String("k8s.replicaset.name", deployment+"-"+hash),
+				otlplog.String("k8s.deployment.name", deployment),
+			}
+		}
+	}
+
+	if hasAnyLabel(labels, "statefulset.kubernetes.io/pod-name", "apps.kubernetes.io/pod-index") {
+		if m := statefulSetPodName.FindStringSubmatch(podName); m != nil {
+			return []otlplog.KeyValue{otlplog.String("k8s.statefulset.name", m[1])}
+		}
+	}
+
+	for _, label := range []string{"batch.kubernetes.io/job-name", "job-name"} {
+		if job, ok := labels[label].(string); ok && job != "" {
+			return []otlplog.KeyValue{otlplog.String("k8s.job.name", job)}
+		}
+	}
+
+	if m := generatedPodName.FindStringSubmatch(podName); m != nil {
+		return []otlplog.KeyValue{
+			otlplog.String("k8s.replicaset.name", m[1]+"-"+m[2]),
+			otlplog.String("k8s.deployment.name", m[1]),
+		}
+	}
+
+	return nil
+}
+
+// controllerReference returns the kind and name of the pod's controlling owner reference.
+// fluent-bit's kubernetes filter adds the owner references when Owner_References is enabled.
+func controllerReference(k8sData map[string]any) (string, string, bool) {
+	refs, ok := k8sData["ownerReferences"].([]any)
+	if !ok {
+		refs, _ = k8sData["owner_references"].([]any)
+	}
+
+	var kind, name string
+	for _, r := range refs {
+		ref, ok := r.(map[string]any)
+		if !ok {
+			continue
+		}
+		refKind, _ := ref["kind"].(string)
+		refName, _ := ref["name"].(string)
+		if refKind == "" || refName == "" {
+			continue
+		}
+		if controller, _ := ref["controller"].(bool); controller {
+			return refKind, refName, true
+		}
+		if kind == "" {
+			kind, name = refKind, refName
+		}
+	}
+
+	return kind, name, kind != ""
+}
+
+func hasAnyLabel(labels map[string]any, keys ...string) bool {
+	for _, key := range keys {
+		if _, ok := labels[key]; ok {
+			return true
+		}
+	}
+
+	return false
+}
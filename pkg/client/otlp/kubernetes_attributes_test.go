@@ -0,0 +1,157 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	otlplog "go.opentelemetry.io/otel/log"
+
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+var _ = Describe("Kubernetes attributes", func() {
+	entryWith := func(k8s map[string]any) types.OutputEntry {
+		return types.OutputEntry{Record: map[string]any{"kubernetes": k8s}}
+	}
+
+	Describe("labels and annotations", func() {
+		var k8s map[string]any
+
+		BeforeEach(func() {
+			k8s = map[string]any{
+				"pod_name": "etcd-main-0",
+				"labels": map[string]any{
+					"app":                        "etcd-statefulset",
+					"role":                       "main",
+					"app.kubernetes.io/name":     "etcd",
+					"app.kubernetes.io/instance": "etcd-main",
+					"controller-revision-hash":   "etcd-main-5f8c7d9b4",
+				},
+				"annotations": map[string]any{
+					"checksum/configmap":   "abc",
+					"gardener.cloud/owner": "garden",
+				},
+			}
+		})
+
+		It("should not promote labels and annotations by default", func() {
+			attrs := extractK8sResourceAttributes(entryWith(k8s), config.KubernetesAttributesConfig{})
+
+			Expect(attrs).To(ConsistOf(otlplog.String("k8s.pod.name", "etcd-main-0")))
+		})
+
+		It("should promote allow-listed labels and annotations", func() {
+			cfg := config.KubernetesAttributesConfig{
+				PodLabels:      config.KeyFilter{Allow: []string{"app.kubernetes.io/*", "role"}, Deny: []string{"app.kubernetes.io/instance"}},
+				PodAnnotations: config.KeyFilter{Allow: []string{"*"}, Deny: []string{"checksum/*"}},
+			}
+
+			attrs := extractK8sResourceAttributes(entryWith(k8s), cfg)
+
+			Expect(attrs).To(ConsistOf(
+				otlplog.String("k8s.pod.name", "etcd-main-0"),
+				otlplog.String("k8s.pod.label.app.kubernetes.io/name", "etcd"),
+				otlplog.String("k8s.pod.label.role", "main"),
+				otlplog.String("k8s.pod.annotation.gardener.cloud/owner", "garden"),
+			))
+		})
+	})
+
+	Describe("container and node fields", func() {
+		It("should extract the container image and docker ID", func() {
+			attrs := extractK8sResourceAttributes(entryWith(map[string]any{
+				"docker_id":       "3c8b1e0a",
+				"container_image": "europe-docker.pkg.dev/gardener-project/releases/gardener/apiserver:v1.110.0@sha256:0123",
+				"host":            "node-1",
+			}), config.KubernetesAttributesConfig{})
+
+			Expect(attrs).To(ConsistOf(
+				otlplog.String("container.id", "3c8b1e0a"),
+				otlplog.String("container.image.name", "europe-docker.pkg.dev/gardener-project/releases/gardener/apiserver"),
+				otlplog.String("container.image.tag", "v1.110.0"),
+				otlplog.String("k8s.node.name", "node-1"),
+			))
+		})
+
+		It("should not mistake a registry port for an image tag", func() {
+			attrs := containerImageAttributes(map[string]any{"container_image": "registry:5000/nginx"})
+
+			Expect(attrs).To(ConsistOf(otlplog.String("container.image.name", "registry:5000/nginx")))
+		})
+	})
+
+	Describe("workload attributes", func() {
+		DescribeTable("should derive the owning workload",
+			func(k8s map[string]any, expected ...otlplog.KeyValue) {
+				Expect(workloadAttributes(k8s)).To(ConsistOf(expected))
+			},
+			Entry("replica set owner reference",
+				map[string]any{"ownerReferences": []any{
+					map[string]any{"kind": "ReplicaSet", "name": "gardener-apiserver-7d4b9c8f6d", "controller": true},
+				}},
+				otlplog.String("k8s.replicaset.name", "gardener-apiserver-7d4b9c8f6d"),
+				otlplog.String("k8s.deployment.name", "gardener-apiserver"),
+			),
+			Entry("controlling owner reference is preferred",
+				map[string]any{"ownerReferences": []any{
+					map[string]any{"kind": "Node", "name": "node-1"},
+					map[string]any{"kind": "StatefulSet", "name": "etcd-main", "controller": true},
+				}},
+				otlplog.String("k8s.statefulset.name", "etcd-main"),
+			),
+			Entry("daemon set owner reference",
+				map[string]any{"ownerReferences": []any{map[string]any{"kind": "DaemonSet", "name": "fluent-bit"}}},
+				otlplog.String("k8s.daemonset.name", "fluent-bit"),
+			),
+			Entry("job created by a cron job",
+				map[string]any{"ownerReferences": []any{map[string]any{"kind": "Job", "name": "backup-29034720"}}},
+				otlplog.String("k8s.job.name", "backup-29034720"),
+				otlplog.String("k8s.cronjob.name", "backup"),
+			),
+			Entry("pod-template-hash label",
+				map[string]any{
+					"pod_name": "vpa-recommender-6b8f9c4d5-x2x7q",
+					"labels":   map[string]any{"pod-template-hash": "6b8f9c4d5"},
+				},
+				otlplog.String("k8s.replicaset.name", "vpa-recommender-6b8f9c4d5"),
+				otlplog.String("k8s.deployment.name", "vpa-recommender"),
+			),
+			Entry("stateful set pod labels",
+				map[string]any{
+					"pod_name": "prometheus-shoot-0",
+					"labels":   map[string]any{"statefulset.kubernetes.io/pod-name": "prometheus-shoot-0"},
+				},
+				otlplog.String("k8s.statefulset.name", "prometheus-shoot"),
+			),
+			Entry("job name label",
+				map[string]any{
+					"pod_name": "migrate-8zq2x",
+					"labels":   map[string]any{"batch.kubernetes.io/job-name": "migrate"},
+				},
+				otlplog.String("k8s.job.name", "migrate"),
+			),
+			Entry("generated deployment pod name",
+				map[string]any{"pod_name": "kube-apiserver-5f6b7c8d9-q4w5z"},
+				otlplog.String("k8s.replicaset.name", "kube-apiserver-5f6b7c8d9"),
+				otlplog.String("k8s.deployment.name", "kube-apiserver"),
+			),
+		)
+
+		It("should not derive workloads from ambiguous pod names", func() {
+			Expect(workloadAttributes(map[string]any{"pod_name": "fluent-bit-rvjzr"})).To(BeEmpty())
+			Expect(workloadAttributes(map[string]any{"pod_name": "etcd-main-0"})).To(BeEmpty())
+		})
+
+		It("should be added only when enabled", func() {
+			k8s := map[string]any{"pod_name": "kube-apiserver-5f6b7c8d9-q4w5z"}
+
+			Expect(extractK8sResourceAttributes(entryWith(k8s), config.KubernetesAttributesConfig{})).
+				NotTo(ContainElement(HaveField("Key", "k8s.deployment.name")))
+			Expect(extractK8sResourceAttributes(entryWith(k8s), config.KubernetesAttributesConfig{WorkloadAttributes: true})).
+				To(ContainElement(otlplog.String("k8s.deployment.name", "kube-apiserver")))
+		})
+	})
+})
@@ -0,0 +1,224 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
+)
+
+// Units of the ThrottleLimit metric
+const (
+	limitUnitRecords = "records"
+	limitUnitBytes   = "bytes"
+)
+
+// Limiter limits the records and the estimated bytes per second handed to the exporter.
+// The burst of both limits is twice the limit per second.
+//
+// An adaptive limiter lowers its limits when the backend throttles the exports and raises them again while it does not,
+// additive increase and multiplicative decrease like TCP congestion control: a throttled export halves the limits,
+// at most once per interval and down to the minimum percentage of the configured limits. Once the backend stops throttling,
+// and after the delay it requested, the limits are raised by the increase percentage per interval up to the configured limits.
+type Limiter struct {
+	records *rate.Limiter // Nil without record limit
+	bytes   *rate.Limiter // Nil without byte limit
+
+	recordsPerSec float64
+	bytesPerSec   float64
+
+	adaptive  bool
+	minRatio  float64
+	increment float64
+	interval  time.Duration
+
+	host    string
+	metrics *metrics.FluentBitGardenerMetrics
+
+	mu sync.Mutex
+	// ratio is the current fraction of the configured limits
+	ratio float64
+	// lastDecrease is the time of the last decrease, the limits are decreased at most once per interval
+	lastDecrease time.Time
+	// nextIncrease is the time of the next increase, postponed by decreases and requested delays
+	nextIncrease time.Time
+}
+
+// NewLimiter creates the limiter of the throttle configuration, nil if throttling is disabled or no limit is configured.
+// The current limits are reported by the ThrottleLimit metric with the host label.
+func NewLimiter(cfg config.OTLPConfig, host string, m *metrics.FluentBitGardenerMetrics) *Limiter {
+	if !cfg.ThrottleEnabled || (cfg.ThrottleRequestsPerSec <= 0 && cfg.ThrottleBytesPerSec <= 0) {
+		return nil
+	}
+
+	l := &Limiter{
+		adaptive:  cfg.ThrottleAdaptive,
+		minRatio:  float64(cfg.ThrottleAdaptiveMinPercent) / 100,
+		increment: float64(cfg.ThrottleAdaptiveIncreasePercent) / 100,
+		interval:  cfg.ThrottleAdaptiveInterval,
+		host:      host,
+		metrics:   m,
+		ratio:     1,
+	}
+	if cfg.ThrottleRequestsPerSec > 0 {
+		l.recordsPerSec = float64(cfg.ThrottleRequestsPerSec)
+		l.records = rate.NewLimiter(rate.Limit(l.recordsPerSec), cfg.ThrottleRequestsPerSec*2)
+	}
+	if cfg.ThrottleBytesPerSec > 0 {
+		l.bytesPerSec = float64(cfg.ThrottleBytesPerSec)
+		l.bytes = rate.NewLimiter(rate.Limit(l.bytesPerSec), cfg.ThrottleBytesPerSec*2)
+	}
+	l.apply()
+
+	return l
+}
+
+// Adaptive reports whether the limiter adapts its limits to the throttling of the backend
+func (l *Limiter) Adaptive() bool {
+	return l.adaptive
+}
+
+// Allow reports whether a record of the estimated size may be sent now, consuming its share of the limits if so.
+// Records larger than the byte burst are counted as the burst, so that they can be sent at all.
+func (l *Limiter) Allow(size int) bool {
+	now := time.Now()
+	l.increase(now)
+
+	var record *rate.Reservation
+	if l.records != nil {
+		record = l.records.ReserveN(now, 1)
+		if !record.OK() || record.DelayFrom(now) > 0 {
+			record.CancelAt(now)
+
+			return false
+		}
+	}
+	if l.bytes != nil && !l.bytes.AllowN(now, min(max(size, 1), l.bytes.Burst())) {
+		// The record is not sent, so it must not consume the record limit either
+		if record != nil {
+			record.CancelAt(now)
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// Throttled lowers the limits of an adaptive limiter after the backend throttled an export.
+// retryAfter is the delay requested by the backend, the limits are not raised before it elapsed.
+func (l *Limiter) Throttled(retryAfter time.Duration) {
+	if !l.adaptive {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if next := now.Add(max(l.interval, retryAfter)); next.After(l.nextIncrease) {
+		l.nextIncrease = next
+	}
+	// Exports in flight when the limits were lowered are throttled as well, they must not lower the limits again
+	if !l.lastDecrease.IsZero() && now.Sub(l.lastDecrease) < l.interval {
+		return
+	}
+	l.lastDecrease = now
+
+	if ratio := max(l.ratio/2, l.minRatio); ratio != l.ratio {
+		l.ratio = ratio
+		l.apply()
+	}
+}
+
+// increase raises the limits of an adaptive limiter by the increment for every interval elapsed since the last change
+func (l *Limiter) increase(now time.Time) {
+	if !l.adaptive {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.ratio >= 1 || now.Before(l.nextIncrease) {
+		return
+	}
+
+	steps := 1 + int(now.Sub(l.nextIncrease)/l.interval)
+	l.ratio = min(1, l.ratio+float64(steps)*l.increment)
+	l.nextIncrease = l.nextIncrease.Add(time.Duration(steps) * l.interval)
+	l.apply()
+}
+
+// apply sets the limits and bursts of the current ratio and reports them.
+// The bursts are at least 1, so that records are still sent at the lowest limits.
+func (l *Limiter) apply() {
+	if l.records != nil {
+		limit := l.recordsPerSec * l.ratio
+		l.records.SetLimit(rate.Limit(limit))
+		l.records.SetBurst(max(1, int(math.Round(limit*2))))
+		l.setMetric(limitUnitRecords, limit)
+	}
+	if l.bytes != nil {
+		limit := l.bytesPerSec * l.ratio
+		l.bytes.SetLimit(rate.Limit(limit))
+		l.bytes.SetBurst(max(1, int(math.Round(limit*2))))
+		l.setMetric(limitUnitBytes, limit)
+	}
+}
+
+func (l *Limiter) setMetric(unit string, limit float64) {
+	if l.metrics != nil {
+		l.metrics.ThrottleLimit.WithLabelValues(l.host, unit).Set(limit)
+	}
+}
+
+// RecordSize estimates the size of a record in bytes as the length of its JSON representation.
+// It is cheap compared to the serialization and close enough for rate limiting.
+func RecordSize(record map[string]any) int {
+	return valueSize(record)
+}
+
+func valueSize(value any) int {
+	switch v := value.(type) {
+	case nil:
+		return len("null")
+	case string:
+		return len(v) + 2
+	case []byte:
+		return len(v) + 2
+	case bool:
+		return len("false")
+	case map[string]any:
+		size := 2
+		for key, item := range v {
+			size += len(key) + 4 + valueSize(item)
+		}
+
+		return size
+	case map[any]any:
+		size := 2
+		for key, item := range v {
+			size += valueSize(key) + 2 + valueSize(item)
+		}
+
+		return size
+	case []any:
+		size := 2
+		for _, item := range v {
+			size += valueSize(item) + 1
+		}
+
+		return size
+	default:
+		return len(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp_test
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
+)
+
+var _ = Describe("Limiter", func() {
+	var (
+		testMetrics *metrics.FluentBitGardenerMetrics
+		cfg         config.OTLPConfig
+	)
+
+	BeforeEach(func() {
+		testMetrics = metrics.RegisterFluentBitGardenerMetrics(metrics.NewRegistry())
+		cfg = config.OTLPConfig{
+			ThrottleEnabled:                 true,
+			ThrottleAdaptiveMinPercent:      25,
+			ThrottleAdaptiveIncreasePercent: 50,
+			ThrottleAdaptiveInterval:        50 * time.Millisecond,
+		}
+	})
+
+	limit := func(unit string) float64 {
+		return testutil.ToFloat64(testMetrics.ThrottleLimit.WithLabelValues("localhost:4317", unit))
+	}
+
+	It("should not be created without limits", func() {
+		Expect(otlp.NewLimiter(cfg, "localhost:4317", testMetrics)).To(BeNil())
+
+		cfg.ThrottleEnabled, cfg.ThrottleRequestsPerSec = false, 100
+		Expect(otlp.NewLimiter(cfg, "localhost:4317", testMetrics)).To(BeNil())
+	})
+
+	It("should limit the records with a burst of twice the limit", func() {
+		cfg.ThrottleRequestsPerSec = 5
+		limiter := otlp.NewLimiter(cfg, "localhost:4317", testMetrics)
+		Expect(limiter.Adaptive()).To(BeFalse())
+		Expect(limit("records")).To(Equal(5.0))
+
+		for range 10 {
+			Expect(limiter.Allow(1 << 20)).To(BeTrue())
+		}
+		Expect(limiter.Allow(1)).To(BeFalse())
+	})
+
+	It("should limit the bytes with a burst of twice the limit", func() {
+		cfg.ThrottleBytesPerSec = 100
+		limiter := otlp.NewLimiter(cfg, "localhost:4317", testMetrics)
+		Expect(limit("bytes")).To(Equal(100.0))
+
+		Expect(limiter.Allow(150)).To(BeTrue())
+		Expect(limiter.Allow(100)).To(BeFalse())
+		Expect(limiter.Allow(50)).To(BeTrue())
+	})
+
+	It("should count records larger than the burst as the burst", func() {
+		cfg.ThrottleBytesPerSec = 100
+		limiter := otlp.NewLimiter(cfg, "localhost:4317", testMetrics)
+
+		Expect(limiter.Allow(1000)).To(BeTrue())
+		Expect(limiter.Allow(1)).To(BeFalse())
+	})
+
+	It("should ignore throttled exports if it is not adaptive", func() {
+		cfg.ThrottleRequestsPerSec = 100
+		limiter := otlp.NewLimiter(cfg, "localhost:4317", testMetrics)
+
+		limiter.Throttled(time.Second)
+		Expect(limit("records")).To(Equal(100.0))
+	})
+
+	Context("adaptive", func() {
+		var limiter *otlp.Limiter
+
+		BeforeEach(func() {
+			cfg.ThrottleAdaptive = true
+			cfg.ThrottleRequestsPerSec = 100
+			cfg.ThrottleBytesPerSec = 1000
+			limiter = otlp.NewLimiter(cfg, "localhost:4317", testMetrics)
+			Expect(limiter.Adaptive()).To(BeTrue())
+		})
+
+		It("should halve the limits at most once per interval down to the minimum", func() {
+			limiter.Throttled(0)
+			Expect(limit("records")).To(Equal(50.0))
+			Expect(limit("bytes")).To(Equal(500.0))
+
+			limiter.Throttled(0)
+			Expect(limit("records")).To(Equal(50.0))
+
+			time.Sleep(cfg.ThrottleAdaptiveInterval)
+			limiter.Throttled(0)
+			Expect(limit("records")).To(Equal(25.0))
+
+			time.Sleep(cfg.ThrottleAdaptiveInterval)
+			limiter.Throttled(0)
+			Expect(limit("records")).To(Equal(25.0))
+			Expect(limit("bytes")).To(Equal(250.0))
+		})
+
+		It("should raise the limits again up to the configured limits", func() {
+			limiter.Throttled(0)
+			Expect(limit("records")).To(Equal(50.0))
+
+			Eventually(func() float64 {
+				limiter.Allow(1)
+
+				return limit("records")
+			}).WithTimeout(time.Second).Should(Equal(100.0))
+			Expect(limit("bytes")).To(Equal(1000.0))
+		})
+
+		It("should not raise the limits before the requested delay", func() {
+			limiter.Throttled(300 * time.Millisecond)
+
+			Consistently(func() float64 {
+				limiter.Allow(1)
+
+				return limit("records")
+			}).WithTimeout(200 * time.Millisecond).Should(Equal(50.0))
+			Eventually(func() float64 {
+				limiter.Allow(1)
+
+				return limit("records")
+			}).WithTimeout(time.Second).Should(Equal(100.0))
+		})
+	})
+
+	It("should estimate the record size from its JSON representation", func() {
+		record := map[string]any{
+			"log":        "2025-01-01T00:00:00Z level=info msg=\"request handled\" path=/api/v1/shoots duration=12ms",
+			"stream":     []byte("stdout"),
+			"kubernetes": map[any]any{"pod_name": "gardener-apiserver-5f7c9", "namespace_name": "garden", "labels": []any{"a", "b"}},
+			"count":      42,
+			"error":      nil,
+			"sampled":    true,
+		}
+		data, err := json.Marshal(map[string]any{
+			"log":        record["log"],
+			"stream":     "stdout",
+			"kubernetes": map[string]any{"pod_name": "gardener-apiserver-5f7c9", "namespace_name": "garden", "labels": []any{"a", "b"}},
+			"count":      42,
+			"error":      nil,
+			"sampled":    true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(otlp.RecordSize(record)).To(BeNumerically("~", len(data), len(data)/10))
+	})
+})
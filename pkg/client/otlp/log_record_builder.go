@@ -4,11 +4,14 @@
 package otlp
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	otlplog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/gardener/logging/v1/pkg/config"
 	"github.com/gardener/logging/v1/pkg/types"
@@ -16,9 +19,12 @@ import (
 
 // LogRecordBuilder builds OTLP log records from output entries
 type LogRecordBuilder struct {
-	record       otlplog.Record
-	severityText string
-	config       config.Config
+	record        otlplog.Record
+	severityText  string
+	bodyFallback  bool
+	spanContext   trace.SpanContext
+	resourceAttrs []attribute.KeyValue
+	config        config.Config
 }
 
 // NewLogRecordBuilder creates a new log record builder
@@ -40,9 +46,9 @@ func (b *LogRecordBuilder) WithTimestamp(timestamp time.Time) *LogRecordBuilder
 	return b
 }
 
-// WithSeverity sets the severity from the entry record
+// WithSeverity sets the severity from the entry record using the configured severity rules
 func (b *LogRecordBuilder) WithSeverity(record map[string]any) *LogRecordBuilder {
-	severity, severityText := mapSeverity(record)
+	severity, severityText := MapSeverity(record, b.config.OTLPConfig.SeverityConfig)
 	b.record.SetSeverity(severity)
 	b.record.SetSeverityText(severityText)
 	b.severityText = severityText
@@ -50,15 +56,51 @@ func (b *LogRecordBuilder) WithSeverity(record map[string]any) *LogRecordBuilder
 	return b
 }
 
-// WithBody sets the body from the entry record
+// WithBody sets the body from the entry record.
+// With StructuredBody enabled, map and slice values are kept as structured OTLP bodies
+// unless they exceed MaxStructuredBodySize, see StructuredBodyFallback.
 func (b *LogRecordBuilder) WithBody(record map[string]any) *LogRecordBuilder {
-	body := extractBody(record)
-	b.record.SetBody(otlplog.StringValue(body))
+	maxSize := b.config.OTLPConfig.MaxBodySize
+	if b.config.OTLPConfig.StructuredBody {
+		body, structured, fits := extractStructuredBody(record, b.config.OTLPConfig.MaxStructuredBodySize, maxSize)
+		if structured && fits {
+			b.record.SetBody(body)
+
+			return b
+		}
+		b.bodyFallback = structured
+	}
+	b.record.SetBody(otlplog.StringValue(extractBody(record, maxSize)))
+
+	return b
+}
+
+// StructuredBodyFallback reports whether the structured body exceeded MaxStructuredBodySize
+// and was set as truncated string instead
+func (b *LogRecordBuilder) StructuredBodyFallback() bool {
+	return b.bodyFallback
+}
+
+// WithTraceContext extracts the trace context from the entry record.
+// The OTLP log API takes the trace context from the emit context, see EmitContext.
+func (b *LogRecordBuilder) WithTraceContext(record map[string]any) *LogRecordBuilder {
+	b.spanContext = extractSpanContext(record, b.config.OTLPConfig)
 
 	return b
 }
 
-// WithAttributes adds all attributes from the entry
+// EmitContext returns the context to emit the log record with.
+// It carries the extracted trace context, so the SDK sets the trace and span IDs of the record.
+func (b *LogRecordBuilder) EmitContext(ctx context.Context) context.Context {
+	if !b.spanContext.HasTraceID() {
+		return ctx
+	}
+
+	return trace.ContextWithSpanContext(ctx, b.spanContext)
+}
+
+// WithAttributes adds all attributes from the entry.
+// With ResourceGrouping enabled, the Kubernetes attributes are kept for the resource instead, see ResourceAttributes.
 func (b *LogRecordBuilder) WithAttributes(entry types.OutputEntry) *LogRecordBuilder {
 	attrs := b.buildAttributes(entry)
 	b.record.AddAttributes(attrs...)
@@ -66,76 +108,115 @@ func (b *LogRecordBuilder) WithAttributes(entry types.OutputEntry) *LogRecordBui
 	return b
 }
 
+// ResourceAttributes returns the Kubernetes attributes identifying the resource of the record.
+// They are only collected when ResourceGrouping is enabled.
+func (b *LogRecordBuilder) ResourceAttributes() []attribute.KeyValue {
+	return b.resourceAttrs
+}
+
 // Build returns the constructed log record
 func (b *LogRecordBuilder) Build() otlplog.Record {
 	return b.record
 }
 
-// extractBody extracts the log message body from the record
-func extractBody(record map[string]any) string {
-	msg, ok := record["log"]
-	if ok {
-		//nolint:revive // enforce-switch-style: default-case is omitted on purpose since
+// bodyKeys are the record fields holding the log message, in order of precedence
+var bodyKeys = []string{"log", "message"}
+
+// extractBody extracts the log message body from the record.
+// Bodies serialized from maps, slices or byte slices are truncated to maxSize bytes, 0 disables truncation.
+func extractBody(record map[string]any, maxSize int) string {
+	for _, key := range bodyKeys {
+		msg, ok := record[key]
+		if !ok {
+			continue
+		}
+
+		//nolint:revive // enforce-switch-style: default-case is omitted on purpose since we are expecting only string, []byte, map or slice types for log/message fields
 		switch v := msg.(type) {
 		case string:
-			return fmt.Sprintf("%v", msg)
+			return v
 		case []byte:
 			// Avoid memory leak: limit string conversion for large byte slices
-			if len(v) > 1024 {
-				// take first 1024 bytes only
-				return fmt.Sprintf("%s... <truncated %d bytes>", string(v[:1024]), len(v)-1024)
+			if maxSize > 0 && len(v) > maxSize {
+				return fmt.Sprintf("%s... <truncated %d bytes>", string(v[:maxSize]), len(v)-maxSize)
 			}
+
+			return string(v)
 		case map[string]any:
 			// For nested maps, avoid deep serialization that causes memory leaks
-			// Serialize the line and fetch 1024 bytes only
+			// Serialize the line and fetch maxSize bytes only
 			t, err := marshalMap(v)
 			if err != nil {
 				return fmt.Sprintf("failed to marshal record: %v", err)
 			}
-			if len(t) > 1024 {
-				return fmt.Sprintf("%s... <truncated %d bytes>", t[:1024], len(t)-1024)
+
+			return truncateBody(t, maxSize)
+		case []any:
+			t, err := json.Marshal(v)
+			if err != nil {
+				return fmt.Sprintf("failed to marshal record: %v", err)
 			}
 
-			return t
+			return truncateBody(string(t), maxSize)
 		}
 	}
 
-	msg, ok = record["message"]
-	if ok {
-		//nolint:revive // enforce-switch-style: default-case is omitted on purpose
-		switch v := msg.(type) {
-		case string:
-			return fmt.Sprintf("%v", msg)
-		case []byte:
-			// Avoid memory leak: limit string conversion for large byte slices
-			if len(v) > 1024 {
-				// take first 1024 bytes only
-				return fmt.Sprintf("%s... <truncated %d bytes>", string(v[:1024]), len(v)-1024)
-			}
-		case map[string]any:
-			// For nested maps, avoid deep serialization that causes memory leaks
-			// Serialize the line and fetch 1024 bytes only
-			t, err := marshalMap(v)
-			if err != nil {
-				return fmt.Sprintf("failed to marshal record: %v", err)
-			}
-			if len(t) > 1024 {
-				return fmt.Sprintf("%s... <truncated %d bytes>", t[:1024], len(t)-1024)
+	return fmt.Sprintf("%v", record)
+}
+
+// extractStructuredBody returns the map or slice log message of the record as structured OTLP value,
+// byte slices in it are limited to maxBytes. structured reports whether the message is a map or slice,
+// fits whether its serialized size is within maxSize, 0 disables the limit.
+// Otherwise the body is expected to be extracted as string.
+func extractStructuredBody(record map[string]any, maxSize, maxBytes int) (body otlplog.Value, structured, fits bool) {
+	for _, key := range bodyKeys {
+		msg, ok := record[key]
+		if !ok {
+			continue
+		}
+
+		switch msg.(type) {
+		case map[string]any, []any:
+		default:
+			return otlplog.Value{}, false, false
+		}
+
+		if maxSize > 0 {
+			t, err := json.Marshal(msg)
+			if err != nil || len(t) > maxSize {
+				return otlplog.Value{}, true, false
 			}
+		}
 
-			return t
-		} //nolint:revive // enforce-switch-style: default-case is omitted on purpose since we are expecting only string or []byte types for log/message fields
+		return toLogValue(msg, maxBytes), true, true
 	}
 
-	return fmt.Sprintf("%v", record)
+	return otlplog.Value{}, false, false
+}
+
+// truncateBody cuts the body to maxSize bytes, 0 disables truncation
+func truncateBody(body string, maxSize int) string {
+	if maxSize <= 0 || len(body) <= maxSize {
+		return body
+	}
+
+	return fmt.Sprintf("%s... <truncated %d bytes>", body[:maxSize], len(body)-maxSize)
 }
 
 func (b *LogRecordBuilder) buildAttributes(entry types.OutputEntry) []otlplog.KeyValue {
-	k8sAttrs := extractK8sResourceAttributes(entry)
+	k8sAttrs := extractK8sResourceAttributes(entry, b.config.OTLPConfig.KubernetesAttributes)
 	attrs := make([]otlplog.KeyValue, 0, len(entry.Record)+len(k8sAttrs)+1)
 
-	// Add Kubernetes resource attributes first
-	attrs = append(attrs, k8sAttrs...)
+	if b.config.OTLPConfig.ResourceGrouping {
+		// Kubernetes resource attributes identify the resource instead of being repeated on every record
+		b.resourceAttrs = make([]attribute.KeyValue, 0, len(k8sAttrs))
+		for _, kv := range k8sAttrs {
+			b.resourceAttrs = append(b.resourceAttrs, attribute.String(kv.Key, kv.Value.AsString()))
+		}
+	} else {
+		// Add Kubernetes resource attributes first
+		attrs = append(attrs, k8sAttrs...)
+	}
 
 	// Add origin attribute if configured
 	if b.config.PluginConfig.Origin != "" {
@@ -147,7 +228,7 @@ func (b *LogRecordBuilder) buildAttributes(entry types.OutputEntry) []otlplog.Ke
 		if b.shouldSkipAttribute(k) {
 			continue
 		}
-		attrs = append(attrs, convertToKeyValue(k, v))
+		attrs = append(attrs, convertToKeyValue(k, v, b.config.OTLPConfig.MaxBodySize))
 	}
 
 	return attrs
@@ -163,7 +244,8 @@ func (b *LogRecordBuilder) shouldSkipAttribute(key string) bool {
 // extractK8sResourceAttributes extracts Kubernetes metadata from the log entry
 // and returns them as OTLP KeyValue attributes following OpenTelemetry semantic conventions
 // for Kubernetes: https://opentelemetry.io/docs/specs/semconv/resource/k8s/
-func extractK8sResourceAttributes(entry types.OutputEntry) []otlplog.KeyValue {
+// Pod labels and annotations are promoted as configured, workload attributes are derived when enabled.
+func extractK8sResourceAttributes(entry types.OutputEntry, cfg config.KubernetesAttributesConfig) []otlplog.KeyValue {
 	k8sField, exists := entry.Record["kubernetes"]
 	if !exists {
 		return nil
@@ -198,8 +280,11 @@ func extractK8sResourceAttributes(entry types.OutputEntry) []otlplog.KeyValue {
 	}
 
 	// container.id - Container ID. Usually a UUID
+	// fluent-bit's kubernetes filter reports it as docker_id
 	if containerID, ok := k8sData["container_id"].(string); ok && containerID != "" {
 		attrs = append(attrs, otlplog.String("container.id", containerID))
+	} else if dockerID, ok := k8sData["docker_id"].(string); ok && dockerID != "" {
+		attrs = append(attrs, otlplog.String("container.id", dockerID))
 	}
 
 	// k8s.node.name - The name of the Node
@@ -207,39 +292,128 @@ func extractK8sResourceAttributes(entry types.OutputEntry) []otlplog.KeyValue {
 		attrs = append(attrs, otlplog.String("k8s.node.name", nodeName))
 	}
 
+	// container.image.name, container.image.tag - The image the container is running
+	attrs = append(attrs, containerImageAttributes(k8sData)...)
+
+	// k8s.pod.label.<key>, k8s.pod.annotation.<key> - Allow-listed pod labels and annotations
+	attrs = append(attrs, promotedAttributes(k8sData["labels"], "k8s.pod.label.", cfg.PodLabels)...)
+	attrs = append(attrs, promotedAttributes(k8sData["annotations"], "k8s.pod.annotation.", cfg.PodAnnotations)...)
+
+	// k8s.deployment.name, k8s.statefulset.name, ... - The workload owning the pod
+	if cfg.WorkloadAttributes {
+		attrs = append(attrs, workloadAttributes(k8sData)...)
+	}
+
 	return attrs
 }
 
-// convertToKeyValue converts a Go value to an OTLP KeyValue attribute
-// FluentBit sends map[string]any, so we can safely assume string keys for nested maps
-func convertToKeyValue(key string, value any) otlplog.KeyValue {
+// maxValueDepth bounds the nesting of maps and slices converted to OTLP values
+const maxValueDepth = 16
+
+// convertToKeyValue converts a Go value to an OTLP KeyValue attribute.
+// Nested maps and slices are kept as OTLP map and slice values, byte slices are limited to maxBytes.
+func convertToKeyValue(key string, value any, maxBytes int) otlplog.KeyValue {
+	return otlplog.KeyValue{Key: key, Value: toLogValue(value, maxBytes)}
+}
+
+// toLogValue converts a record value to an OTLP log value, keeping nested maps and slices.
+// Byte slices longer than maxBytes are replaced by their length, 0 disables the limit.
+func toLogValue(value any, maxBytes int) otlplog.Value {
+	return toLogValueAtDepth(value, maxBytes, 0)
+}
+
+func toLogValueAtDepth(value any, maxBytes, depth int) otlplog.Value {
 	switch v := value.(type) {
+	case nil:
+		return otlplog.Value{}
 	case string:
-		return otlplog.String(key, v)
+		return otlplog.StringValue(v)
 	case int:
-		return otlplog.Int64(key, int64(v))
+		return otlplog.IntValue(v)
 	case int64:
-		return otlplog.Int64(key, v)
+		return otlplog.Int64Value(v)
+	case uint64:
+		return otlplog.Int64Value(int64(v)) //nolint:gosec // G115: values beyond int64 are not expected
+	case float32:
+		return otlplog.Float64Value(float64(v))
 	case float64:
-		return otlplog.Float64(key, v)
+		return otlplog.Float64Value(v)
 	case bool:
-		return otlplog.Bool(key, v)
+		return otlplog.BoolValue(v)
 	case []byte:
 		// Avoid memory leak: limit string conversion for large byte slices
-		if len(v) > 1024 {
-			return otlplog.String(key, fmt.Sprintf("<bytes: %d bytes>", len(v)))
+		if maxBytes > 0 && len(v) > maxBytes {
+			return otlplog.StringValue(fmt.Sprintf("<bytes: %d bytes>", len(v)))
 		}
 
-		return otlplog.String(key, string(v))
+		return otlplog.StringValue(string(v))
 	case map[string]any:
-		// For nested maps, avoid deep serialization that causes memory leaks
-		return otlplog.String(key, fmt.Sprintf("<map: %d keys>", len(v)))
+		// Avoid unbounded recursion on deeply nested structures
+		if depth >= maxValueDepth {
+			return otlplog.StringValue(fmt.Sprintf("<map: %d keys>", len(v)))
+		}
+		kvs := make([]otlplog.KeyValue, 0, len(v))
+		for k, val := range v {
+			kvs = append(kvs, otlplog.KeyValue{Key: k, Value: toLogValueAtDepth(val, maxBytes, depth+1)})
+		}
+
+		return otlplog.MapValue(kvs...)
+	case map[any]any:
+		if depth >= maxValueDepth {
+			return otlplog.StringValue(fmt.Sprintf("<map: %d keys>", len(v)))
+		}
+		kvs := make([]otlplog.KeyValue, 0, len(v))
+		for k, val := range v {
+			kvs = append(kvs, otlplog.KeyValue{Key: fmt.Sprintf("%v", k), Value: toLogValueAtDepth(val, maxBytes, depth+1)})
+		}
+
+		return otlplog.MapValue(kvs...)
 	case []any:
-		// For arrays, avoid deep serialization that causes memory leaks
-		return otlplog.String(key, fmt.Sprintf("<array: %d items>", len(v)))
+		if depth >= maxValueDepth {
+			return otlplog.StringValue(fmt.Sprintf("<array: %d items>", len(v)))
+		}
+		values := make([]otlplog.Value, 0, len(v))
+		for _, val := range v {
+			values = append(values, toLogValueAtDepth(val, maxBytes, depth+1))
+		}
+
+		return otlplog.SliceValue(values...)
 	default:
 		// For unknown types, use type name instead of full value to prevent memory leaks
-		return otlplog.String(key, fmt.Sprintf("<%T>", v))
+		return otlplog.StringValue(fmt.Sprintf("<%T>", v))
+	}
+}
+
+// ValueToAny converts an OTLP log value back to a plain Go value, e.g. for JSON encoding by exporters of other protocols.
+// Byte slices are converted to strings.
+func ValueToAny(v otlplog.Value) any {
+	switch v.Kind() {
+	case otlplog.KindString:
+		return v.AsString()
+	case otlplog.KindBool:
+		return v.AsBool()
+	case otlplog.KindInt64:
+		return v.AsInt64()
+	case otlplog.KindFloat64:
+		return v.AsFloat64()
+	case otlplog.KindBytes:
+		return string(v.AsBytes())
+	case otlplog.KindSlice:
+		items := make([]any, 0, len(v.AsSlice()))
+		for _, item := range v.AsSlice() {
+			items = append(items, ValueToAny(item))
+		}
+
+		return items
+	case otlplog.KindMap:
+		m := make(map[string]any, len(v.AsMap()))
+		for _, kv := range v.AsMap() {
+			m[kv.Key] = ValueToAny(kv.Value)
+		}
+
+		return m
+	default:
+		return nil
 	}
 }
 
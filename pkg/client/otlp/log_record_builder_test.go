@@ -10,8 +10,10 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
 	otlplog "go.opentelemetry.io/otel/log"
 
+	"github.com/gardener/logging/v1/pkg/config"
 	"github.com/gardener/logging/v1/pkg/types"
 )
 
@@ -32,7 +34,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				},
 			}
 
-			attrs := extractK8sResourceAttributes(entry)
+			attrs := extractK8sResourceAttributes(entry, config.DefaultOTLPConfig.KubernetesAttributes)
 
 			Expect(attrs).To(HaveLen(6))
 			Expect(attrs).To(ContainElement(otlplog.String("k8s.namespace.name", "test-namespace")))
@@ -55,7 +57,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				},
 			}
 
-			attrs := extractK8sResourceAttributes(entry)
+			attrs := extractK8sResourceAttributes(entry, config.DefaultOTLPConfig.KubernetesAttributes)
 
 			Expect(attrs).To(HaveLen(3))
 			Expect(attrs).To(ContainElement(otlplog.String("k8s.namespace.name", "test-namespace")))
@@ -75,7 +77,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				},
 			}
 
-			attrs := extractK8sResourceAttributes(entry)
+			attrs := extractK8sResourceAttributes(entry, config.DefaultOTLPConfig.KubernetesAttributes)
 
 			Expect(attrs).To(HaveLen(3))
 			Expect(attrs).To(ContainElement(otlplog.String("k8s.namespace.name", "test-namespace")))
@@ -91,7 +93,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				},
 			}
 
-			attrs := extractK8sResourceAttributes(entry)
+			attrs := extractK8sResourceAttributes(entry, config.DefaultOTLPConfig.KubernetesAttributes)
 
 			Expect(attrs).To(BeNil())
 		})
@@ -104,7 +106,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				},
 			}
 
-			attrs := extractK8sResourceAttributes(entry)
+			attrs := extractK8sResourceAttributes(entry, config.DefaultOTLPConfig.KubernetesAttributes)
 
 			Expect(attrs).To(BeNil())
 		})
@@ -122,7 +124,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				},
 			}
 
-			attrs := extractK8sResourceAttributes(entry)
+			attrs := extractK8sResourceAttributes(entry, config.DefaultOTLPConfig.KubernetesAttributes)
 
 			Expect(attrs).To(HaveLen(2))
 			Expect(attrs).To(ContainElement(otlplog.String("k8s.namespace.name", "test-namespace")))
@@ -143,7 +145,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				},
 			}
 
-			attrs := extractK8sResourceAttributes(entry)
+			attrs := extractK8sResourceAttributes(entry, config.DefaultOTLPConfig.KubernetesAttributes)
 
 			Expect(attrs).To(HaveLen(2))
 			Expect(attrs).To(ContainElement(otlplog.String("k8s.namespace.name", "test-namespace")))
@@ -165,7 +167,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				},
 			}
 
-			attrs := extractK8sResourceAttributes(entry)
+			attrs := extractK8sResourceAttributes(entry, config.DefaultOTLPConfig.KubernetesAttributes)
 
 			Expect(attrs).To(HaveLen(3))
 			Expect(attrs).To(ContainElement(otlplog.String("k8s.namespace.name", "fluent-bit")))
@@ -180,7 +182,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				"log": "test log message",
 			}
 
-			body := extractBody(record)
+			body := extractBody(record, 1024)
 
 			Expect(body).To(Equal("test log message"))
 		})
@@ -190,7 +192,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				"message": "test message",
 			}
 
-			body := extractBody(record)
+			body := extractBody(record, 1024)
 
 			Expect(body).To(Equal("test message"))
 		})
@@ -201,7 +203,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				"message": "message field",
 			}
 
-			body := extractBody(record)
+			body := extractBody(record, 1024)
 
 			Expect(body).To(Equal("log message"))
 		})
@@ -212,7 +214,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				"field2": "value2",
 			}
 
-			body := extractBody(record)
+			body := extractBody(record, 1024)
 
 			Expect(body).To(ContainSubstring("field1"))
 			Expect(body).To(ContainSubstring("field2"))
@@ -226,7 +228,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				},
 			}
 
-			body := extractBody(record)
+			body := extractBody(record, 1024)
 
 			Expect(body).To(ContainSubstring(`"key1":"value1"`))
 			Expect(body).To(ContainSubstring(`"key2":"value2"`))
@@ -241,7 +243,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				"log": largeMap,
 			}
 
-			body := extractBody(record)
+			body := extractBody(record, 1024)
 
 			Expect(body).To(ContainSubstring("<truncated"))
 			Expect(len(body)).To(BeNumerically(">", 1024))
@@ -255,7 +257,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				},
 			}
 
-			body := extractBody(record)
+			body := extractBody(record, 1024)
 
 			Expect(body).To(ContainSubstring(`"event":"started"`))
 			Expect(body).To(ContainSubstring(`"pid":1234`))
@@ -270,7 +272,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				"message": largeMap,
 			}
 
-			body := extractBody(record)
+			body := extractBody(record, 1024)
 
 			Expect(body).To(ContainSubstring("<truncated"))
 			Expect(len(body)).To(BeNumerically(">", 1024))
@@ -281,7 +283,7 @@ var _ = Describe("LogRecordBuilder", func() {
 				"log": []byte(strings.Repeat("a", 2048)),
 			}
 
-			body := extractBody(record)
+			body := extractBody(record, 1024)
 
 			Expect(body).To(ContainSubstring("<truncated"))
 			Expect(body).To(ContainSubstring("1024 bytes"))
@@ -292,54 +294,239 @@ var _ = Describe("LogRecordBuilder", func() {
 				"message": []byte(strings.Repeat("b", 2048)),
 			}
 
-			body := extractBody(record)
+			body := extractBody(record, 1024)
 
 			Expect(body).To(ContainSubstring("<truncated"))
 			Expect(body).To(ContainSubstring("1024 bytes"))
 		})
 	})
 
+	Describe("extractBody with max body size", func() {
+		It("should not truncate when max body size is 0", func() {
+			record := map[string]any{
+				"log": []byte(strings.Repeat("a", 2048)),
+			}
+
+			body := extractBody(record, 0)
+
+			Expect(body).To(HaveLen(2048))
+		})
+
+		It("should truncate at the configured max body size", func() {
+			record := map[string]any{
+				"log": []byte(strings.Repeat("a", 200)),
+			}
+
+			body := extractBody(record, 100)
+
+			Expect(body).To(HavePrefix(strings.Repeat("a", 100) + "..."))
+			Expect(body).To(ContainSubstring("<truncated 100 bytes>"))
+		})
+
+		It("should keep small byte slices", func() {
+			record := map[string]any{
+				"log": []byte("short message"),
+			}
+
+			Expect(extractBody(record, 1024)).To(Equal("short message"))
+		})
+
+		It("should serialize slice in 'log' field as JSON", func() {
+			record := map[string]any{
+				"log": []any{"a", float64(1)},
+			}
+
+			Expect(extractBody(record, 1024)).To(Equal(`["a",1]`))
+		})
+	})
+
+	Describe("structured body", func() {
+		structuredConfig := func(maxStructuredBodySize int) config.Config {
+			cfg := config.Config{}
+			cfg.OTLPConfig.StructuredBody = true
+			cfg.OTLPConfig.MaxBodySize = 100
+			cfg.OTLPConfig.MaxStructuredBodySize = maxStructuredBodySize
+
+			return cfg
+		}
+
+		It("should set map bodies as map values", func() {
+			record := map[string]any{
+				"log": map[string]any{
+					"msg":    "request served",
+					"status": int64(200),
+					"tags":   []any{"a", "b"},
+					"http":   map[string]any{"method": "GET"},
+				},
+			}
+
+			logRecord := NewLogRecordBuilder().WithConfig(structuredConfig(1024)).WithBody(record).Build()
+			body := logRecord.Body()
+
+			Expect(body.Kind()).To(Equal(otlplog.KindMap))
+			Expect(body.AsMap()).To(ConsistOf(
+				otlplog.String("msg", "request served"),
+				otlplog.Int64("status", 200),
+				otlplog.Slice("tags", otlplog.StringValue("a"), otlplog.StringValue("b")),
+				otlplog.Map("http", otlplog.String("method", "GET")),
+			))
+		})
+
+		It("should set slice bodies as slice values", func() {
+			record := map[string]any{
+				"message": []any{"first", true},
+			}
+
+			logRecord := NewLogRecordBuilder().WithConfig(structuredConfig(0)).WithBody(record).Build()
+			body := logRecord.Body()
+
+			Expect(body.Kind()).To(Equal(otlplog.KindSlice))
+			Expect(body.AsSlice()).To(Equal([]otlplog.Value{otlplog.StringValue("first"), otlplog.BoolValue(true)}))
+		})
+
+		It("should keep string bodies as strings", func() {
+			record := map[string]any{
+				"log": "plain message",
+			}
+
+			logRecord := NewLogRecordBuilder().WithConfig(structuredConfig(1024)).WithBody(record).Build()
+			body := logRecord.Body()
+
+			Expect(body.Kind()).To(Equal(otlplog.KindString))
+			Expect(body.AsString()).To(Equal("plain message"))
+		})
+
+		It("should not limit structured bodies by the max body size", func() {
+			record := map[string]any{
+				"log": map[string]any{"msg": strings.Repeat("x", 200)},
+			}
+
+			builder := NewLogRecordBuilder().WithConfig(structuredConfig(1024)).WithBody(record)
+			logRecord := builder.Build()
+			body := logRecord.Body()
+
+			Expect(body.Kind()).To(Equal(otlplog.KindMap))
+			Expect(body.AsMap()).To(ConsistOf(otlplog.String("msg", strings.Repeat("x", 200))))
+			Expect(builder.StructuredBodyFallback()).To(BeFalse())
+		})
+
+		It("should fall back to a truncated string when the body exceeds the max structured body size", func() {
+			record := map[string]any{
+				"log": map[string]any{"msg": strings.Repeat("x", 2000)},
+			}
+
+			builder := NewLogRecordBuilder().WithConfig(structuredConfig(1024)).WithBody(record)
+			logRecord := builder.Build()
+			body := logRecord.Body()
+
+			Expect(body.Kind()).To(Equal(otlplog.KindString))
+			Expect(body.AsString()).To(ContainSubstring("<truncated"))
+			Expect(builder.StructuredBodyFallback()).To(BeTrue())
+		})
+
+		It("should limit byte slices in structured bodies to the max body size", func() {
+			record := map[string]any{
+				"log": map[string]any{"short": []byte("raw"), "long": []byte(strings.Repeat("x", 200))},
+			}
+
+			logRecord := NewLogRecordBuilder().WithConfig(structuredConfig(0)).WithBody(record).Build()
+			body := logRecord.Body()
+
+			Expect(body.AsMap()).To(ConsistOf(otlplog.String("short", "raw"), otlplog.String("long", "<bytes: 200 bytes>")))
+		})
+
+		It("should stringify map bodies when structured bodies are disabled", func() {
+			record := map[string]any{
+				"log": map[string]any{"msg": "hello"},
+			}
+
+			logRecord := NewLogRecordBuilder().WithBody(record).Build()
+			body := logRecord.Body()
+
+			Expect(body.Kind()).To(Equal(otlplog.KindString))
+			Expect(body.AsString()).To(Equal(`{"msg":"hello"}`))
+		})
+	})
+
 	Describe("convertToKeyValue", func() {
 		It("should convert string values", func() {
-			kv := convertToKeyValue("key", "value")
+			kv := convertToKeyValue("key", "value", 1024)
 			Expect(kv).To(Equal(otlplog.String("key", "value")))
 		})
 
 		It("should convert int values", func() {
-			kv := convertToKeyValue("key", 123)
+			kv := convertToKeyValue("key", 123, 1024)
 			Expect(kv).To(Equal(otlplog.Int64("key", 123)))
 		})
 
 		It("should convert int64 values", func() {
-			kv := convertToKeyValue("key", int64(123))
+			kv := convertToKeyValue("key", int64(123), 1024)
 			Expect(kv).To(Equal(otlplog.Int64("key", 123)))
 		})
 
 		It("should convert float64 values", func() {
-			kv := convertToKeyValue("key", 123.45)
+			kv := convertToKeyValue("key", 123.45, 1024)
 			Expect(kv).To(Equal(otlplog.Float64("key", 123.45)))
 		})
 
 		It("should convert bool values", func() {
-			kv := convertToKeyValue("key", true)
+			kv := convertToKeyValue("key", true, 1024)
 			Expect(kv).To(Equal(otlplog.Bool("key", true)))
 		})
 
 		It("should convert byte slice to string", func() {
-			kv := convertToKeyValue("key", []byte("test"))
+			kv := convertToKeyValue("key", []byte("test"), 1024)
 			Expect(kv).To(Equal(otlplog.String("key", "test")))
 		})
 
-		It("should convert map to string representation", func() {
-			kv := convertToKeyValue("key", map[string]any{"nested": "value"})
+		It("should replace byte slices exceeding the max bytes by their length", func() {
+			Expect(convertToKeyValue("key", []byte("test"), 3)).To(Equal(otlplog.String("key", "<bytes: 4 bytes>")))
+			Expect(convertToKeyValue("key", []byte(strings.Repeat("x", 2000)), 0).Value.AsString()).To(HaveLen(2000))
+		})
+
+		It("should convert map to map value", func() {
+			kv := convertToKeyValue("key", map[string]any{"nested": "value"}, 1024)
 			Expect(kv.Key).To(Equal("key"))
-			// Value should be string representation
+			Expect(kv.Value.Equal(otlplog.MapValue(otlplog.String("nested", "value")))).To(BeTrue())
 		})
 
-		It("should convert slice to string representation", func() {
-			kv := convertToKeyValue("key", []any{"item1", "item2"})
+		It("should convert slice to slice value", func() {
+			kv := convertToKeyValue("key", []any{"item1", "item2"}, 1024)
 			Expect(kv.Key).To(Equal("key"))
-			// Value should be string representation
+			Expect(kv.Value.Equal(otlplog.SliceValue(otlplog.StringValue("item1"), otlplog.StringValue("item2")))).To(BeTrue())
+		})
+
+		It("should convert nested maps and slices recursively", func() {
+			kv := convertToKeyValue("labels", map[string]any{
+				"app":   "gardener",
+				"ports": []any{int64(80), int64(443)},
+				"owner": map[any]any{
+					"kind":       "Deployment",
+					"controller": true,
+				},
+			}, 1024)
+
+			expected := otlplog.MapValue(
+				otlplog.String("app", "gardener"),
+				otlplog.Slice("ports", otlplog.Int64Value(80), otlplog.Int64Value(443)),
+				otlplog.Map("owner", otlplog.String("kind", "Deployment"), otlplog.Bool("controller", true)),
+			)
+			Expect(kv.Value.Equal(expected)).To(BeTrue())
+		})
+
+		It("should stop converting at the maximum nesting depth", func() {
+			var nested any = "leaf"
+			for range maxValueDepth + 2 {
+				nested = map[string]any{"child": nested}
+			}
+
+			value := convertToKeyValue("deep", nested, 1024).Value
+			for range maxValueDepth {
+				Expect(value.Kind()).To(Equal(otlplog.KindMap))
+				value = value.AsMap()[0].Value
+			}
+			Expect(value.Kind()).To(Equal(otlplog.KindString))
+			Expect(value.AsString()).To(Equal("<map: 1 keys>"))
 		})
 	})
 
@@ -403,6 +590,48 @@ var _ = Describe("LogRecordBuilder", func() {
 		})
 	})
 
+	Describe("ResourceAttributes", func() {
+		entry := types.OutputEntry{
+			Record: map[string]any{
+				"log": "test message",
+				"kubernetes": map[string]any{
+					"namespace_name": "garden",
+					"pod_name":       "etcd-main-0",
+					"container_name": "etcd",
+				},
+				"stream": "stdout",
+			},
+		}
+
+		It("should keep the kubernetes attributes on the record by default", func() {
+			builder := NewLogRecordBuilder().WithAttributes(entry)
+			logRecord := builder.Build()
+
+			Expect(builder.ResourceAttributes()).To(BeEmpty())
+			Expect(logRecord.AttributesLen()).To(Equal(4))
+		})
+
+		It("should move the kubernetes attributes to the resource with resource grouping", func() {
+			cfg := config.Config{}
+			cfg.OTLPConfig.ResourceGrouping = true
+
+			builder := NewLogRecordBuilder().WithConfig(cfg).WithAttributes(entry)
+			logRecord := builder.Build()
+
+			Expect(builder.ResourceAttributes()).To(ConsistOf(
+				attribute.String("k8s.namespace.name", "garden"),
+				attribute.String("k8s.pod.name", "etcd-main-0"),
+				attribute.String("k8s.container.name", "etcd"),
+			))
+			Expect(logRecord.AttributesLen()).To(Equal(1))
+			logRecord.WalkAttributes(func(kv otlplog.KeyValue) bool {
+				Expect(kv).To(Equal(otlplog.String("stream", "stdout")))
+
+				return true
+			})
+		})
+	})
+
 	Describe("marshalMap", func() {
 		It("should marshal a simple map to JSON", func() {
 			m := map[string]any{
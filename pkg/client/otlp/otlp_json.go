@@ -0,0 +1,122 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// jsonObject is a JSON object whose values are decoded on demand
+type jsonObject = map[string]json.RawMessage
+
+// MarshalLogsJSON encodes the records as OTLP/JSON LogsData, which is also the ExportLogsServiceRequest
+// sent to the OTLP/HTTP endpoint. Records are grouped by their resource and instrumentation scope.
+func MarshalLogsJSON(records []sdklog.Record) ([]byte, error) {
+	return marshalLogsJSON(logsRequest(records))
+}
+
+// TranscodeLogsProtoToJSON re-encodes a protobuf ExportLogsServiceRequest, like the requests of the SDK exporters,
+// as OTLP/JSON
+func TranscodeLogsProtoToJSON(data []byte) ([]byte, error) {
+	var request collogspb.ExportLogsServiceRequest
+	if err := proto.Unmarshal(data, &request); err != nil {
+		return nil, err
+	}
+
+	return marshalLogsJSON(&request)
+}
+
+// marshalLogsJSON renders the request in the OTLP/JSON encoding, which is the protobuf JSON mapping
+// with enums encoded as numbers and hex encoded instead of base64 encoded trace and span IDs.
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+func marshalLogsJSON(request *collogspb.ExportLogsServiceRequest) ([]byte, error) {
+	data, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(request)
+	if err != nil || !hasTraceContext(request) {
+		return data, err
+	}
+
+	var logs jsonObject
+	if err := json.Unmarshal(data, &logs); err != nil {
+		return nil, err
+	}
+	err = rewriteObjects(logs, "resourceLogs", func(resourceLogs jsonObject) error {
+		return rewriteObjects(resourceLogs, "scopeLogs", func(scopeLogs jsonObject) error {
+			return rewriteObjects(scopeLogs, "logRecords", hexEncodeIDs)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(logs)
+}
+
+// hasTraceContext reports whether any record of the request has a trace or span ID
+func hasTraceContext(request *collogspb.ExportLogsServiceRequest) bool {
+	for _, resourceLogs := range request.GetResourceLogs() {
+		for _, scopeLogs := range resourceLogs.GetScopeLogs() {
+			for _, record := range scopeLogs.GetLogRecords() {
+				if len(record.GetTraceId()) > 0 || len(record.GetSpanId()) > 0 {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// rewriteObjects applies fn to the objects of the array at the key of the object
+func rewriteObjects(object jsonObject, key string, fn func(jsonObject) error) error {
+	raw, ok := object[key]
+	if !ok {
+		return nil
+	}
+
+	var items []jsonObject
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	object[key] = encoded
+
+	return nil
+}
+
+// hexEncodeIDs replaces the base64 encoded trace and span ID of the log record with their hex encoding
+func hexEncodeIDs(record jsonObject) error {
+	for _, key := range []string{"traceId", "spanId"} {
+		raw, ok := record[key]
+		if !ok {
+			continue
+		}
+
+		var id []byte
+		if err := json.Unmarshal(raw, &id); err != nil {
+			return err
+		}
+		encoded, err := json.Marshal(hex.EncodeToString(id))
+		if err != nil {
+			return err
+		}
+		record[key] = encoded
+	}
+
+	return nil
+}
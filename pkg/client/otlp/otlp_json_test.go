@@ -0,0 +1,119 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp_test
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	otlplog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+)
+
+var _ = Describe("MarshalLogsJSON", func() {
+	var (
+		exporter *testExporter
+		provider *sdklog.LoggerProvider
+	)
+
+	BeforeEach(func() {
+		exporter = &testExporter{}
+		provider = sdklog.NewLoggerProvider(
+			sdklog.WithResource(sdkresource.NewSchemaless(attribute.String("host.name", "node-1"))),
+			sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)),
+		)
+	})
+
+	emit := func(ctx context.Context, scope string, record otlplog.Record) {
+		provider.Logger(scope, otlplog.WithInstrumentationVersion("v1.2.3")).Emit(ctx, record)
+	}
+
+	decode := func() map[string]any {
+		data, err := otlp.MarshalLogsJSON(exporter.exportedRecords)
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded map[string]any
+		Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+
+		return decoded
+	}
+
+	It("should encode records in the OTLP/JSON format", func() {
+		traceID := trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+		spanID := trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7}
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		}))
+
+		var record otlplog.Record
+		record.SetTimestamp(time.Unix(0, 1700000000123456789))
+		record.SetSeverity(otlplog.SeverityError)
+		record.SetSeverityText("ERROR")
+		record.SetBody(otlplog.MapValue(otlplog.String("msg", "failed"), otlplog.Float64("ratio", math.Inf(1))))
+		record.AddAttributes(
+			otlplog.Int64("count", 42),
+			otlplog.Slice("tags", otlplog.StringValue("a"), otlplog.BoolValue(true)),
+		)
+		emit(ctx, otlp.PluginName, record)
+
+		Expect(decode()).To(Equal(map[string]any{
+			"resourceLogs": []any{map[string]any{
+				"resource": map[string]any{"attributes": []any{
+					map[string]any{"key": "host.name", "value": map[string]any{"stringValue": "node-1"}},
+				}},
+				"scopeLogs": []any{map[string]any{
+					"scope": map[string]any{"name": otlp.PluginName, "version": "v1.2.3"},
+					"logRecords": []any{map[string]any{
+						"timeUnixNano":         "1700000000123456789",
+						"observedTimeUnixNano": strconv.FormatInt(exporter.exportedRecords[0].ObservedTimestamp().UnixNano(), 10),
+						"severityNumber":       float64(otlplog.SeverityError),
+						"severityText":         "ERROR",
+						"body": map[string]any{"kvlistValue": map[string]any{"values": []any{
+							map[string]any{"key": "msg", "value": map[string]any{"stringValue": "failed"}},
+							map[string]any{"key": "ratio", "value": map[string]any{"doubleValue": "Infinity"}},
+						}}},
+						"attributes": []any{
+							map[string]any{"key": "count", "value": map[string]any{"intValue": "42"}},
+							map[string]any{"key": "tags", "value": map[string]any{"arrayValue": map[string]any{"values": []any{
+								map[string]any{"stringValue": "a"},
+								map[string]any{"boolValue": true},
+							}}}},
+						},
+						"flags":   float64(1),
+						"traceId": "4bf92f3577b34da6a3ce929d0e0e4736",
+						"spanId":  "00f067aa0ba902b7",
+					}},
+				}},
+			}},
+		}))
+	})
+
+	It("should group records by resource and scope", func() {
+		var record otlplog.Record
+		record.SetBody(otlplog.StringValue("test"))
+		emit(context.Background(), "first", record)
+		emit(context.Background(), "second", record)
+		emit(context.Background(), "first", record)
+
+		resourceLogs := decode()["resourceLogs"].([]any)
+		Expect(resourceLogs).To(HaveLen(1))
+
+		scopeLogs := resourceLogs[0].(map[string]any)["scopeLogs"].([]any)
+		Expect(scopeLogs).To(HaveLen(2))
+		Expect(scopeLogs[0]).To(HaveKeyWithValue("logRecords", HaveLen(2)))
+		Expect(scopeLogs[1]).To(HaveKeyWithValue("logRecords", HaveLen(1)))
+	})
+})
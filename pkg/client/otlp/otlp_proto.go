@@ -0,0 +1,190 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	otlplog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// MarshalLogsProto encodes the records as protobuf ExportLogsServiceRequest, the body sent to the OTLP/HTTP endpoint
+func MarshalLogsProto(records []sdklog.Record) ([]byte, error) {
+	return proto.Marshal(logsRequest(records))
+}
+
+// logsRequest converts the records to an ExportLogsServiceRequest, grouping them by their resource and instrumentation scope
+func logsRequest(records []sdklog.Record) *collogspb.ExportLogsServiceRequest {
+	type scopeKey struct {
+		resource attribute.Distinct
+		scope    instrumentation.Scope
+	}
+
+	request := &collogspb.ExportLogsServiceRequest{ResourceLogs: make([]*logspb.ResourceLogs, 0, 1)}
+	resources := make(map[attribute.Distinct]*logspb.ResourceLogs)
+	scopes := make(map[scopeKey]*logspb.ScopeLogs)
+
+	for i := range records {
+		r := &records[i]
+
+		res := r.Resource()
+		if res == nil {
+			res = sdkresource.Empty()
+		}
+		resourceKey := res.Equivalent()
+		resourceLogs, ok := resources[resourceKey]
+		if !ok {
+			resourceLogs = &logspb.ResourceLogs{
+				Resource:  &resourcepb.Resource{Attributes: attributesToProto(res.Set())},
+				SchemaUrl: res.SchemaURL(),
+			}
+			resources[resourceKey] = resourceLogs
+			request.ResourceLogs = append(request.ResourceLogs, resourceLogs)
+		}
+
+		scope := r.InstrumentationScope()
+		key := scopeKey{resource: resourceKey, scope: scope}
+		scopeLogs, ok := scopes[key]
+		if !ok {
+			scopeLogs = &logspb.ScopeLogs{
+				Scope: &commonpb.InstrumentationScope{
+					Name:       scope.Name,
+					Version:    scope.Version,
+					Attributes: attributesToProto(&scope.Attributes),
+				},
+				SchemaUrl: scope.SchemaURL,
+			}
+			scopes[key] = scopeLogs
+			resourceLogs.ScopeLogs = append(resourceLogs.ScopeLogs, scopeLogs)
+		}
+
+		scopeLogs.LogRecords = append(scopeLogs.LogRecords, logRecordToProto(r))
+	}
+
+	return request
+}
+
+func logRecordToProto(r *sdklog.Record) *logspb.LogRecord {
+	record := &logspb.LogRecord{
+		SeverityNumber:         logspb.SeverityNumber(r.Severity()), // #nosec G115 -- severities are within [0, 24]
+		SeverityText:           r.SeverityText(),
+		EventName:              r.EventName(),
+		DroppedAttributesCount: uint32(r.DroppedAttributes()), // #nosec G115 -- dropped attributes are not negative
+		Flags:                  uint32(r.TraceFlags()),
+	}
+
+	if ts := r.Timestamp(); !ts.IsZero() {
+		record.TimeUnixNano = uint64(ts.UnixNano()) // #nosec G115 -- timestamps after 1970
+	}
+	if ts := r.ObservedTimestamp(); !ts.IsZero() {
+		record.ObservedTimeUnixNano = uint64(ts.UnixNano()) // #nosec G115 -- timestamps after 1970
+	}
+
+	if body := r.Body(); !body.Empty() {
+		record.Body = logValueToProto(body)
+	}
+
+	if n := r.AttributesLen(); n > 0 {
+		record.Attributes = make([]*commonpb.KeyValue, 0, n)
+		r.WalkAttributes(func(kv otlplog.KeyValue) bool {
+			record.Attributes = append(record.Attributes, &commonpb.KeyValue{Key: kv.Key, Value: logValueToProto(kv.Value)})
+
+			return true
+		})
+	}
+
+	if traceID := r.TraceID(); traceID.IsValid() {
+		record.TraceId = traceID[:]
+	}
+	if spanID := r.SpanID(); spanID.IsValid() {
+		record.SpanId = spanID[:]
+	}
+
+	return record
+}
+
+func logValueToProto(v otlplog.Value) *commonpb.AnyValue {
+	switch v.Kind() {
+	case otlplog.KindString:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.AsString()}}
+	case otlplog.KindBool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v.AsBool()}}
+	case otlplog.KindInt64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v.AsInt64()}}
+	case otlplog.KindFloat64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v.AsFloat64()}}
+	case otlplog.KindBytes:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: v.AsBytes()}}
+	case otlplog.KindSlice:
+		values := make([]*commonpb.AnyValue, 0, len(v.AsSlice()))
+		for _, item := range v.AsSlice() {
+			values = append(values, logValueToProto(item))
+		}
+
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: values}}}
+	case otlplog.KindMap:
+		values := make([]*commonpb.KeyValue, 0, len(v.AsMap()))
+		for _, kv := range v.AsMap() {
+			values = append(values, &commonpb.KeyValue{Key: kv.Key, Value: logValueToProto(kv.Value)})
+		}
+
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{Values: values}}}
+	default:
+		return &commonpb.AnyValue{}
+	}
+}
+
+func attributesToProto(set *attribute.Set) []*commonpb.KeyValue {
+	if set.Len() == 0 {
+		return nil
+	}
+
+	attrs := make([]*commonpb.KeyValue, 0, set.Len())
+	iter := set.Iter()
+	for iter.Next() {
+		kv := iter.Attribute()
+		attrs = append(attrs, &commonpb.KeyValue{Key: string(kv.Key), Value: attributeValueToProto(kv.Value)})
+	}
+
+	return attrs
+}
+
+func attributeValueToProto(v attribute.Value) *commonpb.AnyValue {
+	switch v.Type() {
+	case attribute.STRING:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.AsString()}}
+	case attribute.BOOL:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v.AsBool()}}
+	case attribute.INT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v.AsInt64()}}
+	case attribute.FLOAT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v.AsFloat64()}}
+	case attribute.STRINGSLICE:
+		return sliceToProto(v.AsStringSlice(), attribute.StringValue)
+	case attribute.BOOLSLICE:
+		return sliceToProto(v.AsBoolSlice(), attribute.BoolValue)
+	case attribute.INT64SLICE:
+		return sliceToProto(v.AsInt64Slice(), attribute.Int64Value)
+	case attribute.FLOAT64SLICE:
+		return sliceToProto(v.AsFloat64Slice(), attribute.Float64Value)
+	default:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.Emit()}}
+	}
+}
+
+func sliceToProto[T any](items []T, value func(T) attribute.Value) *commonpb.AnyValue {
+	values := make([]*commonpb.AnyValue, 0, len(items))
+	for _, item := range items {
+		values = append(values, attributeValueToProto(value(item)))
+	}
+
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: values}}}
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp_test
+
+import (
+	"context"
+	"math"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	otlplog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+)
+
+var _ = Describe("MarshalLogsProto", func() {
+	var (
+		exporter *testExporter
+		provider *sdklog.LoggerProvider
+	)
+
+	BeforeEach(func() {
+		exporter = &testExporter{}
+		provider = sdklog.NewLoggerProvider(
+			sdklog.WithResource(sdkresource.NewSchemaless(attribute.String("host.name", "node-1"))),
+			sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)),
+		)
+	})
+
+	decode := func() *collogspb.ExportLogsServiceRequest {
+		data, err := otlp.MarshalLogsProto(exporter.exportedRecords)
+		Expect(err).NotTo(HaveOccurred())
+
+		var request collogspb.ExportLogsServiceRequest
+		Expect(proto.Unmarshal(data, &request)).To(Succeed())
+
+		return &request
+	}
+
+	It("should encode records as protobuf export request", func() {
+		var record otlplog.Record
+		record.SetTimestamp(time.Unix(0, 1700000000123456789))
+		record.SetSeverity(otlplog.SeverityError)
+		record.SetSeverityText("ERROR")
+		record.SetBody(otlplog.MapValue(otlplog.String("msg", "failed")))
+		record.AddAttributes(
+			otlplog.Int64("count", 42),
+			otlplog.Slice("tags", otlplog.StringValue("a"), otlplog.BoolValue(true)),
+		)
+		provider.Logger(otlp.PluginName, otlplog.WithInstrumentationVersion("v1.2.3")).Emit(context.Background(), record)
+
+		request := decode()
+		Expect(request.ResourceLogs).To(HaveLen(1))
+		resourceLogs := request.ResourceLogs[0]
+		Expect(resourceLogs.Resource.Attributes).To(HaveLen(1))
+		Expect(resourceLogs.Resource.Attributes[0].Key).To(Equal("host.name"))
+		Expect(resourceLogs.Resource.Attributes[0].Value.GetStringValue()).To(Equal("node-1"))
+
+		Expect(resourceLogs.ScopeLogs).To(HaveLen(1))
+		Expect(resourceLogs.ScopeLogs[0].Scope.Name).To(Equal(otlp.PluginName))
+		Expect(resourceLogs.ScopeLogs[0].Scope.Version).To(Equal("v1.2.3"))
+		Expect(resourceLogs.ScopeLogs[0].LogRecords).To(HaveLen(1))
+
+		logRecord := resourceLogs.ScopeLogs[0].LogRecords[0]
+		Expect(logRecord.TimeUnixNano).To(Equal(uint64(1700000000123456789)))
+		Expect(logRecord.SeverityNumber).To(Equal(logspb.SeverityNumber_SEVERITY_NUMBER_ERROR))
+		Expect(logRecord.SeverityText).To(Equal("ERROR"))
+		Expect(logRecord.Body.GetKvlistValue().Values[0].Key).To(Equal("msg"))
+		Expect(logRecord.Body.GetKvlistValue().Values[0].Value.GetStringValue()).To(Equal("failed"))
+		Expect(logRecord.Attributes).To(HaveLen(2))
+		Expect(logRecord.Attributes[0].Value.GetIntValue()).To(Equal(int64(42)))
+		tags := logRecord.Attributes[1].Value.GetArrayValue().Values
+		Expect(tags).To(HaveLen(2))
+		Expect(tags[0].GetStringValue()).To(Equal("a"))
+		Expect(tags[1].GetBoolValue()).To(BeTrue())
+	})
+
+	It("should transcode export requests to the OTLP/JSON encoding of MarshalLogsJSON", func() {
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+			SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+			TraceFlags: trace.FlagsSampled,
+		}))
+
+		var record otlplog.Record
+		record.SetTimestamp(time.Unix(0, 1700000000123456789))
+		record.SetSeverity(otlplog.SeverityWarn)
+		record.SetBody(otlplog.MapValue(otlplog.String("msg", "failed"), otlplog.Float64("ratio", math.NaN())))
+		record.AddAttributes(otlplog.Int64("count", 42), otlplog.Bytes("raw", []byte{1, 2}), otlplog.Map("empty"))
+		provider.Logger(otlp.PluginName, otlplog.WithInstrumentationVersion("v1.2.3")).Emit(ctx, record)
+
+		data, err := otlp.MarshalLogsProto(exporter.exportedRecords)
+		Expect(err).NotTo(HaveOccurred())
+		transcoded, err := otlp.TranscodeLogsProtoToJSON(data)
+		Expect(err).NotTo(HaveOccurred())
+		expected, err := otlp.MarshalLogsJSON(exporter.exportedRecords)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(transcoded).To(MatchJSON(expected))
+		Expect(string(transcoded)).To(ContainSubstring(`"traceId":"4bf92f3577b34da6a3ce929d0e0e4736"`))
+	})
+
+	It("should group records by resource and scope", func() {
+		var record otlplog.Record
+		record.SetBody(otlplog.StringValue("test"))
+		provider.Logger("first").Emit(context.Background(), record)
+		provider.Logger("second").Emit(context.Background(), record)
+		provider.Logger("first").Emit(context.Background(), record)
+
+		request := decode()
+		Expect(request.ResourceLogs).To(HaveLen(1))
+		Expect(request.ResourceLogs[0].ScopeLogs).To(HaveLen(2))
+		Expect(request.ResourceLogs[0].ScopeLogs[0].LogRecords).To(HaveLen(2))
+		Expect(request.ResourceLogs[0].ScopeLogs[1].LogRecords).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,34 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpgrpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/gardener/logging/v1/pkg/client/auth"
+)
+
+// authCredentials adds the authorization of the authenticator to the metadata of each RPC
+type authCredentials struct {
+	authenticator auth.Authenticator
+}
+
+var _ credentials.PerRPCCredentials = &authCredentials{}
+
+// GetRequestMetadata returns the authorization metadata, the RPC fails if the authenticator fails
+func (c *authCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	authorization, err := c.authenticator.Authorization(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"authorization": authorization}, nil
+}
+
+// RequireTransportSecurity returns false, the credentials are sent like the headers also over insecure connections
+func (*authCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpgrpc
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+// The SDK exporter only supports gzip, the zstd and snappy compressors are registered
+// with the names used by the OpenTelemetry collector
+func init() {
+	encoding.RegisterCompressor(&zstdCompressor{})
+	encoding.RegisterCompressor(&snappyCompressor{})
+}
+
+// zstdCompressor is a gRPC compressor pooling the zstd encoders and decoders
+type zstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+var _ encoding.Compressor = &zstdCompressor{}
+
+// Name returns the name of the grpc-encoding
+func (*zstdCompressor) Name() string {
+	return config.CompressionZstd
+}
+
+// Compress returns a writer compressing to w, the encoder is returned to the pool when the writer is closed
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	encoder, ok := c.encoders.Get().(*zstd.Encoder)
+	if !ok {
+		var err error
+		if encoder, err = zstd.NewWriter(w, zstd.WithEncoderConcurrency(1)); err != nil {
+			return nil, err
+		}
+	} else {
+		encoder.Reset(w)
+	}
+
+	return &zstdWriter{Encoder: encoder, pool: &c.encoders}, nil
+}
+
+// Decompress returns a reader decompressing r, the decoder is returned to the pool when r is read to the end
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	decoder, ok := c.decoders.Get().(*zstd.Decoder)
+	if !ok {
+		var err error
+		if decoder, err = zstd.NewReader(r, zstd.WithDecoderConcurrency(1)); err != nil {
+			return nil, err
+		}
+	} else if err := decoder.Reset(r); err != nil {
+		c.decoders.Put(decoder)
+
+		return nil, err
+	}
+
+	return &zstdReader{Decoder: decoder, pool: &c.decoders}, nil
+}
+
+type zstdWriter struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+// Close flushes the compressed data and returns the encoder to the pool
+func (w *zstdWriter) Close() error {
+	err := w.Encoder.Close()
+	w.pool.Put(w.Encoder)
+
+	return err
+}
+
+type zstdReader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+// Read decompresses into p and returns the decoder to the pool at the end of the stream
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.Decoder == nil {
+		return 0, io.EOF
+	}
+
+	n, err := r.Decoder.Read(p)
+	if err == io.EOF {
+		r.pool.Put(r.Decoder)
+		r.Decoder = nil
+	}
+
+	return n, err
+}
+
+// snappyCompressor is a gRPC compressor using the snappy framing format
+type snappyCompressor struct{}
+
+var _ encoding.Compressor = &snappyCompressor{}
+
+// Name returns the name of the grpc-encoding
+func (*snappyCompressor) Name() string {
+	return config.CompressionSnappy
+}
+
+// Compress returns a writer compressing to w
+func (*snappyCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return snappy.NewBufferedWriter(w), nil
+}
+
+// Decompress returns a reader decompressing r
+func (*snappyCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	return snappy.NewReader(r), nil
+}
@@ -6,15 +6,20 @@ package otlpgrpc
 import (
 	"github.com/go-logr/logr"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	"github.com/gardener/logging/v1/pkg/client/auth"
+	"github.com/gardener/logging/v1/pkg/client/otlp"
 	"github.com/gardener/logging/v1/pkg/config"
 )
 
 // ConfigBuilder builds OTLP gRPC exporter options from configuration
 type ConfigBuilder struct {
-	cfg    config.Config
-	logger logr.Logger
+	cfg           config.Config
+	logger        logr.Logger
+	authenticator auth.Authenticator
+	limiter       *otlp.Limiter
 }
 
 // NewConfigBuilder creates a new OTLP gRPC configuration builder
@@ -22,6 +27,20 @@ func NewConfigBuilder(cfg config.Config, logger logr.Logger) *ConfigBuilder {
 	return &ConfigBuilder{cfg: cfg, logger: logger}
 }
 
+// WithAuthenticator sets the authenticator of the requests
+func (b *ConfigBuilder) WithAuthenticator(authenticator auth.Authenticator) *ConfigBuilder {
+	b.authenticator = authenticator
+
+	return b
+}
+
+// WithLimiter sets the limiter of the client, an adaptive limiter is told about throttled exports
+func (b *ConfigBuilder) WithLimiter(limiter *otlp.Limiter) *ConfigBuilder {
+	b.limiter = limiter
+
+	return b
+}
+
 // Build constructs the exporter options
 func (b *ConfigBuilder) Build() []otlploggrpc.Option {
 	opts := []otlploggrpc.Option{
@@ -30,6 +49,8 @@ func (b *ConfigBuilder) Build() []otlploggrpc.Option {
 
 	b.configureTLS(&opts)
 	b.configureHeaders(&opts)
+	b.configureAuth(&opts)
+	b.configureThrottle(&opts)
 	b.configureTimeout(&opts)
 	b.configureCompression(&opts)
 	b.configureRetry(&opts)
@@ -51,6 +72,24 @@ func (b *ConfigBuilder) configureHeaders(opts *[]otlploggrpc.Option) {
 	if len(b.cfg.OTLPConfig.Headers) > 0 {
 		*opts = append(*opts, otlploggrpc.WithHeaders(b.cfg.OTLPConfig.Headers))
 	}
+	if b.cfg.OTLPConfig.HeaderFiles != nil {
+		*opts = append(*opts, otlploggrpc.WithDialOption(
+			grpc.WithPerRPCCredentials(&headerFileCredentials{headers: b.cfg.OTLPConfig.HeaderFiles})))
+	}
+}
+
+func (b *ConfigBuilder) configureAuth(opts *[]otlploggrpc.Option) {
+	if b.authenticator != nil {
+		*opts = append(*opts, otlploggrpc.WithDialOption(
+			grpc.WithPerRPCCredentials(&authCredentials{authenticator: b.authenticator})))
+	}
+}
+
+func (b *ConfigBuilder) configureThrottle(opts *[]otlploggrpc.Option) {
+	if b.limiter != nil && b.limiter.Adaptive() {
+		*opts = append(*opts, otlploggrpc.WithDialOption(
+			grpc.WithChainUnaryInterceptor(throttleInterceptor(b.limiter))))
+	}
 }
 
 func (b *ConfigBuilder) configureTimeout(opts *[]otlploggrpc.Option) {
@@ -60,8 +99,14 @@ func (b *ConfigBuilder) configureTimeout(opts *[]otlploggrpc.Option) {
 }
 
 func (b *ConfigBuilder) configureCompression(opts *[]otlploggrpc.Option) {
-	if b.cfg.OTLPConfig.Compression > 0 {
-		*opts = append(*opts, otlploggrpc.WithCompressor(compressionToString(b.cfg.OTLPConfig.Compression)))
+	//nolint:revive // enforce-switch-style: default-case is omitted on purpose, none needs no option
+	switch b.cfg.OTLPConfig.Compression {
+	case config.CompressionGzip:
+		*opts = append(*opts, otlploggrpc.WithCompressor(config.CompressionGzip))
+	case config.CompressionZstd, config.CompressionSnappy:
+		// The exporter only supports gzip, the compressors are registered by this package
+		*opts = append(*opts, otlploggrpc.WithDialOption(
+			grpc.WithDefaultCallOptions(grpc.UseCompressor(b.cfg.OTLPConfig.Compression))))
 	}
 }
 
@@ -75,13 +120,3 @@ func (b *ConfigBuilder) configureRetry(opts *[]otlploggrpc.Option) {
 		}))
 	}
 }
-
-// compressionToString maps compression integer to string
-func compressionToString(compression int) string {
-	switch compression {
-	case 1:
-		return "gzip"
-	default:
-		return "none"
-	}
-}
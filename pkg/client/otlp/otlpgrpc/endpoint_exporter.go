@@ -0,0 +1,63 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpgrpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/client/retry"
+)
+
+// endpointExporter is the exporter of an endpoint. It marks the errors of transient failures as retryable,
+// so that the failover exporter tries the next endpoint, and the errors of messages exceeding the maximum
+// message size with otlp.ErrBatchTooLarge, so that the batch processor splits the batch. Other errors are returned as they are.
+type endpointExporter struct {
+	sdklog.Exporter
+}
+
+// Export exports the records and marks the errors of transient failures and too large batches
+func (e *endpointExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	err := e.Exporter.Export(ctx, records)
+	switch {
+	case err == nil:
+		return nil
+	case tooLarge(err):
+		return fmt.Errorf("%w: %w", otlp.ErrBatchTooLarge, err)
+	case transient(err):
+		return retry.Retryable(err, 0)
+	default:
+		return err
+	}
+}
+
+// tooLarge reports whether the message of the export exceeded the maximum message size of the client or the collector.
+// gRPC rejects such messages with ResourceExhausted, which otherwise signals rate limiting.
+func tooLarge(err error) bool {
+	st, ok := status.FromError(err)
+
+	return ok && st.Code() == codes.ResourceExhausted && strings.Contains(st.Message(), "larger than max")
+}
+
+// transient reports whether the export failed in the transport or the collector is unavailable, overloaded or failing.
+// Errors without gRPC status did not reach the collector.
+func transient(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return true
+	}
+
+	switch st.Code() {
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded, codes.Aborted, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
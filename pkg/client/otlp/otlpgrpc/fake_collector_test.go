@@ -0,0 +1,131 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpgrpc_test
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+)
+
+// export is an export request received by the fake collector
+type export struct {
+	encoding      string
+	wireBytes     int
+	records       int
+	authorization []string
+}
+
+type rpcStatsKey struct{}
+
+// fakeCollector is an in-process OTLP logs gRPC server recording the received exports and their compressed size
+type fakeCollector struct {
+	collogspb.UnimplementedLogsServiceServer
+
+	addr string
+
+	mu      sync.Mutex
+	exports []export
+	err     error // Returned instead of accepting the exports if set
+}
+
+var _ stats.Handler = &fakeCollector{}
+
+func newFakeCollector() *fakeCollector {
+	c := &fakeCollector{}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	c.addr = listener.Addr().String()
+
+	server := grpc.NewServer(grpc.StatsHandler(c))
+	collogspb.RegisterLogsServiceServer(server, c)
+	go func() { _ = server.Serve(listener) }()
+	DeferCleanup(server.Stop)
+
+	return c
+}
+
+// Export records the request, the encoding and wire size are set by the stats handler before the handler is called
+func (c *fakeCollector) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	c.mu.Lock()
+	err := c.err
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	e := export{}
+	if rpcStats, ok := ctx.Value(rpcStatsKey{}).(*export); ok {
+		e = *rpcStats
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		e.authorization = md.Get("authorization")
+	}
+	for _, resourceLogs := range req.ResourceLogs {
+		for _, scopeLogs := range resourceLogs.ScopeLogs {
+			e.records += len(scopeLogs.LogRecords)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.exports = append(c.exports, e)
+
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+func (c *fakeCollector) received() []export {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]export(nil), c.exports...)
+}
+
+// fail makes the collector reject the exports with the error, nil accepts them again
+func (c *fakeCollector) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.err = err
+}
+
+func (c *fakeCollector) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.exports = nil
+}
+
+func (*fakeCollector) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, rpcStatsKey{}, &export{})
+}
+
+func (*fakeCollector) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	rpcStats, ok := ctx.Value(rpcStatsKey{}).(*export)
+	if !ok {
+		return
+	}
+
+	//nolint:revive // enforce-switch-style: default-case is omitted on purpose, other stats are not recorded
+	switch in := s.(type) {
+	case *stats.InHeader:
+		rpcStats.encoding = in.Compression
+	case *stats.InPayload:
+		rpcStats.wireBytes += in.CompressedLength
+	}
+}
+
+func (*fakeCollector) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (*fakeCollector) HandleConn(context.Context, stats.ConnStats) {}
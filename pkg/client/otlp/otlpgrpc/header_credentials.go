@@ -0,0 +1,30 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpgrpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+// headerFileCredentials adds the headers read from files to the metadata of each RPC,
+// so that rotated files, e.g. projected service account tokens, are used by the following exports.
+type headerFileCredentials struct {
+	headers *config.HeaderFiles
+}
+
+var _ credentials.PerRPCCredentials = &headerFileCredentials{}
+
+// GetRequestMetadata returns the current values of the headers
+func (c *headerFileCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return c.headers.Values(), nil
+}
+
+// RequireTransportSecurity returns false, the headers are sent like the static headers also over insecure connections
+func (*headerFileCredentials) RequireTransportSecurity() bool {
+	return false
+}
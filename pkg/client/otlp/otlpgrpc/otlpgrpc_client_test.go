@@ -5,11 +5,20 @@ package otlpgrpc_test
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/gardener/logging/v1/pkg/client/api"
 	"github.com/gardener/logging/v1/pkg/client/otlp/otlpgrpc"
@@ -33,7 +42,7 @@ var _ = Describe("OTLPGRPCClient", func() {
 			OTLPConfig: config.OTLPConfig{
 				Endpoint:    "localhost:4317",
 				Insecure:    true,
-				Compression: 0,
+				Compression: config.CompressionNone,
 				Timeout:     30 * time.Second,
 				Headers:     make(map[string]string),
 				DQueConfig: config.DQueConfig{
@@ -207,6 +216,252 @@ var _ = Describe("OTLPGRPCClient", func() {
 		})
 	})
 
+	Describe("Compression", func() {
+		var collector *fakeCollector
+
+		BeforeEach(func() {
+			collector = newFakeCollector()
+			cfg.OTLPConfig.Endpoint = collector.addr
+			cfg.OTLPConfig.UseSDKBatchProcessor = true
+			cfg.OTLPConfig.SDKBatchMaxQueueSize = config.DefaultOTLPConfig.SDKBatchMaxQueueSize
+			cfg.OTLPConfig.SDKBatchExportTimeout = config.DefaultOTLPConfig.SDKBatchExportTimeout
+			cfg.OTLPConfig.SDKBatchExportInterval = config.DefaultOTLPConfig.SDKBatchExportInterval
+			cfg.OTLPConfig.SDKBatchExportMaxBatchSize = config.DefaultOTLPConfig.SDKBatchExportMaxBatchSize
+		})
+
+		// send sends a batch of typical container logs with the compression and returns the received export
+		send := func(compression string) export {
+			collector.reset()
+
+			c := cfg
+			c.OTLPConfig.Compression = compression
+			client, err := otlpgrpc.New(context.Background(), c, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+			for i := range 200 {
+				Expect(client.Handle(types.OutputEntry{
+					Timestamp: time.Unix(1700000000, int64(i)*1000),
+					Record: map[string]any{
+						"log":   fmt.Sprintf("I1116 10:13:%02d.%06d reconciler.go:93] Reconciling object default/etcd-%d", i%60, i*137, i%3),
+						"level": "info",
+						"kubernetes": map[string]any{
+							"namespace_name": "shoot--dev--cluster",
+							"pod_name":       fmt.Sprintf("etcd-main-%d", i%3),
+							"container_name": "etcd",
+						},
+					},
+				})).To(Succeed())
+			}
+			client.StopWait()
+
+			exports := collector.received()
+			Expect(exports).To(HaveLen(1))
+
+			return exports[0]
+		}
+
+		DescribeTable("should compress the requests with the registered compressors and reduce the bandwidth",
+			func(compression string, minSaving float64) {
+				uncompressed := send(config.CompressionNone)
+				Expect(uncompressed.encoding).To(BeEmpty())
+
+				compressed := send(compression)
+				Expect(compressed.encoding).To(Equal(compression))
+				Expect(compressed.records).To(Equal(200))
+
+				saving := 1 - float64(compressed.wireBytes)/float64(uncompressed.wireBytes)
+				AddReportEntry(compression+" bandwidth", fmt.Sprintf("%d of %d bytes, %.1f%% saved",
+					compressed.wireBytes, uncompressed.wireBytes, 100*saving))
+				Expect(saving).To(BeNumerically(">=", minSaving))
+			},
+			Entry("gzip", config.CompressionGzip, 0.8),
+			Entry("zstd", config.CompressionZstd, 0.8),
+			Entry("snappy", config.CompressionSnappy, 0.6),
+		)
+	})
+
+	Describe("Failover", func() {
+		It("should export to the failover endpoint if the primary endpoint is unreachable", func() {
+			collector := newFakeCollector()
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			unreachable := listener.Addr().String()
+			Expect(listener.Close()).To(Succeed())
+
+			cfg.OTLPConfig.Endpoint = unreachable
+			cfg.OTLPConfig.FailoverEndpoints = []string{collector.addr}
+			cfg.OTLPConfig.FailoverFailureThreshold = 1
+			cfg.OTLPConfig.FailoverCooldown = time.Minute
+			cfg.OTLPConfig.UseSDKBatchProcessor = true
+			cfg.OTLPConfig.SDKBatchMaxQueueSize = config.DefaultOTLPConfig.SDKBatchMaxQueueSize
+			cfg.OTLPConfig.SDKBatchExportTimeout = config.DefaultOTLPConfig.SDKBatchExportTimeout
+			cfg.OTLPConfig.SDKBatchExportInterval = config.DefaultOTLPConfig.SDKBatchExportInterval
+			cfg.OTLPConfig.SDKBatchExportMaxBatchSize = config.DefaultOTLPConfig.SDKBatchExportMaxBatchSize
+
+			client, err := otlpgrpc.New(context.Background(), cfg, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(client.Endpoint()).To(Equal(unreachable))
+			Expect(client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": "failover"}})).To(Succeed())
+			client.StopWait()
+
+			Expect(collector.received()).To(HaveLen(1))
+			Expect(collector.received()[0].records).To(Equal(1))
+			Expect(client.Endpoint()).To(Equal(collector.addr))
+		})
+
+		It("should not fail over if the primary endpoint rejects the records", func() {
+			primary := newFakeCollector()
+			primary.fail(status.Error(codes.InvalidArgument, "invalid records"))
+			secondary := newFakeCollector()
+
+			cfg.OTLPConfig.Endpoint = primary.addr
+			cfg.OTLPConfig.FailoverEndpoints = []string{secondary.addr}
+			cfg.OTLPConfig.FailoverFailureThreshold = 1
+			cfg.OTLPConfig.FailoverCooldown = time.Minute
+			cfg.OTLPConfig.UseSDKBatchProcessor = true
+			cfg.OTLPConfig.SDKBatchMaxQueueSize = config.DefaultOTLPConfig.SDKBatchMaxQueueSize
+			cfg.OTLPConfig.SDKBatchExportTimeout = config.DefaultOTLPConfig.SDKBatchExportTimeout
+			cfg.OTLPConfig.SDKBatchExportInterval = config.DefaultOTLPConfig.SDKBatchExportInterval
+			cfg.OTLPConfig.SDKBatchExportMaxBatchSize = config.DefaultOTLPConfig.SDKBatchExportMaxBatchSize
+
+			client, err := otlpgrpc.New(context.Background(), cfg, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": "rejected"}})).To(Succeed())
+			client.StopWait()
+
+			Expect(secondary.received()).To(BeEmpty())
+			Expect(client.Endpoint()).To(Equal(primary.addr))
+		})
+	})
+
+	Describe("Request size", func() {
+		It("should move records rejected as too large to the dead letter file instead of requeueing them", func() {
+			collector := newFakeCollector()
+			collector.fail(status.Error(codes.ResourceExhausted, "grpc: received message larger than max (5000 vs. 4000)"))
+
+			cfg.OTLPConfig.Endpoint = collector.addr
+			cfg.OTLPConfig.DQueBatchProcessorDeadLetterMaxBytes = 1 << 20
+
+			client, err := otlpgrpc.New(context.Background(), cfg, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+			defer client.Stop()
+			Expect(client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": "first"}})).To(Succeed())
+			Expect(client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": "second"}})).To(Succeed())
+
+			Eventually(func() float64 {
+				return testutil.ToFloat64(testMetrics.DroppedLogs.WithLabelValues(collector.addr, "dead_letter"))
+			}).Should(Equal(2.0))
+			Expect(testutil.ToFloat64(testMetrics.DroppedLogs.WithLabelValues(collector.addr, "export_error"))).To(BeZero())
+		})
+	})
+
+	Describe("Header files", func() {
+		It("should send the current values of the header files", func() {
+			collector := newFakeCollector()
+			token := filepath.Join(GinkgoT().TempDir(), "token")
+			Expect(os.WriteFile(token, []byte("first-token\n"), 0o600)).To(Succeed())
+			parsed, err := config.ParseConfig(map[string]any{
+				"HeaderFiles": `{"Authorization": {"file": "` + token + `", "prefix": "Bearer "}}`,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			cfg.OTLPConfig.Endpoint = collector.addr
+			cfg.OTLPConfig.HeaderFiles = parsed.OTLPConfig.HeaderFiles
+			cfg.OTLPConfig.UseSDKBatchProcessor = true
+			cfg.OTLPConfig.SDKBatchMaxQueueSize = config.DefaultOTLPConfig.SDKBatchMaxQueueSize
+			cfg.OTLPConfig.SDKBatchExportTimeout = config.DefaultOTLPConfig.SDKBatchExportTimeout
+			cfg.OTLPConfig.SDKBatchExportInterval = 20 * time.Millisecond
+			cfg.OTLPConfig.SDKBatchExportMaxBatchSize = config.DefaultOTLPConfig.SDKBatchExportMaxBatchSize
+
+			client, err := otlpgrpc.New(context.Background(), cfg, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+			defer client.StopWait()
+
+			authorizations := func() [][]string {
+				var values [][]string
+				for _, e := range collector.received() {
+					values = append(values, e.authorization)
+				}
+
+				return values
+			}
+
+			Expect(client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": "first"}})).To(Succeed())
+			Eventually(authorizations).Should(Equal([][]string{{"Bearer first-token"}}))
+
+			// The rotated token is longer, so that the change is detected also with a coarse modification time
+			Expect(os.WriteFile(token, []byte("rotated-second-token"), 0o600)).To(Succeed())
+			Expect(client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": "second"}})).To(Succeed())
+			Eventually(authorizations).Should(ContainElement([]string{"Bearer rotated-second-token"}))
+		})
+	})
+
+	Describe("Authentication", func() {
+		It("should send the service account token as per-RPC credentials", func() {
+			collector := newFakeCollector()
+			token := filepath.Join(GinkgoT().TempDir(), "token")
+			Expect(os.WriteFile(token, []byte("service-account-token"), 0o600)).To(Succeed())
+
+			cfg.OTLPConfig.Endpoint = collector.addr
+			cfg.OTLPConfig.Authenticator = config.AuthenticatorKubernetes
+			cfg.OTLPConfig.KubernetesTokenFile = token
+			cfg.OTLPConfig.UseSDKBatchProcessor = true
+			cfg.OTLPConfig.SDKBatchMaxQueueSize = config.DefaultOTLPConfig.SDKBatchMaxQueueSize
+			cfg.OTLPConfig.SDKBatchExportTimeout = config.DefaultOTLPConfig.SDKBatchExportTimeout
+			cfg.OTLPConfig.SDKBatchExportInterval = config.DefaultOTLPConfig.SDKBatchExportInterval
+			cfg.OTLPConfig.SDKBatchExportMaxBatchSize = config.DefaultOTLPConfig.SDKBatchExportMaxBatchSize
+
+			client, err := otlpgrpc.New(context.Background(), cfg, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": "authenticated"}})).To(Succeed())
+			client.StopWait()
+
+			Expect(collector.received()).To(HaveLen(1))
+			Expect(collector.received()[0].authorization).To(Equal([]string{"Bearer service-account-token"}))
+		})
+	})
+
+	Describe("Throttling", func() {
+		It("should lower the limits when the collector throttles and raise them again when it recovers", func() {
+			collector := newFakeCollector()
+			throttled, err := status.New(codes.ResourceExhausted, "rate limit exceeded").
+				WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(0)})
+			Expect(err).NotTo(HaveOccurred())
+			collector.fail(throttled.Err())
+
+			cfg.OTLPConfig.Endpoint = collector.addr
+			cfg.OTLPConfig.ThrottleEnabled = true
+			cfg.OTLPConfig.ThrottleRequestsPerSec = 1000
+			cfg.OTLPConfig.ThrottleBytesPerSec = 1 << 20
+			cfg.OTLPConfig.ThrottleAdaptive = true
+			cfg.OTLPConfig.ThrottleAdaptiveMinPercent = 10
+			cfg.OTLPConfig.ThrottleAdaptiveIncreasePercent = 50
+			cfg.OTLPConfig.ThrottleAdaptiveInterval = 50 * time.Millisecond
+			cfg.OTLPConfig.UseSDKBatchProcessor = true
+			cfg.OTLPConfig.SDKBatchMaxQueueSize = config.DefaultOTLPConfig.SDKBatchMaxQueueSize
+			cfg.OTLPConfig.SDKBatchExportTimeout = config.DefaultOTLPConfig.SDKBatchExportTimeout
+			cfg.OTLPConfig.SDKBatchExportInterval = 20 * time.Millisecond
+			cfg.OTLPConfig.SDKBatchExportMaxBatchSize = config.DefaultOTLPConfig.SDKBatchExportMaxBatchSize
+
+			client, err := otlpgrpc.New(context.Background(), cfg, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+			defer client.Stop()
+
+			limit := func(unit string) func() float64 {
+				return func() float64 {
+					_ = client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": "throttled"}})
+
+					return testutil.ToFloat64(testMetrics.ThrottleLimit.WithLabelValues(collector.addr, unit))
+				}
+			}
+			Eventually(limit("records")).WithTimeout(5 * time.Second).WithPolling(20 * time.Millisecond).Should(BeNumerically("<", 1000))
+			Expect(limit("bytes")()).To(BeNumerically("<", 1<<20))
+
+			collector.fail(nil)
+			Eventually(limit("records")).WithTimeout(5 * time.Second).WithPolling(20 * time.Millisecond).Should(Equal(1000.0))
+			Eventually(limit("bytes")).WithTimeout(5 * time.Second).WithPolling(20 * time.Millisecond).Should(Equal(float64(1 << 20)))
+		})
+	})
+
 	Describe("Stop and StopWait", func() {
 		It("should stop the client immediately", func() {
 			client, err := otlpgrpc.New(context.Background(), cfg, logger, testMetrics, nil)
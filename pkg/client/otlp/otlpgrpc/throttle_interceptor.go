@@ -0,0 +1,48 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpgrpc
+
+import (
+	"context"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+)
+
+// throttleInterceptor lowers the limits of an adaptive limiter when the backend throttles the exports.
+// Throttled exports fail with RESOURCE_EXHAUSTED, or UNAVAILABLE with RetryInfo, the delay of RetryInfo is passed on.
+func throttleInterceptor(limiter *otlp.Limiter) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			return nil
+		}
+
+		st, ok := status.FromError(err)
+		if !ok {
+			return err
+		}
+		retryInfo := retryInfoOf(st)
+		if st.Code() == codes.ResourceExhausted || (st.Code() == codes.Unavailable && retryInfo != nil) {
+			limiter.Throttled(retryInfo.GetRetryDelay().AsDuration())
+		}
+
+		return err
+	}
+}
+
+// retryInfoOf returns the RetryInfo details of the status, nil if there are none
+func retryInfoOf(st *status.Status) *errdetails.RetryInfo {
+	for _, detail := range st.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			return retryInfo
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttp
+
+import (
+	"net/http"
+
+	"github.com/gardener/logging/v1/pkg/client/auth"
+)
+
+// authTransport sets the Authorization header of the authenticator on each request
+type authTransport struct {
+	base          http.RoundTripper
+	authenticator auth.Authenticator
+}
+
+var _ http.RoundTripper = &authTransport{}
+
+// RoundTrip sends a copy of the request with the authorization, the request fails if the authenticator fails
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	authorization, err := t.authenticator.Authorization(req.Context())
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+
+		return nil, err
+	}
+
+	out := req.Clone(req.Context())
+	out.Header.Set("Authorization", authorization)
+
+	return t.base.RoundTrip(out)
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttp
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+// zstdEncoder is shared by the transports, EncodeAll is safe for concurrent use
+var zstdEncoder = sync.OnceValues(func() (*zstd.Encoder, error) {
+	return zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+})
+
+// compressionTransport compresses the request bodies with zstd or snappy, which the SDK exporter does not support.
+// Snappy uses the block format, as the OpenTelemetry collector expects for the snappy Content-Encoding.
+type compressionTransport struct {
+	base        http.RoundTripper
+	compression string
+}
+
+var _ http.RoundTripper = &compressionTransport{}
+
+// RoundTrip sends the request with the compressed body
+func (t *compressionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || req.Header.Get("Content-Encoding") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := t.compress(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress request with %s: %w", t.compression, err)
+	}
+
+	out := withBody(req, body)
+	out.Header.Set("Content-Encoding", t.compression)
+
+	return t.base.RoundTrip(out)
+}
+
+func (t *compressionTransport) compress(req *http.Request) ([]byte, error) {
+	defer func() { _ = req.Body.Close() }()
+
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.compression {
+	case config.CompressionZstd:
+		encoder, err := zstdEncoder()
+		if err != nil {
+			return nil, err
+		}
+
+		return encoder.EncodeAll(data, nil), nil
+	case config.CompressionSnappy:
+		return snappy.Encode(nil, data), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", t.compression)
+	}
+}
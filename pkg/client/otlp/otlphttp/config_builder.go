@@ -4,14 +4,20 @@
 package otlphttp
 
 import (
+	"net/http"
+
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
 
+	"github.com/gardener/logging/v1/pkg/client/auth"
+	"github.com/gardener/logging/v1/pkg/client/otlp"
 	"github.com/gardener/logging/v1/pkg/config"
 )
 
 // ConfigBuilder builds OTLP HTTP exporter options from configuration
 type ConfigBuilder struct {
-	cfg config.Config
+	cfg           config.Config
+	authenticator auth.Authenticator
+	limiter       *otlp.Limiter
 }
 
 // NewConfigBuilder creates a new OTLP HTTP configuration builder
@@ -19,6 +25,20 @@ func NewConfigBuilder(cfg config.Config) *ConfigBuilder {
 	return &ConfigBuilder{cfg: cfg}
 }
 
+// WithAuthenticator sets the authenticator of the requests, it is applied by the HTTP client
+func (b *ConfigBuilder) WithAuthenticator(authenticator auth.Authenticator) *ConfigBuilder {
+	b.authenticator = authenticator
+
+	return b
+}
+
+// WithLimiter sets the limiter of the client, an adaptive limiter is told about throttled exports by the HTTP client
+func (b *ConfigBuilder) WithLimiter(limiter *otlp.Limiter) *ConfigBuilder {
+	b.limiter = limiter
+
+	return b
+}
+
 // Build constructs the exporter options
 func (b *ConfigBuilder) Build() []otlploghttp.Option {
 	opts := []otlploghttp.Option{}
@@ -29,6 +49,7 @@ func (b *ConfigBuilder) Build() []otlploghttp.Option {
 	b.configureTimeout(&opts)
 	b.configureCompression(&opts)
 	b.configureRetry(&opts)
+	b.configureHTTPClient(&opts)
 
 	return opts
 }
@@ -53,8 +74,9 @@ func (b *ConfigBuilder) configureTimeout(opts *[]otlploghttp.Option) {
 	}
 }
 
+// configureCompression enables the gzip compression of the exporter, zstd and snappy are applied by the HTTP client
 func (b *ConfigBuilder) configureCompression(opts *[]otlploghttp.Option) {
-	if b.cfg.OTLPConfig.Compression > 0 {
+	if b.cfg.OTLPConfig.Compression == config.CompressionGzip {
 		*opts = append(*opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
 	}
 }
@@ -70,6 +92,42 @@ func (b *ConfigBuilder) configureRetry(opts *[]otlploghttp.Option) {
 	}
 }
 
+// configureHTTPClient sets the HTTP client, which records the response status for the endpointExporter and applies
+// the json encoding, zstd or snappy compression, the HTTP proxy, header files, the authenticator and the limiter.
+// The HTTP client takes precedence over the TLS, timeout and proxy options of the exporter, so it is configured with them.
+func (b *ConfigBuilder) configureHTTPClient(opts *[]otlploghttp.Option) {
+	otlpCfg := b.cfg.OTLPConfig
+	compress := otlpCfg.Compression == config.CompressionZstd || otlpCfg.Compression == config.CompressionSnappy
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !otlpCfg.Insecure || otlpCfg.EndpointURL != "" {
+		transport.TLSClientConfig = otlpCfg.TLSConfig
+	}
+	if otlpCfg.HTTPProxyURL != nil {
+		otlp.ConfigureProxy(transport, otlpCfg)
+	}
+
+	var roundTripper http.RoundTripper = &statusTransport{base: transport}
+	roundTripper = otlp.NewThrottleTransport(roundTripper, b.limiter)
+	if compress {
+		roundTripper = &compressionTransport{base: roundTripper, compression: otlpCfg.Compression}
+	}
+	if otlpCfg.Encoding == config.OTLPEncodingJSON {
+		roundTripper = &jsonTransport{base: roundTripper}
+	}
+	if otlpCfg.HeaderFiles != nil {
+		roundTripper = &headerTransport{base: roundTripper, headers: otlpCfg.HeaderFiles}
+	}
+	if b.authenticator != nil {
+		roundTripper = &authTransport{base: roundTripper, authenticator: b.authenticator}
+	}
+
+	*opts = append(*opts, otlploghttp.WithHTTPClient(&http.Client{
+		Transport: roundTripper,
+		Timeout:   otlpCfg.Timeout,
+	}))
+}
+
 func (b *ConfigBuilder) configureEndpoint(opts *[]otlploghttp.Option) {
 	// TODO: check the correct order of precedence for EndpointURL vs Endpoint
 	*opts = append(*opts, otlploghttp.WithURLPath(b.cfg.OTLPConfig.EndpointURLPath))
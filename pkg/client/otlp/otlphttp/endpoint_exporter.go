@@ -0,0 +1,66 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/client/retry"
+)
+
+// responseStatusKey is the context key of the status of the last response of an export
+type responseStatusKey struct{}
+
+// statusTransport records the status of the response in the request context of an endpointExporter
+type statusTransport struct {
+	base http.RoundTripper
+}
+
+var _ http.RoundTripper = &statusTransport{}
+
+// RoundTrip sends the request and records the status of the response
+func (t *statusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if status, ok := req.Context().Value(responseStatusKey{}).(*atomic.Int32); ok && resp != nil {
+		status.Store(int32(resp.StatusCode)) // #nosec G115 -- HTTP status codes have three digits
+	}
+
+	return resp, err
+}
+
+// endpointExporter is the exporter of an endpoint. It marks the errors of transient failures as retryable,
+// so that the failover exporter tries the next endpoint, and the errors of requests rejected with 413 with
+// otlp.ErrBatchTooLarge, so that the batch processor splits the batch. Other errors are returned as they are.
+// The HTTP client of the exporter records the response status with a statusTransport.
+type endpointExporter struct {
+	sdklog.Exporter
+}
+
+// Export exports the records and marks the errors of transient failures and too large batches
+func (e *endpointExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	var status atomic.Int32
+	err := e.Exporter.Export(context.WithValue(ctx, responseStatusKey{}, &status), records)
+	switch {
+	case err == nil:
+		return nil
+	case status.Load() == http.StatusRequestEntityTooLarge:
+		return fmt.Errorf("%w: %w", otlp.ErrBatchTooLarge, err)
+	case transientStatus(int(status.Load())):
+		return retry.Retryable(err, 0)
+	default:
+		return err
+	}
+}
+
+// transientStatus reports whether the export failed in the transport, without response status,
+// or the collector answered with rate limiting or a server error
+func transientStatus(status int) bool {
+	return status == 0 || status == http.StatusTooManyRequests || status/100 == 5
+}
@@ -0,0 +1,29 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttp
+
+import (
+	"net/http"
+
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+// headerTransport sets the headers read from files on each request,
+// so that rotated files, e.g. projected service account tokens, are used by the following exports.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers *config.HeaderFiles
+}
+
+var _ http.RoundTripper = &headerTransport{}
+
+// RoundTrip sends a copy of the request with the current values of the headers
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	for name, value := range t.headers.Values() {
+		out.Header.Set(name, value)
+	}
+
+	return t.base.RoundTrip(out)
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+)
+
+const (
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeJSON     = "application/json"
+)
+
+// jsonTransport re-encodes the protobuf export requests of the SDK exporter as OTLP/JSON,
+// since the exporter only supports the protobuf encoding. Gzip compressed requests are compressed again.
+// Responses are passed as is, the exporter ignores response bodies which are not protobuf.
+type jsonTransport struct {
+	base http.RoundTripper
+}
+
+var _ http.RoundTripper = &jsonTransport{}
+
+// RoundTrip sends the request with the body encoded as OTLP/JSON
+func (t *jsonTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || req.Header.Get("Content-Type") != contentTypeProtobuf {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := t.transcode(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request as OTLP/JSON: %w", err)
+	}
+
+	out := withBody(req, body)
+	out.Header.Set("Content-Type", contentTypeJSON)
+
+	return t.base.RoundTrip(out)
+}
+
+// withBody returns a copy of the request with the body.
+// A RoundTripper must not modify the request, the exporter resets the body of the original request for retries.
+func withBody(req *http.Request, body []byte) *http.Request {
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+	out.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return out
+}
+
+// transcode reads the protobuf body of the request and returns it as OTLP/JSON with the same content encoding
+func (*jsonTransport) transcode(req *http.Request) ([]byte, error) {
+	defer func() { _ = req.Body.Close() }()
+
+	gzipped := req.Header.Get("Content-Encoding") == "gzip"
+	var reader io.Reader = req.Body
+	if gzipped {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		reader = gz
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = otlp.TranscodeLogsProtoToJSON(data)
+	if err != nil || !gzipped {
+		return data, err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
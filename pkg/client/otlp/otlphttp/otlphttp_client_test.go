@@ -4,14 +4,43 @@
 package otlphttp_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/gardener/logging/v1/pkg/client/api"
+	"github.com/gardener/logging/v1/pkg/client/otlp"
 	"github.com/gardener/logging/v1/pkg/client/otlp/otlphttp"
 	"github.com/gardener/logging/v1/pkg/config"
 	"github.com/gardener/logging/v1/pkg/metrics"
@@ -33,7 +62,7 @@ var _ = Describe("OTLPHTTPClient", func() {
 			OTLPConfig: config.OTLPConfig{
 				Endpoint:    "localhost:4318",
 				Insecure:    true,
-				Compression: 0,
+				Compression: config.CompressionNone,
 				Timeout:     30 * time.Second,
 				Headers:     make(map[string]string),
 				DQueConfig: config.DQueConfig{
@@ -98,7 +127,7 @@ var _ = Describe("OTLPHTTPClient", func() {
 		})
 
 		It("should handle compression configuration", func() {
-			cfg.OTLPConfig.Compression = 1 // gzip
+			cfg.OTLPConfig.Compression = config.CompressionGzip
 
 			client, err := otlphttp.New(context.Background(), cfg, logger, testMetrics, nil)
 			Expect(err).ToNot(HaveOccurred())
@@ -391,6 +420,805 @@ var _ = Describe("OTLPHTTPClient", func() {
 		})
 	})
 
+	Describe("Encoding", func() {
+		type request struct {
+			contentType     string
+			contentEncoding string
+			body            []byte
+		}
+
+		var (
+			server   *httptest.Server
+			mu       sync.Mutex
+			requests []request
+		)
+
+		BeforeEach(func() {
+			requests = nil
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				var reader io.Reader = r.Body
+				if r.Header.Get("Content-Encoding") == "gzip" {
+					gz, err := gzip.NewReader(r.Body)
+					Expect(err).ToNot(HaveOccurred())
+					reader = gz
+				}
+				body, err := io.ReadAll(reader)
+				Expect(err).ToNot(HaveOccurred())
+
+				mu.Lock()
+				requests = append(requests, request{
+					contentType:     r.Header.Get("Content-Type"),
+					contentEncoding: r.Header.Get("Content-Encoding"),
+					body:            body,
+				})
+				mu.Unlock()
+
+				w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+				_, _ = w.Write([]byte("{}"))
+			}))
+			DeferCleanup(server.Close)
+
+			cfg.OTLPConfig.EndpointURL = server.URL + "/v1/logs"
+			cfg.OTLPConfig.UseSDKBatchProcessor = true
+			cfg.OTLPConfig.SDKBatchMaxQueueSize = config.DefaultOTLPConfig.SDKBatchMaxQueueSize
+			cfg.OTLPConfig.SDKBatchExportTimeout = config.DefaultOTLPConfig.SDKBatchExportTimeout
+			cfg.OTLPConfig.SDKBatchExportInterval = config.DefaultOTLPConfig.SDKBatchExportInterval
+			cfg.OTLPConfig.SDKBatchExportMaxBatchSize = config.DefaultOTLPConfig.SDKBatchExportMaxBatchSize
+		})
+
+		received := func() []request {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return append([]request(nil), requests...)
+		}
+
+		send := func() {
+			client, err := otlphttp.New(context.Background(), cfg, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(client.Handle(types.OutputEntry{
+				Timestamp: time.Unix(0, 1700000000123456789),
+				Record: map[string]any{
+					"log":   "json encoded",
+					"level": "error",
+					"kubernetes": map[string]any{
+						"namespace_name": "garden",
+						"pod_name":       "etcd-0",
+					},
+				},
+			})).To(Succeed())
+			client.StopWait()
+		}
+
+		// decode returns the log records of the OTLP/JSON request
+		decode := func(body []byte) []any {
+			var decoded map[string]any
+			Expect(json.Unmarshal(body, &decoded)).To(Succeed())
+			Expect(decoded).To(HaveKeyWithValue("resourceLogs", HaveLen(1)))
+			resourceLogs := decoded["resourceLogs"].([]any)[0].(map[string]any)
+			Expect(resourceLogs).To(HaveKeyWithValue("scopeLogs", HaveLen(1)))
+			scopeLogs := resourceLogs["scopeLogs"].([]any)[0].(map[string]any)
+			Expect(scopeLogs).To(HaveKeyWithValue("scope", HaveKeyWithValue("name", "fluent-bit-output-plugin")))
+
+			return scopeLogs["logRecords"].([]any)
+		}
+
+		It("should send protobuf requests by default", func() {
+			send()
+
+			Expect(received()).To(HaveLen(1))
+			Expect(received()[0].contentType).To(Equal("application/x-protobuf"))
+		})
+
+		It("should send OTLP/JSON requests", func() {
+			cfg.OTLPConfig.Encoding = config.OTLPEncodingJSON
+
+			send()
+
+			Expect(received()).To(HaveLen(1))
+			Expect(received()[0].contentType).To(Equal("application/json"))
+			records := decode(received()[0].body)
+			Expect(records).To(HaveLen(1))
+			record := records[0].(map[string]any)
+			Expect(record).To(HaveKeyWithValue("timeUnixNano", "1700000000123456789"))
+			Expect(record).To(HaveKeyWithValue("severityNumber", float64(17)))
+			Expect(record).To(HaveKeyWithValue("body", map[string]any{"stringValue": "json encoded"}))
+			Expect(record["attributes"]).To(ContainElement(map[string]any{
+				"key":   "k8s.namespace.name",
+				"value": map[string]any{"stringValue": "garden"},
+			}))
+		})
+
+		It("should send gzip compressed OTLP/JSON requests", func() {
+			cfg.OTLPConfig.Encoding = config.OTLPEncodingJSON
+			cfg.OTLPConfig.Compression = config.CompressionGzip
+
+			send()
+
+			Expect(received()).To(HaveLen(1))
+			Expect(received()[0].contentType).To(Equal("application/json"))
+			Expect(received()[0].contentEncoding).To(Equal("gzip"))
+			Expect(decode(received()[0].body)).To(HaveLen(1))
+		})
+	})
+
+	Describe("Compression", func() {
+		type request struct {
+			contentEncoding string
+			wireBytes       int
+			records         int
+		}
+
+		var (
+			server   *httptest.Server
+			mu       sync.Mutex
+			requests []request
+		)
+
+		BeforeEach(func() {
+			requests = nil
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				body, err := io.ReadAll(r.Body)
+				Expect(err).ToNot(HaveOccurred())
+				data := body
+				switch r.Header.Get("Content-Encoding") {
+				case "gzip":
+					gz, err := gzip.NewReader(bytes.NewReader(body))
+					Expect(err).ToNot(HaveOccurred())
+					data, err = io.ReadAll(gz)
+					Expect(err).ToNot(HaveOccurred())
+				case "zstd":
+					decoder, err := zstd.NewReader(nil)
+					Expect(err).ToNot(HaveOccurred())
+					data, err = decoder.DecodeAll(body, nil)
+					Expect(err).ToNot(HaveOccurred())
+					decoder.Close()
+				case "snappy":
+					data, err = snappy.Decode(nil, body)
+					Expect(err).ToNot(HaveOccurred())
+				}
+
+				records := 0
+				if r.Header.Get("Content-Type") == "application/json" {
+					var export struct {
+						ResourceLogs []struct {
+							ScopeLogs []struct {
+								LogRecords []json.RawMessage `json:"logRecords"`
+							} `json:"scopeLogs"`
+						} `json:"resourceLogs"`
+					}
+					Expect(json.Unmarshal(data, &export)).To(Succeed())
+					for _, resourceLogs := range export.ResourceLogs {
+						for _, scopeLogs := range resourceLogs.ScopeLogs {
+							records += len(scopeLogs.LogRecords)
+						}
+					}
+				} else {
+					var export collogspb.ExportLogsServiceRequest
+					Expect(proto.Unmarshal(data, &export)).To(Succeed())
+					for _, resourceLogs := range export.ResourceLogs {
+						for _, scopeLogs := range resourceLogs.ScopeLogs {
+							records += len(scopeLogs.LogRecords)
+						}
+					}
+				}
+
+				mu.Lock()
+				requests = append(requests, request{
+					contentEncoding: r.Header.Get("Content-Encoding"),
+					wireBytes:       len(body),
+					records:         records,
+				})
+				mu.Unlock()
+				w.WriteHeader(http.StatusOK)
+			}))
+			DeferCleanup(server.Close)
+
+			cfg.OTLPConfig.EndpointURL = server.URL + "/v1/logs"
+			cfg.OTLPConfig.UseSDKBatchProcessor = true
+			cfg.OTLPConfig.SDKBatchMaxQueueSize = config.DefaultOTLPConfig.SDKBatchMaxQueueSize
+			cfg.OTLPConfig.SDKBatchExportTimeout = config.DefaultOTLPConfig.SDKBatchExportTimeout
+			cfg.OTLPConfig.SDKBatchExportInterval = config.DefaultOTLPConfig.SDKBatchExportInterval
+			cfg.OTLPConfig.SDKBatchExportMaxBatchSize = config.DefaultOTLPConfig.SDKBatchExportMaxBatchSize
+		})
+
+		// send sends a batch of typical container logs with the compression and returns the received request
+		send := func(compression string) request {
+			mu.Lock()
+			requests = nil
+			mu.Unlock()
+
+			c := cfg
+			c.OTLPConfig.Compression = compression
+			client, err := otlphttp.New(context.Background(), c, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+			for i := range 200 {
+				Expect(client.Handle(types.OutputEntry{
+					Timestamp: time.Unix(1700000000, int64(i)*1000),
+					Record: map[string]any{
+						"log":   fmt.Sprintf("I1116 10:13:%02d.%06d reconciler.go:93] Reconciling object default/etcd-%d", i%60, i*137, i%3),
+						"level": "info",
+						"kubernetes": map[string]any{
+							"namespace_name": "shoot--dev--cluster",
+							"pod_name":       fmt.Sprintf("etcd-main-%d", i%3),
+							"container_name": "etcd",
+						},
+					},
+				})).To(Succeed())
+			}
+			client.StopWait()
+
+			mu.Lock()
+			defer mu.Unlock()
+			Expect(requests).To(HaveLen(1))
+
+			return requests[0]
+		}
+
+		DescribeTable("should compress the requests and reduce the bandwidth",
+			func(compression string, minSaving float64) {
+				uncompressed := send(config.CompressionNone)
+				Expect(uncompressed.contentEncoding).To(BeEmpty())
+
+				compressed := send(compression)
+				Expect(compressed.contentEncoding).To(Equal(compression))
+				Expect(compressed.records).To(Equal(200))
+
+				saving := 1 - float64(compressed.wireBytes)/float64(uncompressed.wireBytes)
+				AddReportEntry(compression+" bandwidth", fmt.Sprintf("%d of %d bytes, %.1f%% saved",
+					compressed.wireBytes, uncompressed.wireBytes, 100*saving))
+				Expect(saving).To(BeNumerically(">=", minSaving))
+			},
+			Entry("gzip", config.CompressionGzip, 0.8),
+			Entry("zstd", config.CompressionZstd, 0.8),
+			Entry("snappy", config.CompressionSnappy, 0.6),
+		)
+
+		It("should compress OTLP/JSON requests with zstd", func() {
+			cfg.OTLPConfig.Encoding = config.OTLPEncodingJSON
+
+			compressed := send(config.CompressionZstd)
+			Expect(compressed.contentEncoding).To(Equal("zstd"))
+			Expect(compressed.records).To(Equal(200))
+		})
+	})
+
+	Describe("HTTP proxy", func() {
+		type proxyRequest struct {
+			method        string
+			requestURI    string
+			authorization string
+		}
+
+		var (
+			collector *httptest.Server
+			mu        sync.Mutex
+			requests  []proxyRequest
+			records   int
+		)
+
+		// newProxy starts a forward proxy, answering plain HTTP requests itself and tunneling CONNECT requests to the collector
+		newProxy := func(secure bool) *httptest.Server {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				mu.Lock()
+				requests = append(requests, proxyRequest{
+					method:        r.Method,
+					requestURI:    r.RequestURI,
+					authorization: r.Header.Get("Proxy-Authorization"),
+				})
+				mu.Unlock()
+
+				if r.Method != http.MethodConnect {
+					_, _ = io.Copy(io.Discard, r.Body)
+					w.WriteHeader(http.StatusOK)
+
+					return
+				}
+
+				upstream, err := net.Dial("tcp", collector.Listener.Addr().String())
+				Expect(err).ToNot(HaveOccurred())
+				conn, rw, err := http.NewResponseController(w).Hijack()
+				Expect(err).ToNot(HaveOccurred())
+				_, _ = rw.WriteString("HTTP/1.1 200 Connection established\r\n\r\n")
+				_ = rw.Flush()
+
+				go func() {
+					_, _ = io.Copy(upstream, rw)
+					_ = upstream.Close()
+				}()
+				_, _ = io.Copy(conn, upstream)
+				_ = conn.Close()
+			})
+
+			var proxy *httptest.Server
+			if secure {
+				proxy = httptest.NewTLSServer(handler)
+			} else {
+				proxy = httptest.NewServer(handler)
+			}
+			DeferCleanup(proxy.Close)
+
+			return proxy
+		}
+
+		BeforeEach(func() {
+			requests = nil
+			records = 0
+			collector = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(io.Discard, r.Body)
+				mu.Lock()
+				records++
+				mu.Unlock()
+				w.WriteHeader(http.StatusOK)
+			}))
+			DeferCleanup(collector.Close)
+
+			cfg.OTLPConfig.UseSDKBatchProcessor = true
+			cfg.OTLPConfig.SDKBatchMaxQueueSize = config.DefaultOTLPConfig.SDKBatchMaxQueueSize
+			cfg.OTLPConfig.SDKBatchExportTimeout = config.DefaultOTLPConfig.SDKBatchExportTimeout
+			cfg.OTLPConfig.SDKBatchExportInterval = config.DefaultOTLPConfig.SDKBatchExportInterval
+			cfg.OTLPConfig.SDKBatchExportMaxBatchSize = config.DefaultOTLPConfig.SDKBatchExportMaxBatchSize
+		})
+
+		received := func() []proxyRequest {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return append([]proxyRequest(nil), requests...)
+		}
+
+		send := func() {
+			client, err := otlphttp.New(context.Background(), cfg, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(client.Handle(types.OutputEntry{
+				Timestamp: time.Now(),
+				Record:    map[string]any{"log": "proxied"},
+			})).To(Succeed())
+			client.StopWait()
+		}
+
+		proxyURL := func(proxy *httptest.Server) *url.URL {
+			u, err := url.Parse(proxy.URL)
+			Expect(err).ToNot(HaveOccurred())
+			u.User = url.UserPassword("user", "secret")
+
+			return u
+		}
+
+		basicAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:secret"))
+
+		It("should send plain HTTP requests with proxy authorization to the proxy", func() {
+			proxy := newProxy(false)
+			cfg.OTLPConfig.EndpointURL = "http://collector.example/v1/logs"
+			cfg.OTLPConfig.HTTPProxyURL = proxyURL(proxy)
+
+			send()
+
+			Expect(received()).To(ConsistOf(proxyRequest{
+				method:        http.MethodPost,
+				requestURI:    "http://collector.example/v1/logs",
+				authorization: basicAuth,
+			}))
+		})
+
+		It("should tunnel HTTPS requests through a TLS proxy verified with the proxy TLS configuration", func() {
+			proxy := newProxy(true)
+			proxyCAs := x509.NewCertPool()
+			proxyCAs.AddCert(proxy.Certificate())
+			collectorCAs := x509.NewCertPool()
+			collectorCAs.AddCert(collector.Certificate())
+
+			// The httptest certificates are valid for example.com
+			cfg.OTLPConfig.EndpointURL = "https://example.com/v1/logs"
+			cfg.OTLPConfig.TLSConfig = &tls.Config{RootCAs: collectorCAs, MinVersion: tls.VersionTLS12}
+			cfg.OTLPConfig.HTTPProxyURL = proxyURL(proxy)
+			cfg.OTLPConfig.HTTPProxyTLSConfig = &tls.Config{RootCAs: proxyCAs, ServerName: "127.0.0.1", MinVersion: tls.VersionTLS12}
+
+			send()
+
+			Expect(received()).To(ConsistOf(proxyRequest{
+				method:        http.MethodConnect,
+				requestURI:    "example.com:443",
+				authorization: basicAuth,
+			}))
+			mu.Lock()
+			defer mu.Unlock()
+			Expect(records).To(Equal(1))
+		})
+
+		It("should send JSON encoded requests through the proxy", func() {
+			proxy := newProxy(false)
+			cfg.OTLPConfig.EndpointURL = "http://collector.example/v1/logs"
+			cfg.OTLPConfig.Encoding = config.OTLPEncodingJSON
+			cfg.OTLPConfig.HTTPProxyURL = proxyURL(proxy)
+
+			send()
+
+			Expect(received()).To(HaveLen(1))
+			Expect(received()[0].requestURI).To(Equal("http://collector.example/v1/logs"))
+		})
+	})
+
+	Describe("Credential reload", func() {
+		type request struct {
+			authorization string
+			clientCert    string
+		}
+
+		var (
+			server   *httptest.Server
+			dir      string
+			mu       sync.Mutex
+			requests []request
+		)
+
+		BeforeEach(func() {
+			requests = nil
+			dir = GinkgoT().TempDir()
+			server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(io.Discard, r.Body)
+				mu.Lock()
+				requests = append(requests, request{
+					authorization: r.Header.Get("Authorization"),
+					clientCert:    r.TLS.PeerCertificates[0].Subject.CommonName,
+				})
+				mu.Unlock()
+				// New connections are established for each export, so that the client certificate is sent again
+				w.Header().Set("Connection", "close")
+				w.WriteHeader(http.StatusOK)
+			}))
+			server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+			server.StartTLS()
+			DeferCleanup(server.Close)
+		})
+
+		// write replaces the file and moves its modification time forward, so that the change is detected
+		write := func(name string, data []byte) string {
+			path := filepath.Join(dir, name)
+			info, statErr := os.Stat(path)
+			Expect(os.WriteFile(path, data, 0o600)).To(Succeed())
+			if statErr == nil {
+				modTime := info.ModTime().Add(time.Second)
+				Expect(os.Chtimes(path, modTime, modTime)).To(Succeed())
+			}
+
+			return path
+		}
+
+		writeClientCertificate := func(commonName string) {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			template := &x509.Certificate{
+				SerialNumber: big.NewInt(time.Now().UnixNano()),
+				Subject:      pkix.Name{CommonName: commonName},
+				NotBefore:    time.Now().Add(-time.Hour),
+				NotAfter:     time.Now().Add(time.Hour),
+				KeyUsage:     x509.KeyUsageDigitalSignature,
+				ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			}
+			der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+			Expect(err).ToNot(HaveOccurred())
+			keyDER, err := x509.MarshalECPrivateKey(key)
+			Expect(err).ToNot(HaveOccurred())
+
+			write("tls.crt", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+			write("tls.key", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+		}
+
+		It("should use rotated client certificates and header files without recreating the client", func() {
+			writeClientCertificate("first")
+			token := write("token", []byte("first-token"))
+			parsed, err := config.ParseConfig(map[string]any{
+				"TLSCertFile":   filepath.Join(dir, "tls.crt"),
+				"TLSKeyFile":    filepath.Join(dir, "tls.key"),
+				"TLSCAFile":     write("ca.crt", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})),
+				"TLSServerName": "example.com",
+				"HeaderFiles":   `{"Authorization": {"file": "` + token + `", "prefix": "Bearer "}}`,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			cfg.OTLPConfig.Insecure = false
+			cfg.OTLPConfig.EndpointURL = server.URL + "/v1/logs"
+			cfg.OTLPConfig.TLSConfig = parsed.OTLPConfig.TLSConfig
+			cfg.OTLPConfig.HeaderFiles = parsed.OTLPConfig.HeaderFiles
+			cfg.OTLPConfig.UseSDKBatchProcessor = true
+			cfg.OTLPConfig.SDKBatchMaxQueueSize = config.DefaultOTLPConfig.SDKBatchMaxQueueSize
+			cfg.OTLPConfig.SDKBatchExportTimeout = config.DefaultOTLPConfig.SDKBatchExportTimeout
+			cfg.OTLPConfig.SDKBatchExportInterval = 20 * time.Millisecond
+			cfg.OTLPConfig.SDKBatchExportMaxBatchSize = config.DefaultOTLPConfig.SDKBatchExportMaxBatchSize
+
+			client, err := otlphttp.New(context.Background(), cfg, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+			defer client.StopWait()
+
+			received := func() []request {
+				mu.Lock()
+				defer mu.Unlock()
+
+				return append([]request(nil), requests...)
+			}
+
+			Expect(client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": "first"}})).To(Succeed())
+			Eventually(received).Should(ConsistOf(request{authorization: "Bearer first-token", clientCert: "first"}))
+
+			writeClientCertificate("second")
+			write("token", []byte("second-token"))
+			Expect(client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": "second"}})).To(Succeed())
+			Eventually(received).Should(ContainElement(request{authorization: "Bearer second-token", clientCert: "second"}))
+		})
+	})
+
+	Describe("Authentication", func() {
+		It("should send bearer tokens of the OAuth2 client credentials flow", func() {
+			var tokenRequests atomic.Int32
+			tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tokenRequests.Add(1)
+				clientID, clientSecret, _ := r.BasicAuth()
+				if clientID != "fluent-bit" || clientSecret != "secret" || r.FormValue("grant_type") != "client_credentials" {
+					w.WriteHeader(http.StatusUnauthorized)
+
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"access_token": "oauth2-token", "token_type": "Bearer", "expires_in": 3600}`))
+			}))
+			DeferCleanup(tokenServer.Close)
+
+			var (
+				mu             sync.Mutex
+				authorizations []string
+			)
+			collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(io.Discard, r.Body)
+				mu.Lock()
+				authorizations = append(authorizations, r.Header.Get("Authorization"))
+				mu.Unlock()
+				w.WriteHeader(http.StatusOK)
+			}))
+			DeferCleanup(collector.Close)
+
+			secretFile := filepath.Join(GinkgoT().TempDir(), "client-secret")
+			Expect(os.WriteFile(secretFile, []byte("secret"), 0o600)).To(Succeed())
+			cfg.OTLPConfig.EndpointURL = collector.URL + "/v1/logs"
+			cfg.OTLPConfig.Authenticator = config.AuthenticatorOAuth2
+			cfg.OTLPConfig.OAuth2TokenURL = tokenServer.URL
+			cfg.OTLPConfig.OAuth2ClientID = "fluent-bit"
+			cfg.OTLPConfig.OAuth2ClientSecretFile = secretFile
+			cfg.OTLPConfig.UseSDKBatchProcessor = true
+			cfg.OTLPConfig.SDKBatchMaxQueueSize = config.DefaultOTLPConfig.SDKBatchMaxQueueSize
+			cfg.OTLPConfig.SDKBatchExportTimeout = config.DefaultOTLPConfig.SDKBatchExportTimeout
+			cfg.OTLPConfig.SDKBatchExportInterval = 20 * time.Millisecond
+			cfg.OTLPConfig.SDKBatchExportMaxBatchSize = config.DefaultOTLPConfig.SDKBatchExportMaxBatchSize
+
+			client, err := otlphttp.New(context.Background(), cfg, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+			defer client.StopWait()
+
+			received := func() []string {
+				mu.Lock()
+				defer mu.Unlock()
+
+				return append([]string(nil), authorizations...)
+			}
+			for i := range 3 {
+				Expect(client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": fmt.Sprintf("log %d", i)}})).To(Succeed())
+				Eventually(received).Should(HaveLen(i + 1))
+			}
+			Expect(received()).To(HaveEach("Bearer oauth2-token"))
+			// The token is cached
+			Expect(tokenRequests.Load()).To(Equal(int32(1)))
+		})
+
+		It("should fail the client creation if the secret file cannot be read", func() {
+			cfg.OTLPConfig.Authenticator = config.AuthenticatorBasic
+			cfg.OTLPConfig.BasicAuthUsername = "fluent-bit"
+			cfg.OTLPConfig.BasicAuthPasswordFile = filepath.Join(GinkgoT().TempDir(), "missing")
+
+			_, err := otlphttp.New(context.Background(), cfg, logger, testMetrics, nil)
+			Expect(err).To(MatchError(ContainSubstring("failed to create authenticator")))
+		})
+	})
+
+	Describe("Throttling", func() {
+		It("should reject records exceeding the byte limit", func() {
+			cfg.OTLPConfig.ThrottleEnabled = true
+			cfg.OTLPConfig.ThrottleBytesPerSec = 100
+
+			client, err := otlphttp.New(context.Background(), cfg, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+			defer client.Stop()
+
+			entry := types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": strings.Repeat("x", 120)}}
+			Expect(client.Handle(entry)).To(Succeed())
+			Expect(client.Handle(entry)).To(MatchError(otlp.ErrThrottled))
+			Expect(testutil.ToFloat64(testMetrics.ThrottledLogs.WithLabelValues("localhost:4318"))).To(Equal(1.0))
+			Expect(testutil.ToFloat64(testMetrics.ThrottleLimit.WithLabelValues("localhost:4318", "bytes"))).To(Equal(100.0))
+		})
+
+		It("should lower the limits when the collector throttles and raise them again when it recovers", func() {
+			var throttle atomic.Bool
+			throttle.Store(true)
+			collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(io.Discard, r.Body)
+				if throttle.Load() {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusTooManyRequests)
+
+					return
+				}
+				w.Header().Set("Content-Type", "application/x-protobuf")
+			}))
+			DeferCleanup(collector.Close)
+
+			cfg.OTLPConfig.EndpointURL = collector.URL + "/v1/logs"
+			cfg.OTLPConfig.ThrottleEnabled = true
+			cfg.OTLPConfig.ThrottleRequestsPerSec = 1000
+			cfg.OTLPConfig.ThrottleAdaptive = true
+			cfg.OTLPConfig.ThrottleAdaptiveMinPercent = 10
+			cfg.OTLPConfig.ThrottleAdaptiveIncreasePercent = 50
+			cfg.OTLPConfig.ThrottleAdaptiveInterval = 50 * time.Millisecond
+			cfg.OTLPConfig.UseSDKBatchProcessor = true
+			cfg.OTLPConfig.SDKBatchMaxQueueSize = config.DefaultOTLPConfig.SDKBatchMaxQueueSize
+			cfg.OTLPConfig.SDKBatchExportTimeout = config.DefaultOTLPConfig.SDKBatchExportTimeout
+			cfg.OTLPConfig.SDKBatchExportInterval = 20 * time.Millisecond
+			cfg.OTLPConfig.SDKBatchExportMaxBatchSize = config.DefaultOTLPConfig.SDKBatchExportMaxBatchSize
+
+			client, err := otlphttp.New(context.Background(), cfg, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+			defer client.Stop()
+
+			limit := func() float64 {
+				_ = client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": "throttled"}})
+
+				return testutil.ToFloat64(testMetrics.ThrottleLimit.WithLabelValues("localhost:4318", "records"))
+			}
+			Eventually(limit).WithTimeout(5 * time.Second).WithPolling(20 * time.Millisecond).Should(BeNumerically("<", 1000))
+
+			throttle.Store(false)
+			Eventually(limit).WithTimeout(5 * time.Second).WithPolling(20 * time.Millisecond).Should(Equal(1000.0))
+		})
+	})
+
+	Describe("Failover", func() {
+		It("should fail over to the next endpoint and fail back once the primary endpoint recovers", func() {
+			var primaryDown atomic.Bool
+			primaryDown.Store(true)
+			var primaryRequests, secondaryRequests atomic.Int32
+			primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				primaryRequests.Add(1)
+				if primaryDown.Load() {
+					w.WriteHeader(http.StatusServiceUnavailable)
+
+					return
+				}
+				w.Header().Set("Content-Type", "application/x-protobuf")
+			}))
+			DeferCleanup(primary.Close)
+			secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				secondaryRequests.Add(1)
+				w.Header().Set("Content-Type", "application/x-protobuf")
+			}))
+			DeferCleanup(secondary.Close)
+
+			cfg.OTLPConfig.EndpointURL = primary.URL + "/v1/logs"
+			cfg.OTLPConfig.FailoverEndpoints = []string{secondary.URL + "/v1/logs"}
+			cfg.OTLPConfig.FailoverFailureThreshold = 1
+			cfg.OTLPConfig.FailoverCooldown = 200 * time.Millisecond
+			cfg.OTLPConfig.UseSDKBatchProcessor = true
+			cfg.OTLPConfig.SDKBatchMaxQueueSize = config.DefaultOTLPConfig.SDKBatchMaxQueueSize
+			cfg.OTLPConfig.SDKBatchExportTimeout = config.DefaultOTLPConfig.SDKBatchExportTimeout
+			cfg.OTLPConfig.SDKBatchExportInterval = 20 * time.Millisecond
+			cfg.OTLPConfig.SDKBatchExportMaxBatchSize = config.DefaultOTLPConfig.SDKBatchExportMaxBatchSize
+
+			client, err := otlphttp.New(context.Background(), cfg, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+			defer client.StopWait()
+			Expect(client.Endpoint()).To(Equal(primary.URL + "/v1/logs"))
+
+			handle := func() {
+				Expect(client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": "failover"}})).To(Succeed())
+			}
+
+			handle()
+			Eventually(secondaryRequests.Load).Should(BeNumerically(">=", 1))
+			Expect(primaryRequests.Load()).To(BeNumerically(">=", 1))
+			Expect(client.Endpoint()).To(Equal(secondary.URL + "/v1/logs"))
+			Expect(testutil.ToFloat64(testMetrics.ActiveEndpoint.WithLabelValues(primary.URL+"/v1/logs", secondary.URL+"/v1/logs"))).To(Equal(1.0))
+
+			primaryDown.Store(false)
+			Eventually(func() string {
+				handle()
+
+				return client.Endpoint()
+			}).WithPolling(50 * time.Millisecond).Should(Equal(primary.URL + "/v1/logs"))
+			Expect(testutil.ToFloat64(testMetrics.ActiveEndpoint.WithLabelValues(primary.URL+"/v1/logs", primary.URL+"/v1/logs"))).To(Equal(1.0))
+		})
+
+		DescribeTable("should fail over on server errors only",
+			func(status int, failover bool) {
+				var primaryRequests, secondaryRequests atomic.Int32
+				primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					primaryRequests.Add(1)
+					w.WriteHeader(status)
+				}))
+				DeferCleanup(primary.Close)
+				secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					secondaryRequests.Add(1)
+					w.Header().Set("Content-Type", "application/x-protobuf")
+				}))
+				DeferCleanup(secondary.Close)
+
+				cfg.OTLPConfig.EndpointURL = primary.URL + "/v1/logs"
+				cfg.OTLPConfig.FailoverEndpoints = []string{secondary.URL + "/v1/logs"}
+				cfg.OTLPConfig.FailoverFailureThreshold = 1
+				cfg.OTLPConfig.FailoverCooldown = time.Minute
+				cfg.OTLPConfig.UseSDKBatchProcessor = true
+				cfg.OTLPConfig.SDKBatchMaxQueueSize = config.DefaultOTLPConfig.SDKBatchMaxQueueSize
+				cfg.OTLPConfig.SDKBatchExportTimeout = config.DefaultOTLPConfig.SDKBatchExportTimeout
+				cfg.OTLPConfig.SDKBatchExportInterval = config.DefaultOTLPConfig.SDKBatchExportInterval
+				cfg.OTLPConfig.SDKBatchExportMaxBatchSize = config.DefaultOTLPConfig.SDKBatchExportMaxBatchSize
+
+				client, err := otlphttp.New(context.Background(), cfg, logger, testMetrics, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": "failover"}})).To(Succeed())
+				client.StopWait()
+
+				Expect(primaryRequests.Load()).To(Equal(int32(1)))
+				if failover {
+					Expect(secondaryRequests.Load()).To(Equal(int32(1)))
+					Expect(client.Endpoint()).To(Equal(secondary.URL + "/v1/logs"))
+				} else {
+					Expect(secondaryRequests.Load()).To(BeZero())
+					Expect(client.Endpoint()).To(Equal(primary.URL + "/v1/logs"))
+				}
+			},
+			Entry("internal server error", http.StatusInternalServerError, true),
+			Entry("rate limited", http.StatusTooManyRequests, true),
+			Entry("bad request", http.StatusBadRequest, false),
+			Entry("unauthorized", http.StatusUnauthorized, false),
+		)
+	})
+
+	Describe("Request size", func() {
+		It("should split batches rejected with 413 and move records rejected on their own to the dead letter file", func() {
+			collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if len(body) > 4000 {
+					w.WriteHeader(http.StatusRequestEntityTooLarge)
+
+					return
+				}
+				w.Header().Set("Content-Type", "application/x-protobuf")
+			}))
+			DeferCleanup(collector.Close)
+
+			cfg.OTLPConfig.EndpointURL = collector.URL + "/v1/logs"
+			cfg.OTLPConfig.DQueBatchProcessorDeadLetterMaxBytes = 1 << 20
+
+			client, err := otlphttp.New(context.Background(), cfg, logger, testMetrics, nil)
+			Expect(err).ToNot(HaveOccurred())
+			defer client.Stop()
+			for _, line := range []string{"first", strings.Repeat("x", 5000), "second"} {
+				Expect(client.Handle(types.OutputEntry{Timestamp: time.Now(), Record: map[string]any{"log": line}})).To(Succeed())
+			}
+
+			Eventually(func() float64 {
+				return testutil.ToFloat64(testMetrics.DroppedLogs.WithLabelValues("localhost:4318", "dead_letter"))
+			}).Should(Equal(1.0))
+			Eventually(func() float64 {
+				return testutil.ToFloat64(testMetrics.ExportedClientLogs.WithLabelValues("localhost:4318"))
+			}).Should(Equal(2.0))
+			Expect(testutil.ToFloat64(testMetrics.DroppedLogs.WithLabelValues("localhost:4318", "export_error"))).To(BeZero())
+		})
+	})
+
 	Describe("Endpoint", func() {
 		It("should return the configured endpoint", func() {
 			client, err := otlphttp.New(context.Background(), cfg, logger, testMetrics, nil)
@@ -0,0 +1,59 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package otlptest provides helpers for testing the exporters of the clients
+package otlptest
+
+import (
+	"context"
+
+	otlplog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+// Records emits the log records and returns them as the batch processors hand them to the exporters
+func Records(records ...otlplog.Record) []sdklog.Record {
+	collector := &recordCollector{}
+	logger := sdklog.NewLoggerProvider(sdklog.WithProcessor(collector)).Logger(otlp.PluginName)
+	for _, record := range records {
+		logger.Emit(context.Background(), record)
+	}
+
+	return collector.records
+}
+
+// EntryRecords builds the records of the entries like the clients and returns them as the batch processors hand them
+// to the exporters
+func EntryRecords(cfg config.Config, entries ...types.OutputEntry) []sdklog.Record {
+	records := make([]otlplog.Record, 0, len(entries))
+	for _, e := range entries {
+		records = append(records, otlp.NewLogRecordBuilder().
+			WithConfig(cfg).
+			WithTimestamp(e.Timestamp).
+			WithSeverity(e.Record).
+			WithBody(e.Record).
+			WithAttributes(e).
+			Build())
+	}
+
+	return Records(records...)
+}
+
+// recordCollector is a processor collecting the emitted records
+type recordCollector struct {
+	records []sdklog.Record
+}
+
+func (c *recordCollector) OnEmit(_ context.Context, r *sdklog.Record) error {
+	c.records = append(c.records, r.Clone())
+
+	return nil
+}
+
+func (*recordCollector) Enabled(context.Context, sdklog.EnabledParameters) bool { return true }
+func (*recordCollector) Shutdown(context.Context) error                         { return nil }
+func (*recordCollector) ForceFlush(context.Context) error                       { return nil }
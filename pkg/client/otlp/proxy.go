@@ -0,0 +1,63 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+// ConfigureProxy sends the requests of the transport through the configured HTTP proxy,
+// except for the hosts matching HTTPNoProxy. The NO_PROXY rules apply, e.g. loopback addresses are never proxied.
+//
+// The transport would use the TLS configuration of the endpoint for https:// proxies,
+// so they are passed to the transport as http:// proxies and the TLS connection is established when dialing the proxy.
+func ConfigureProxy(transport *http.Transport, cfg config.OTLPConfig) {
+	proxyURL := *cfg.HTTPProxyURL
+	if proxyURL.Scheme == "https" {
+		if proxyURL.Port() == "" {
+			proxyURL.Host = net.JoinHostPort(proxyURL.Hostname(), "443")
+		}
+		proxyURL.Scheme = "http"
+		transport.DialContext = dialProxyTLS(proxyURL.Host, cfg.HTTPProxyTLSConfig)
+	}
+
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  proxyURL.String(),
+		HTTPSProxy: proxyURL.String(),
+		NoProxy:    cfg.HTTPNoProxy,
+	}).ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+}
+
+// dialProxyTLS returns a dial function establishing a TLS connection for connections to the proxy address
+func dialProxyTLS(proxyAddr string, tlsConfig *tls.Config) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dialer.DialContext(ctx, network, addr)
+		if err != nil || addr != proxyAddr {
+			return conn, err
+		}
+
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+
+			return nil, err
+		}
+
+		return tlsConn, nil
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp_test
+
+import (
+	"net/http"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+var _ = Describe("ConfigureProxy", func() {
+	proxyFor := func(cfg config.OTLPConfig, target string) *url.URL {
+		transport := &http.Transport{}
+		otlp.ConfigureProxy(transport, cfg)
+
+		req, err := http.NewRequest(http.MethodPost, target, nil)
+		Expect(err).ToNot(HaveOccurred())
+		proxyURL, err := transport.Proxy(req)
+		Expect(err).ToNot(HaveOccurred())
+
+		return proxyURL
+	}
+
+	It("should proxy requests except for the hosts matching HTTPNoProxy", func() {
+		cfg := config.OTLPConfig{
+			HTTPProxyURL: &url.URL{Scheme: "http", User: url.UserPassword("user", "secret"), Host: "proxy.example:3128"},
+			HTTPNoProxy:  ".svc.cluster.local,10.0.0.0/8,collector.example:4318",
+		}
+
+		Expect(proxyFor(cfg, "https://logs.example.com/v1/logs")).To(Equal(cfg.HTTPProxyURL))
+		Expect(proxyFor(cfg, "http://logs.example.com/v1/logs")).To(Equal(cfg.HTTPProxyURL))
+		Expect(proxyFor(cfg, "http://collector.example/v1/logs")).To(Equal(cfg.HTTPProxyURL))
+		Expect(proxyFor(cfg, "http://otel.garden.svc.cluster.local/v1/logs")).To(BeNil())
+		Expect(proxyFor(cfg, "http://10.1.2.3:4318/v1/logs")).To(BeNil())
+		Expect(proxyFor(cfg, "http://collector.example:4318/v1/logs")).To(BeNil())
+		Expect(proxyFor(cfg, "http://127.0.0.1:4318/v1/logs")).To(BeNil())
+	})
+
+	It("should dial https proxies on the default port with TLS", func() {
+		transport := &http.Transport{}
+		otlp.ConfigureProxy(transport, config.OTLPConfig{HTTPProxyURL: &url.URL{Scheme: "https", Host: "proxy.example"}})
+
+		req, err := http.NewRequest(http.MethodPost, "https://logs.example.com/v1/logs", nil)
+		Expect(err).ToNot(HaveOccurred())
+		proxyURL, err := transport.Proxy(req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proxyURL.String()).To(Equal("http://proxy.example:443"))
+		Expect(transport.DialContext).NotTo(BeNil())
+	})
+})
@@ -4,30 +4,53 @@
 package otlp
 
 import (
+	"regexp"
 	"strconv"
+	"strings"
 
 	otlplog "go.opentelemetry.io/otel/log"
+
+	"github.com/gardener/logging/v1/pkg/config"
 )
 
-// mapSeverity maps log level from various common formats to OTLP severity
-// Supports: level, severity, loglevel fields as string or numeric values
+// MapSeverity maps the log level of the record to OTLP severity using the given rules.
+// The configured fields are checked first, then the body patterns are applied to the log message.
 // Returns both the OTLP severity enum and the original severity text
-func mapSeverity(record map[string]any) (otlplog.Severity, string) {
-	// Try common field names for log level
-	levelFields := []string{"level", "severity", "loglevel", "log_level", "lvl"}
-
-	for _, field := range levelFields {
-		if levelValue, ok := record[field]; ok {
-			// Handle string levels
-			if levelStr, ok := levelValue.(string); ok {
-				return mapSeverityString(levelStr), levelStr
-			}
-			// Handle numeric levels (e.g., syslog severity)
-			if levelNum, ok := levelValue.(int); ok {
-				return mapSeverityNumeric(levelNum), strconv.Itoa(levelNum)
-			}
-			if levelNum, ok := levelValue.(float64); ok {
-				return mapSeverityNumeric(int(levelNum)), strconv.Itoa(int(levelNum))
+func MapSeverity(record map[string]any, rules config.SeverityConfig) (otlplog.Severity, string) {
+	if rules.IsEmpty() {
+		rules = defaultSeverityConfig
+	}
+
+	for _, field := range rules.Fields {
+		levelValue, ok := record[field]
+		if !ok {
+			continue
+		}
+
+		//nolint:revive // enforce-switch-style: default-case is omitted on purpose, other types are not levels
+		switch level := levelValue.(type) {
+		case string:
+			return mapSeverityString(level, rules), level
+		case []byte:
+			return mapSeverityString(string(level), rules), string(level)
+		// Handle numeric levels (e.g., syslog severity)
+		case int:
+			return mapSeverityNumeric(level, rules), strconv.Itoa(level)
+		case int64:
+			return mapSeverityNumeric(int(level), rules), strconv.FormatInt(level, 10)
+		case uint64:
+			return mapSeverityNumeric(int(level), rules), strconv.FormatUint(level, 10) //nolint:gosec // G115: levels are small numbers
+		case float64:
+			return mapSeverityNumeric(int(level), rules), strconv.Itoa(int(level))
+		}
+	}
+
+	if len(rules.BodyPatterns) > 0 {
+		if body, ok := bodyString(record); ok {
+			for _, pattern := range rules.BodyPatterns {
+				if level, ok := matchLevel(pattern.FindStringSubmatch(body), pattern); ok {
+					return mapSeverityString(level, rules), level
+				}
 			}
 		}
 	}
@@ -36,46 +59,52 @@ func mapSeverity(record map[string]any) (otlplog.Severity, string) {
 	return otlplog.SeverityInfo, "Info"
 }
 
-// mapSeverityString maps string log levels to OTLP severity
-func mapSeverityString(level string) otlplog.Severity {
-	// Normalize to lowercase for case-insensitive matching
-	//nolint:revive // identical-switch-branches: default fallback improves readability
-	switch level {
-	case "trace", "TRACE", "Trace":
-		return otlplog.SeverityTrace
-	case "debug", "DEBUG", "Debug", "dbg", "DBG":
-		return otlplog.SeverityDebug
-	case "info", "INFO", "Info", "information", "INFORMATION":
-		return otlplog.SeverityInfo
-	case "warn", "WARN", "Warn", "warning", "WARNING", "Warning":
-		return otlplog.SeverityWarn
-	case "error", "ERROR", "Error", "err", "ERR":
-		return otlplog.SeverityError
-	case "fatal", "FATAL", "Fatal", "critical", "CRITICAL", "Critical", "crit", "CRIT":
-		return otlplog.SeverityFatal
-	default:
-		return otlplog.SeverityInfo
+// defaultSeverityConfig is used when the builder has no severity rules configured
+var defaultSeverityConfig = config.DefaultSeverityConfig()
+
+// mapSeverityString maps textual log levels to OTLP severity, matching case-insensitively
+func mapSeverityString(level string, rules config.SeverityConfig) otlplog.Severity {
+	if severity, ok := rules.Values[strings.ToLower(level)]; ok {
+		return otlplog.Severity(severity) //nolint:gosec // G115: severities are validated to be between 1 and 24
+	}
+
+	return otlplog.SeverityInfo
+}
+
+// mapSeverityNumeric maps numeric log levels (e.g., syslog or bunyan levels) to OTLP severity
+func mapSeverityNumeric(level int, rules config.SeverityConfig) otlplog.Severity {
+	if severity, ok := rules.NumericValues[level]; ok {
+		return otlplog.Severity(severity) //nolint:gosec // G115: severities are validated to be between 1 and 24
+	}
+
+	return otlplog.SeverityInfo
+}
+
+// bodyString returns the textual log message of the record
+func bodyString(record map[string]any) (string, bool) {
+	for _, key := range bodyKeys {
+		switch v := record[key].(type) {
+		case string:
+			return v, true
+		case []byte:
+			return string(v), true
+		default:
+		}
 	}
+
+	return "", false
 }
 
-// mapSeverityNumeric maps numeric log levels (e.g., syslog severity) to OTLP severity
-// Uses syslog severity scale: 0=Emergency, 1=Alert, 2=Critical, 3=Error, 4=Warning, 5=Notice, 6=Info, 7=Debug
-func mapSeverityNumeric(level int) otlplog.Severity {
-	//nolint:revive // identical-switch-branches: default fallback improves readability
-	switch level {
-	case 0, 1: // Emergency, Alert
-		return otlplog.SeverityFatal4
-	case 2: // Critical
-		return otlplog.SeverityFatal
-	case 3: // Error
-		return otlplog.SeverityError
-	case 4: // Warning
-		return otlplog.SeverityWarn
-	case 5, 6: // Notice, Info
-		return otlplog.SeverityInfo
-	case 7: // Debug
-		return otlplog.SeverityDebug
-	default:
-		return otlplog.SeverityInfo
+// matchLevel returns the level captured by a body pattern.
+// The capture group named "level" is preferred over the first capture group.
+func matchLevel(match []string, pattern *regexp.Regexp) (string, bool) {
+	if len(match) < 2 {
+		return "", false
 	}
+
+	if i := pattern.SubexpIndex("level"); i > 0 && match[i] != "" {
+		return match[i], true
+	}
+
+	return match[1], match[1] != ""
 }
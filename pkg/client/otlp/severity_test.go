@@ -0,0 +1,118 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	otlplog "go.opentelemetry.io/otel/log"
+
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+var _ = Describe("mapSeverity", func() {
+	rulesWithPresets := func(presets string) config.SeverityConfig {
+		cfg, err := config.ParseConfig(map[string]any{"SeverityPresets": presets})
+		Expect(err).NotTo(HaveOccurred())
+
+		return cfg.OTLPConfig.SeverityConfig
+	}
+
+	Context("with the default rules", func() {
+		DescribeTable("should map levels of the common fields",
+			func(record map[string]any, expected otlplog.Severity, expectedText string) {
+				severity, text := MapSeverity(record, config.SeverityConfig{})
+				Expect(severity).To(Equal(expected))
+				Expect(text).To(Equal(expectedText))
+			},
+			Entry("lowercase level", map[string]any{"level": "error"}, otlplog.SeverityError, "error"),
+			Entry("mixed case severity", map[string]any{"severity": "WaRn"}, otlplog.SeverityWarn, "WaRn"),
+			Entry("syslog numeric level", map[string]any{"lvl": int64(3)}, otlplog.SeverityError, "3"),
+			Entry("float numeric level", map[string]any{"loglevel": float64(7)}, otlplog.SeverityDebug, "7"),
+			Entry("unknown level", map[string]any{"level": "verbose"}, otlplog.SeverityInfo, "verbose"),
+			Entry("missing level", map[string]any{"log": "E0102 klog line"}, otlplog.SeverityInfo, "Info"),
+		)
+	})
+
+	Context("with presets", func() {
+		It("should extract klog levels from the body", func() {
+			rules := rulesWithPresets("klog")
+
+			severity, text := MapSeverity(map[string]any{"log": "E0102 15:04:05.000000 1 main.go:10] failed"}, rules)
+			Expect(severity).To(Equal(otlplog.SeverityError))
+			Expect(text).To(Equal("E"))
+
+			severity, _ = MapSeverity(map[string]any{"log": "W0102 15:04:05.000000 1 main.go:10] careful"}, rules)
+			Expect(severity).To(Equal(otlplog.SeverityWarn))
+		})
+
+		It("should map logrus levels from fields and text output", func() {
+			rules := rulesWithPresets("logrus")
+
+			severity, _ := MapSeverity(map[string]any{"level": "panic"}, rules)
+			Expect(severity).To(Equal(otlplog.SeverityFatal2))
+
+			severity, text := MapSeverity(map[string]any{"log": `time="2024-01-02T15:04:05Z" level=warning msg="disk"`}, rules)
+			Expect(severity).To(Equal(otlplog.SeverityWarn))
+			Expect(text).To(Equal("warning"))
+		})
+
+		It("should map java levels from the body", func() {
+			rules := rulesWithPresets("java")
+
+			severity, text := MapSeverity(map[string]any{"log": "2024-01-02 15:04:05,000 [main] SEVERE connection lost"}, rules)
+			Expect(severity).To(Equal(otlplog.SeverityError))
+			Expect(text).To(Equal("SEVERE"))
+
+			severity, _ = MapSeverity(map[string]any{"log": "2024-01-02 15:04:05,000 FINE cache hit"}, rules)
+			Expect(severity).To(Equal(otlplog.SeverityDebug))
+		})
+
+		It("should map numeric bunyan levels", func() {
+			rules := rulesWithPresets("bunyan")
+
+			severity, text := MapSeverity(map[string]any{"level": float64(50)}, rules)
+			Expect(severity).To(Equal(otlplog.SeverityError))
+			Expect(text).To(Equal("50"))
+
+			// syslog levels keep working next to the bunyan levels
+			severity, _ = MapSeverity(map[string]any{"level": int64(4)}, rules)
+			Expect(severity).To(Equal(otlplog.SeverityWarn))
+		})
+	})
+
+	Context("with custom rules", func() {
+		It("should apply custom fields, body regex and value tables", func() {
+			cfg, err := config.ParseConfig(map[string]any{
+				"SeverityFields":        "sev",
+				"SeverityBodyRegex":     `^\[(?P<level>[a-z]+)\]`,
+				"SeverityValues":        `{"notice": "info2", "oops": 18}`,
+				"SeverityNumericValues": `{"100": "fatal"}`,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			rules := cfg.OTLPConfig.SeverityConfig
+
+			severity, _ := MapSeverity(map[string]any{"sev": "notice", "level": "error"}, rules)
+			Expect(severity).To(Equal(otlplog.SeverityInfo2))
+
+			severity, _ = MapSeverity(map[string]any{"sev": int64(100)}, rules)
+			Expect(severity).To(Equal(otlplog.SeverityFatal))
+
+			severity, text := MapSeverity(map[string]any{"log": "[oops] something went wrong"}, rules)
+			Expect(severity).To(Equal(otlplog.SeverityError2))
+			Expect(text).To(Equal("oops"))
+		})
+	})
+
+	It("should be applied by the log record builder", func() {
+		cfg, err := config.ParseConfig(map[string]any{"SeverityPresets": "klog"})
+		Expect(err).NotTo(HaveOccurred())
+
+		record := map[string]any{"log": "F0102 15:04:05.000000 1 main.go:10] boom"}
+		logRecord := NewLogRecordBuilder().WithConfig(*cfg).WithSeverity(record).Build()
+
+		Expect(logRecord.Severity()).To(Equal(otlplog.SeverityFatal))
+		Expect(logRecord.SeverityText()).To(Equal("F"))
+	})
+})
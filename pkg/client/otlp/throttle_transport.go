@@ -0,0 +1,44 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"net/http"
+
+	"github.com/gardener/logging/v1/pkg/client/retry"
+)
+
+// throttleTransport lowers the limits of an adaptive limiter when the backend throttles the exports.
+// Throttled exports are answered with 429 Too Many Requests, or 503 Service Unavailable with a Retry-After header.
+type throttleTransport struct {
+	base    http.RoundTripper
+	limiter *Limiter
+}
+
+var _ http.RoundTripper = &throttleTransport{}
+
+// NewThrottleTransport wraps the transport of an HTTP exporter to lower the limits of an adaptive limiter.
+// The base transport is returned without limiter or if the limiter is not adaptive.
+func NewThrottleTransport(base http.RoundTripper, limiter *Limiter) http.RoundTripper {
+	if limiter == nil || !limiter.Adaptive() {
+		return base
+	}
+
+	return &throttleTransport{base: base, limiter: limiter}
+}
+
+// RoundTrip sends the request and reports throttled responses to the limiter
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	retryAfter := resp.Header.Get("Retry-After")
+	if resp.StatusCode == http.StatusTooManyRequests || (resp.StatusCode == http.StatusServiceUnavailable && retryAfter != "") {
+		t.limiter.Throttled(retry.After(retryAfter))
+	}
+
+	return resp, nil
+}
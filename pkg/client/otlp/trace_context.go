@@ -0,0 +1,153 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+// extractSpanContext extracts the trace context of the record from the configured fields.
+// The W3C traceparent field takes precedence over the trace/span ID fields,
+// which take precedence over the nested trace context object.
+// An empty span context is returned when no valid trace ID is found.
+func extractSpanContext(record map[string]any, cfg config.OTLPConfig) trace.SpanContext {
+	if !cfg.TraceContextEnabled {
+		return trace.SpanContext{}
+	}
+
+	if traceParent, ok := stringField(record, cfg.TraceParentField); ok {
+		if sc, ok := parseTraceParent(traceParent); ok {
+			return sc
+		}
+	}
+
+	if sc, ok := spanContextFromFields(record, cfg.TraceIDField, cfg.SpanIDField, cfg.TraceFlagsField); ok {
+		return sc
+	}
+
+	if cfg.TraceContextObjectField != "" {
+		if object, ok := record[cfg.TraceContextObjectField].(map[string]any); ok {
+			if sc, ok := spanContextFromFields(object, cfg.TraceIDField, cfg.SpanIDField, cfg.TraceFlagsField); ok {
+				return sc
+			}
+			// OTLP/JSON names of the trace context fields
+			if sc, ok := spanContextFromFields(object, "traceId", "spanId", "flags"); ok {
+				return sc
+			}
+		}
+	}
+
+	return trace.SpanContext{}
+}
+
+// spanContextFromFields builds a span context from separate trace ID, span ID and trace flags fields.
+// The span ID and trace flags are optional.
+func spanContextFromFields(record map[string]any, traceIDField, spanIDField, traceFlagsField string) (trace.SpanContext, bool) {
+	rawTraceID, ok := stringField(record, traceIDField)
+	if !ok {
+		return trace.SpanContext{}, false
+	}
+
+	traceID, err := trace.TraceIDFromHex(strings.ToLower(rawTraceID))
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+
+	scc := trace.SpanContextConfig{TraceID: traceID}
+
+	if rawSpanID, ok := stringField(record, spanIDField); ok {
+		if spanID, err := trace.SpanIDFromHex(strings.ToLower(rawSpanID)); err == nil {
+			scc.SpanID = spanID
+		}
+	}
+
+	if flags, ok := traceFlagsValue(record, traceFlagsField); ok {
+		scc.TraceFlags = flags
+	}
+
+	return trace.NewSpanContext(scc), true
+}
+
+// parseTraceParent parses a W3C traceparent value: version-traceid-spanid-flags
+// https://www.w3.org/TR/trace-context/#traceparent-header
+func parseTraceParent(value string) (trace.SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(strings.ToLower(value)), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[3]) != 2 {
+		return trace.SpanContext{}, false
+	}
+	// Version 00 defines exactly four fields, future versions may append more
+	if parts[0] == "00" && len(parts) != 4 {
+		return trace.SpanContext{}, false
+	}
+
+	traceID, err := trace.TraceIDFromHex(parts[1])
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+
+	spanID, err := trace.SpanIDFromHex(parts[2])
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.TraceFlags(flags[0]),
+	}), true
+}
+
+// traceFlagsValue reads trace flags given as number or hex string
+func traceFlagsValue(record map[string]any, field string) (trace.TraceFlags, bool) {
+	if field == "" {
+		return 0, false
+	}
+
+	switch v := record[field].(type) {
+	case int:
+		return trace.TraceFlags(v), v >= 0 && v <= 0xff //nolint:gosec // G115: range is checked
+	case int64:
+		return trace.TraceFlags(v), v >= 0 && v <= 0xff //nolint:gosec // G115: range is checked
+	case uint64:
+		return trace.TraceFlags(v), v <= 0xff //nolint:gosec // G115: range is checked
+	case float64:
+		return trace.TraceFlags(v), v >= 0 && v <= 0xff
+	case string:
+		flags, err := strconv.ParseUint(v, 16, 8)
+		if err != nil {
+			return 0, false
+		}
+
+		return trace.TraceFlags(flags), true
+	default:
+		return 0, false
+	}
+}
+
+// stringField returns the non-empty string value of the record field
+func stringField(record map[string]any, field string) (string, bool) {
+	if field == "" {
+		return "", false
+	}
+
+	switch v := record[field].(type) {
+	case string:
+		return v, v != ""
+	case []byte:
+		return string(v), len(v) > 0
+	default:
+		return "", false
+	}
+}
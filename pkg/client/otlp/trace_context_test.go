@@ -0,0 +1,127 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlp
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+var _ = Describe("extractSpanContext", func() {
+	const (
+		traceIDHex = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanIDHex  = "00f067aa0ba902b7"
+	)
+
+	var (
+		cfg     config.OTLPConfig
+		traceID trace.TraceID
+		spanID  trace.SpanID
+	)
+
+	BeforeEach(func() {
+		cfg = config.DefaultOTLPConfig
+
+		var err error
+		traceID, err = trace.TraceIDFromHex(traceIDHex)
+		Expect(err).NotTo(HaveOccurred())
+		spanID, err = trace.SpanIDFromHex(spanIDHex)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should extract the trace context from trace_id and span_id fields", func() {
+		sc := extractSpanContext(map[string]any{
+			"trace_id":    traceIDHex,
+			"span_id":     spanIDHex,
+			"trace_flags": int64(1),
+		}, cfg)
+
+		Expect(sc.TraceID()).To(Equal(traceID))
+		Expect(sc.SpanID()).To(Equal(spanID))
+		Expect(sc.IsSampled()).To(BeTrue())
+	})
+
+	It("should extract the trace ID without a span ID", func() {
+		sc := extractSpanContext(map[string]any{"trace_id": traceIDHex}, cfg)
+
+		Expect(sc.TraceID()).To(Equal(traceID))
+		Expect(sc.HasSpanID()).To(BeFalse())
+	})
+
+	It("should extract the trace context from a W3C traceparent", func() {
+		sc := extractSpanContext(map[string]any{
+			"traceparent": "00-" + traceIDHex + "-" + spanIDHex + "-01",
+			"trace_id":    "ffffffffffffffffffffffffffffffff",
+		}, cfg)
+
+		Expect(sc.TraceID()).To(Equal(traceID))
+		Expect(sc.SpanID()).To(Equal(spanID))
+		Expect(sc.TraceFlags()).To(Equal(trace.FlagsSampled))
+	})
+
+	It("should extract the trace context from a nested otel object", func() {
+		sc := extractSpanContext(map[string]any{
+			"otel": map[string]any{
+				"traceId": traceIDHex,
+				"spanId":  spanIDHex,
+				"flags":   "01",
+			},
+		}, cfg)
+
+		Expect(sc.TraceID()).To(Equal(traceID))
+		Expect(sc.SpanID()).To(Equal(spanID))
+		Expect(sc.IsSampled()).To(BeTrue())
+	})
+
+	It("should use the configured field names", func() {
+		cfg.TraceIDField = "traceID"
+		cfg.SpanIDField = "spanID"
+
+		sc := extractSpanContext(map[string]any{
+			"traceID":  traceIDHex,
+			"spanID":   spanIDHex,
+			"trace_id": "ffffffffffffffffffffffffffffffff",
+		}, cfg)
+
+		Expect(sc.TraceID()).To(Equal(traceID))
+		Expect(sc.SpanID()).To(Equal(spanID))
+	})
+
+	DescribeTable("should ignore invalid trace contexts",
+		func(record map[string]any) {
+			Expect(extractSpanContext(record, cfg).HasTraceID()).To(BeFalse())
+		},
+		Entry("malformed trace ID", map[string]any{"trace_id": "not-a-trace-id"}),
+		Entry("all zero trace ID", map[string]any{"trace_id": "00000000000000000000000000000000"}),
+		Entry("invalid traceparent version", map[string]any{"traceparent": "ff-" + traceIDHex + "-" + spanIDHex + "-01"}),
+		Entry("truncated traceparent", map[string]any{"traceparent": "00-" + traceIDHex + "-01"}),
+		Entry("no trace fields", map[string]any{"log": "message"}),
+	)
+
+	It("should not extract anything when disabled", func() {
+		cfg.TraceContextEnabled = false
+
+		Expect(extractSpanContext(map[string]any{"trace_id": traceIDHex}, cfg).HasTraceID()).To(BeFalse())
+	})
+
+	It("should carry the extracted trace context in the emit context", func() {
+		c := config.Config{OTLPConfig: cfg}
+		builder := NewLogRecordBuilder().WithConfig(c).WithTraceContext(map[string]any{
+			"trace_id": traceIDHex,
+			"span_id":  spanIDHex,
+		})
+
+		sc := trace.SpanContextFromContext(builder.EmitContext(context.Background()))
+		Expect(sc.TraceID()).To(Equal(traceID))
+		Expect(sc.SpanID()).To(Equal(spanID))
+
+		ctx := context.Background()
+		Expect(NewLogRecordBuilder().WithConfig(c).WithTraceContext(map[string]any{}).EmitContext(ctx)).To(Equal(ctx))
+	})
+})
@@ -0,0 +1,90 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package retry retries requests of the exporters which do not bring their own retry logic
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+// retryableError is an error which is retried
+type retryableError struct {
+	err   error
+	after time.Duration
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// Retryable marks the error as retryable, after is the minimum delay requested by the server, e.g. by Retry-After
+func Retryable(err error, after time.Duration) error {
+	return &retryableError{err: err, after: after}
+}
+
+// IsRetryable reports whether the error is marked as retryable
+func IsRetryable(err error) bool {
+	var retryable *retryableError
+
+	return errors.As(err, &retryable)
+}
+
+// Do calls fn until it succeeds or returns an error which is not retryable.
+// Retries back off exponentially from the initial to the maximum interval and stop after the maximum elapsed time.
+// Without retry configuration or with retries disabled fn is called once.
+func Do(ctx context.Context, cfg *config.RetryConfig, logger logr.Logger, fn func(context.Context) error) error {
+	if cfg == nil || !cfg.Enabled {
+		return fn(ctx)
+	}
+
+	interval := cfg.InitialInterval
+	deadline := time.Now().Add(cfg.MaxElapsedTime)
+	for {
+		err := fn(ctx)
+		var retryable *retryableError
+		if err == nil || !errors.As(err, &retryable) {
+			return err
+		}
+
+		wait := max(interval, retryable.after)
+		if time.Now().Add(wait).After(deadline) {
+			return fmt.Errorf("giving up after %s: %w", cfg.MaxElapsedTime, err)
+		}
+		logger.V(2).Info("retrying request", "error", err.Error(), "wait", wait)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+
+		interval = min(interval*2, cfg.MaxInterval)
+	}
+}
+
+// After parses the delay seconds of a Retry-After header, dates and invalid values are ignored
+func After(header string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/logging/v1/pkg/client/retry"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/log"
+)
+
+var _ = Describe("Retry", func() {
+	var cfg *config.RetryConfig
+
+	BeforeEach(func() {
+		cfg = &config.RetryConfig{
+			Enabled:         true,
+			InitialInterval: time.Millisecond,
+			MaxInterval:     2 * time.Millisecond,
+			MaxElapsedTime:  time.Second,
+		}
+	})
+
+	// failing returns a function failing with the errors before it succeeds
+	failing := func(calls *int, errs ...error) func(context.Context) error {
+		return func(context.Context) error {
+			*calls++
+			if *calls <= len(errs) {
+				return errs[*calls-1]
+			}
+
+			return nil
+		}
+	}
+
+	It("should retry retryable errors until the call succeeds", func() {
+		var calls int
+		err := retry.Do(context.Background(), cfg, log.NewNoop(), failing(&calls,
+			retry.Retryable(errors.New("unavailable"), 0),
+			retry.Retryable(errors.New("too many requests"), time.Millisecond),
+		))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(3))
+	})
+
+	It("should not retry other errors", func() {
+		var calls int
+		permanent := errors.New("bad request")
+		err := retry.Do(context.Background(), cfg, log.NewNoop(), failing(&calls, permanent))
+		Expect(err).To(MatchError(permanent))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("should call once with retries disabled", func() {
+		var calls int
+		cfg.Enabled = false
+		err := retry.Do(context.Background(), cfg, log.NewNoop(), failing(&calls, retry.Retryable(errors.New("unavailable"), 0)))
+		Expect(retry.IsRetryable(err)).To(BeTrue())
+		Expect(calls).To(Equal(1))
+	})
+
+	It("should give up after the maximum elapsed time", func() {
+		var calls int
+		cfg.MaxElapsedTime = 10 * time.Millisecond
+		err := retry.Do(context.Background(), cfg, log.NewNoop(), func(context.Context) error {
+			calls++
+
+			return retry.Retryable(errors.New("unavailable"), 0)
+		})
+		Expect(err).To(MatchError(ContainSubstring("giving up after 10ms: unavailable")))
+		Expect(calls).To(BeNumerically(">", 1))
+	})
+
+	It("should stop when the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		cfg.InitialInterval = time.Minute
+		cfg.MaxInterval = time.Minute
+		cfg.MaxElapsedTime = time.Hour
+
+		var calls int
+		err := retry.Do(ctx, cfg, log.NewNoop(), failing(&calls, retry.Retryable(errors.New("unavailable"), 0)))
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(calls).To(Equal(1))
+	})
+
+	DescribeTable("should parse Retry-After delay seconds",
+		func(header string, expected time.Duration) {
+			Expect(retry.After(header)).To(Equal(expected))
+		},
+		Entry("seconds", "3", 3*time.Second),
+		Entry("empty", "", time.Duration(0)),
+		Entry("negative", "-1", time.Duration(0)),
+		Entry("date", "Wed, 21 Oct 2015 07:28:00 GMT", time.Duration(0)),
+	)
+})
@@ -45,14 +45,7 @@ func New(ctx context.Context, cfg config.Config, logger logr.Logger, m *metrics.
 
 // Handle processes and writes the log entry to stdout while incrementing metrics
 func (c *Client) Handle(entry types.OutputEntry) error {
-	// Create a map with timestamp and record fields
-	output := map[string]any{
-		"timestamp": entry.Timestamp.Format("2006-01-02T15:04:05.000000Z07:00"),
-		"record":    entry.Record,
-	}
-
-	// Marshal to JSON
-	data, err := json.Marshal(output)
+	data, err := MarshalEntry(entry)
 	if err != nil {
 		c.logger.Error(err, "failed to marshal log entry to JSON")
 		c.metrics.Errors.WithLabelValues(metrics.ErrorSendRecord).Inc()
@@ -74,6 +67,52 @@ func (c *Client) Handle(entry types.OutputEntry) error {
 	return nil
 }
 
+// MarshalEntry encodes the entry as JSON object with its timestamp, record and log group
+func MarshalEntry(entry types.OutputEntry) ([]byte, error) {
+	output := map[string]any{
+		"timestamp": entry.Timestamp.Format("2006-01-02T15:04:05.000000Z07:00"),
+		"record":    toJSONValue(entry.Record),
+	}
+	if entry.Group != nil {
+		output["group"] = entry.Group
+	}
+
+	return json.Marshal(output)
+}
+
+// toJSONValue prepares nested record values for JSON encoding.
+// Maps with non-string keys are converted to string keyed maps and byte slices to strings
+// so nested structures are written as JSON objects instead of failing or being base64 encoded.
+func toJSONValue(value any) any {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, val := range v {
+			m[k] = toJSONValue(val)
+		}
+
+		return m
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, val := range v {
+			m[fmt.Sprintf("%v", k)] = toJSONValue(val)
+		}
+
+		return m
+	case []any:
+		s := make([]any, len(v))
+		for i, val := range v {
+			s[i] = toJSONValue(val)
+		}
+
+		return s
+	default:
+		return v
+	}
+}
+
 // Stop shuts down the client immediately
 func (c *Client) Stop() {
 	c.logger.V(2).Info(fmt.Sprintf("stopping %s", componentStdoutName))
@@ -174,6 +174,43 @@ var _ = Describe("StdoutClient", func() {
 			Expect(k8s["pod_name"]).To(Equal("test-pod"))
 		})
 
+		It("should write deeply nested maps and slices as JSON", func() {
+			entry := types.OutputEntry{
+				Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+				Record: map[string]any{
+					"log": []byte("test log message"),
+					"kubernetes": map[string]any{
+						"labels": map[any]any{
+							"app": "gardener",
+							"tier": map[string]any{
+								"name": []byte("control-plane"),
+							},
+						},
+						"containers": []any{map[any]any{"name": "init"}, "main"},
+					},
+				},
+			}
+			Expect(outputClient.Handle(entry)).To(Succeed())
+
+			_ = w.Close()
+			var buf bytes.Buffer
+			_, err := io.Copy(&buf, r)
+			Expect(err).NotTo(HaveOccurred())
+
+			var output map[string]any
+			Expect(json.Unmarshal(buf.Bytes(), &output)).To(Succeed())
+			Expect(output["record"]).To(Equal(map[string]any{
+				"log": "test log message",
+				"kubernetes": map[string]any{
+					"labels": map[string]any{
+						"app":  "gardener",
+						"tier": map[string]any{"name": "control-plane"},
+					},
+					"containers": []any{map[string]any{"name": "init"}, "main"},
+				},
+			}))
+		})
+
 		It("should handle concurrent log entries safely", func() {
 			initialMetric := testMetrics.OutputClientLogs.WithLabelValues(outputClient.Endpoint())
 			beforeCount := testutil.ToFloat64(initialMetric)
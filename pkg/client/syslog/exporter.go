@@ -0,0 +1,83 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslog
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/go-logr/logr"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/retry"
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+// exporter is a blocking sdklog.Exporter sending each record as RFC 5424 message
+type exporter struct {
+	sender    *sender
+	formatter *formatter
+	retry     *config.RetryConfig
+	logger    logr.Logger
+}
+
+var _ sdklog.Exporter = &exporter{}
+
+// newExporter creates an exporter from the endpoint, TLS, timeout, retry and syslog configuration
+func newExporter(cfg config.Config, logger logr.Logger) *exporter {
+	tlsConfig := cfg.OTLPConfig.TLSConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return &exporter{
+		sender: &sender{
+			network:   cfg.SyslogConfig.Network,
+			addr:      cfg.OTLPConfig.Endpoint,
+			tlsConfig: tlsConfig,
+			timeout:   cfg.OTLPConfig.Timeout,
+		},
+		formatter: &formatter{
+			facility:         cfg.SyslogConfig.Facility,
+			appName:          cfg.SyslogConfig.AppName,
+			structuredDataID: cfg.SyslogConfig.StructuredDataID,
+		},
+		retry:  cfg.OTLPConfig.RetryConfig,
+		logger: logger,
+	}
+}
+
+// Export sends the records in order. Each retry only sends the messages which were not written yet.
+func (e *exporter) Export(ctx context.Context, records []sdklog.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	pending := make([][]byte, 0, len(records))
+	for i := range records {
+		pending = append(pending, e.formatter.format(&records[i]))
+	}
+
+	return retry.Do(ctx, e.retry, e.logger, func(ctx context.Context) error {
+		n, err := e.sender.send(ctx, pending)
+		pending = pending[n:]
+		if err != nil {
+			return retry.Retryable(err, 0)
+		}
+
+		return nil
+	})
+}
+
+// Shutdown closes the connection
+func (e *exporter) Shutdown(context.Context) error {
+	e.sender.close()
+
+	return nil
+}
+
+// ForceFlush is a no-op, the exporter does not buffer records
+func (*exporter) ForceFlush(context.Context) error {
+	return nil
+}
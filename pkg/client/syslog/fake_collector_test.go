@@ -0,0 +1,150 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslog
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeCollector is an in-process syslog collector receiving octet counted messages over TCP or TLS,
+// or datagrams over UDP
+type fakeCollector struct {
+	addr string
+
+	mu       sync.Mutex
+	messages []string
+	conns    int
+}
+
+// newFakeCollector starts a collector on the network, serving TLS with the certificate if it is set
+func newFakeCollector(network string, cert *tls.Certificate) *fakeCollector {
+	c := &fakeCollector{}
+
+	if network == "udp" {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		c.addr = conn.LocalAddr().String()
+		DeferCleanup(func() { _ = conn.Close() })
+		go c.serveUDP(conn)
+
+		return c
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	if cert != nil {
+		listener = tls.NewListener(listener, &tls.Config{Certificates: []tls.Certificate{*cert}, MinVersion: tls.VersionTLS12})
+	}
+	c.addr = listener.Addr().String()
+	DeferCleanup(func() { _ = listener.Close() })
+	go c.serveTCP(listener)
+
+	return c
+}
+
+func (c *fakeCollector) serveUDP(conn net.PacketConn) {
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		c.add(string(buf[:n]))
+	}
+}
+
+func (c *fakeCollector) serveTCP(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		c.mu.Lock()
+		c.conns++
+		c.mu.Unlock()
+		go c.handleConn(conn)
+	}
+}
+
+// handleConn reads octet counted frames, MSG-LEN SP SYSLOG-MSG
+func (c *fakeCollector) handleConn(conn net.Conn) {
+	defer GinkgoRecover()
+	defer func() { _ = conn.Close() }()
+
+	r := bufio.NewReader(conn)
+	for {
+		length, err := r.ReadString(' ')
+		if err != nil {
+			return
+		}
+		n, err := strconv.Atoi(length[:len(length)-1])
+		Expect(err).NotTo(HaveOccurred())
+		message := make([]byte, n)
+		if _, err := io.ReadFull(r, message); err != nil {
+			return
+		}
+		c.add(string(message))
+	}
+}
+
+func (c *fakeCollector) add(message string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.messages = append(c.messages, message)
+}
+
+func (c *fakeCollector) received() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.messages...)
+}
+
+func (c *fakeCollector) connections() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.conns
+}
+
+// selfSignedCertificate returns a certificate for 127.0.0.1 and a pool trusting it
+func selfSignedCertificate() (*tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "syslog"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	leaf, err := x509.ParseCertificate(der)
+	Expect(err).NotTo(HaveOccurred())
+
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, pool
+}
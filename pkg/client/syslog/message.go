@@ -0,0 +1,223 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslog
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otlplog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+)
+
+// Syslog severities of RFC 5424
+const (
+	severityEmergency = 0
+	severityAlert     = 1
+	severityCritical  = 2
+	severityError     = 3
+	severityWarning   = 4
+	severityNotice    = 5
+	severityInfo      = 6
+	severityDebug     = 7
+)
+
+const (
+	// nilValue is the NILVALUE of header fields and the structured data
+	nilValue = "-"
+	// timestampFormat is the TIMESTAMP format, RFC 3339 with microseconds
+	timestampFormat = "2006-01-02T15:04:05.000000Z07:00"
+	// kubernetesPrefix is the prefix of the attributes added as structured data parameters
+	kubernetesPrefix = "k8s."
+	// maxHostnameLength, maxAppNameLength and maxParamNameLength are the lengths allowed by RFC 5424
+	maxHostnameLength  = 255
+	maxAppNameLength   = 48
+	maxParamNameLength = 32
+)
+
+// formatter formats records as RFC 5424 messages
+type formatter struct {
+	facility         int
+	appName          string
+	structuredDataID string
+}
+
+// format returns the RFC 5424 message of the record:
+//
+//	<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [SD-ID k8s.namespace.name="..." ...] MSG
+func (f *formatter) format(r *sdklog.Record) []byte {
+	var sb strings.Builder
+	sb.WriteByte('<')
+	sb.WriteString(strconv.Itoa(f.facility*8 + severity(r.Severity())))
+	sb.WriteString(">1 ")
+
+	timestamp := r.Timestamp()
+	if timestamp.IsZero() {
+		timestamp = r.ObservedTimestamp()
+	}
+	if timestamp.IsZero() {
+		sb.WriteString(nilValue)
+	} else {
+		sb.WriteString(timestamp.Round(time.Microsecond).Format(timestampFormat))
+	}
+
+	sb.WriteByte(' ')
+	sb.WriteString(headerField(hostname(r), maxHostnameLength))
+	sb.WriteByte(' ')
+	sb.WriteString(headerField(f.appName, maxAppNameLength))
+	// PROCID and MSGID are not known
+	sb.WriteString(" - - ")
+	f.writeStructuredData(&sb, r)
+
+	if msg := bodyString(r.Body()); msg != "" {
+		sb.WriteByte(' ')
+		sb.WriteString(msg)
+	}
+
+	return []byte(sb.String())
+}
+
+// writeStructuredData writes an element with the Kubernetes attributes of the record and its resource,
+// record attributes take precedence. Attributes with names longer than a PARAM-NAME are skipped.
+func (f *formatter) writeStructuredData(sb *strings.Builder, r *sdklog.Record) {
+	var params []attribute.KeyValue
+	seen := make(map[string]bool)
+	add := func(key, value string) {
+		if !strings.HasPrefix(key, kubernetesPrefix) || len(key) > maxParamNameLength || seen[key] ||
+			strings.ContainsAny(key, `= ]"`) {
+			return
+		}
+		seen[key] = true
+		params = append(params, attribute.String(key, value))
+	}
+
+	r.WalkAttributes(func(kv otlplog.KeyValue) bool {
+		add(kv.Key, bodyString(kv.Value))
+
+		return true
+	})
+	if res := r.Resource(); res != nil {
+		iter := res.Iter()
+		for iter.Next() {
+			kv := iter.Attribute()
+			add(string(kv.Key), kv.Value.Emit())
+		}
+	}
+
+	if len(params) == 0 {
+		sb.WriteString(nilValue)
+
+		return
+	}
+
+	sb.WriteByte('[')
+	sb.WriteString(f.structuredDataID)
+	for _, p := range params {
+		sb.WriteByte(' ')
+		sb.WriteString(string(p.Key))
+		sb.WriteString(`="`)
+		sb.WriteString(paramValueEscaper.Replace(p.Value.AsString()))
+		sb.WriteByte('"')
+	}
+	sb.WriteByte(']')
+}
+
+// paramValueEscaper escapes the characters of PARAM-VALUE which must be escaped
+var paramValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// hostname returns the host.name resource attribute, falling back to the k8s.node.name attribute
+func hostname(r *sdklog.Record) string {
+	if res := r.Resource(); res != nil {
+		if v, ok := res.Set().Value("host.name"); ok && v.AsString() != "" {
+			return v.AsString()
+		}
+	}
+
+	var node string
+	r.WalkAttributes(func(kv otlplog.KeyValue) bool {
+		if kv.Key != "k8s.node.name" {
+			return true
+		}
+		node = kv.Value.AsString()
+
+		return false
+	})
+	if node == "" {
+		if res := r.Resource(); res != nil {
+			if v, ok := res.Set().Value("k8s.node.name"); ok {
+				node = v.AsString()
+			}
+		}
+	}
+
+	return node
+}
+
+// headerField returns the value with characters other than printable US-ASCII removed, truncated to maxLength,
+// or NILVALUE if the value is empty
+func headerField(value string, maxLength int) string {
+	value = strings.Map(func(c rune) rune {
+		if c < 33 || c > 126 {
+			return -1
+		}
+
+		return c
+	}, value)
+	if len(value) > maxLength {
+		value = value[:maxLength]
+	}
+	if value == "" {
+		return nilValue
+	}
+
+	return value
+}
+
+// bodyString returns string values as is and encodes other values as JSON
+func bodyString(v otlplog.Value) string {
+	switch v.Kind() {
+	case otlplog.KindEmpty:
+		return ""
+	case otlplog.KindString:
+		return v.AsString()
+	default:
+		data, err := json.Marshal(otlp.ValueToAny(v))
+		if err != nil {
+			return v.String()
+		}
+
+		return string(data)
+	}
+}
+
+// severity maps the OTLP severity to the syslog severity. The fatal severities are mapped to critical, alert
+// and emergency, the INFO2 to INFO4 severities to notice, like the syslog receiver of the collector maps them.
+// Records without severity are informational.
+func severity(s otlplog.Severity) int {
+	switch {
+	case s == otlplog.SeverityUndefined:
+		return severityInfo
+	case s <= otlplog.SeverityDebug4:
+		return severityDebug
+	case s == otlplog.SeverityInfo:
+		return severityInfo
+	case s <= otlplog.SeverityInfo4:
+		return severityNotice
+	case s <= otlplog.SeverityWarn4:
+		return severityWarning
+	case s <= otlplog.SeverityError4:
+		return severityError
+	case s <= otlplog.SeverityFatal2:
+		return severityCritical
+	case s == otlplog.SeverityFatal3:
+		return severityAlert
+	default:
+		return severityEmergency
+	}
+}
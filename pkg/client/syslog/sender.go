@@ -0,0 +1,130 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslog
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gardener/logging/v1/pkg/config"
+)
+
+// maxDatagramSize is the largest message sent over UDP, the maximum UDP payload over IPv4
+const maxDatagramSize = 65507
+
+// sender writes messages to the syslog collector. The connection is opened on the first send
+// and reopened after write errors. It is safe for concurrent use.
+type sender struct {
+	network   string
+	addr      string
+	tlsConfig *tls.Config
+	timeout   time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// send writes the messages and returns the number of messages written before an error occurred.
+// Over TCP messages are framed by octet counting, over UDP each message is sent as datagram.
+func (s *sender) send(ctx context.Context, messages [][]byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return 0, err
+		}
+		s.conn = conn
+	}
+
+	deadline := time.Now().Add(s.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := s.conn.SetWriteDeadline(deadline); err != nil {
+		s.reset()
+
+		return 0, fmt.Errorf("failed to send message to %s: %w", s.addr, err)
+	}
+
+	for i, m := range messages {
+		var err error
+		if s.network == config.SyslogNetworkUDP {
+			_, err = s.conn.Write(truncate(m, maxDatagramSize))
+		} else {
+			_, err = s.conn.Write(frame(m))
+		}
+		if err != nil {
+			// A partially written frame breaks the framing of the connection, the next send reconnects
+			s.reset()
+
+			return i, fmt.Errorf("failed to send message to %s: %w", s.addr, err)
+		}
+	}
+
+	return len(messages), nil
+}
+
+// dial connects to the collector, using TLS for the tcp+tls network
+func (s *sender) dial(ctx context.Context) (net.Conn, error) {
+	netDialer := &net.Dialer{Timeout: s.timeout}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	switch s.network {
+	case config.SyslogNetworkTCPTLS:
+		conn, err = (&tls.Dialer{NetDialer: netDialer, Config: s.tlsConfig}).DialContext(ctx, "tcp", s.addr)
+	default:
+		conn, err = netDialer.DialContext(ctx, s.network, s.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", s.addr, err)
+	}
+
+	return conn, nil
+}
+
+// close closes the connection
+func (s *sender) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reset()
+}
+
+func (s *sender) reset() {
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// frame prefixes the message with its length, the octet counting framing of RFC 6587
+func frame(m []byte) []byte {
+	f := strconv.AppendInt(make([]byte, 0, len(m)+8), int64(len(m)), 10)
+	f = append(f, ' ')
+
+	return append(f, m...)
+}
+
+// truncate cuts the message to at most size bytes without splitting a UTF-8 character
+func truncate(m []byte, size int) []byte {
+	if len(m) <= size {
+		return m
+	}
+	for size > 0 && !utf8.RuneStart(m[size]) {
+		size--
+	}
+
+	return m[:size]
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
+)
+
+const componentSyslogName = "syslog"
+
+// New creates a new client forwarding logs to a syslog collector. Records are built like for the OTLP clients and
+// batched by the configured batch processor, the exporter sends each record as RFC 5424 message.
+func New(ctx context.Context, cfg config.Config, logger logr.Logger, m *metrics.FluentBitGardenerMetrics) (*otlp.ExporterClient, error) {
+	if cfg.OTLPConfig.Endpoint == "" {
+		return nil, errors.New("Endpoint is required for the syslog client")
+	}
+
+	return otlp.NewExporterClient(ctx, cfg, logger, m, componentSyslogName, cfg.OTLPConfig.Endpoint,
+		func(context.Context, *otlp.Limiter) (sdklog.Exporter, error) {
+			return newExporter(cfg, logger), nil
+		})
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslog
+
+import (
+	"context"
+	"crypto/tls"
+	"regexp"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	otlplog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/client/otlp/otlptest"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/log"
+	"github.com/gardener/logging/v1/pkg/metrics"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+// rfc5424 matches the header and structured data of the messages sent by the client
+var rfc5424 = regexp.MustCompile(`^<(\d+)>1 (\S+) (\S+) (\S+) - - (-|\[.*?[^\\]\])(?: (.*))?$`)
+
+var _ = Describe("Syslog client", func() {
+	var testMetrics *metrics.FluentBitGardenerMetrics
+
+	BeforeEach(func() {
+		testMetrics = metrics.RegisterFluentBitGardenerMetrics(metrics.NewRegistry())
+	})
+
+	parseConfig := func(endpoint string, configMap map[string]any) config.Config {
+		base := map[string]any{
+			"Endpoint":             endpoint,
+			"HostnameValue":        "node-1",
+			"DQueDir":              GinkgoT().TempDir(),
+			"UseSDKBatchProcessor": "true",
+			"RetryInitialInterval": "10ms",
+			"RetryMaxInterval":     "20ms",
+			"RetryMaxElapsedTime":  "1s",
+		}
+		for k, v := range configMap {
+			base[k] = v
+		}
+		cfg, err := config.ParseConfig(base)
+		Expect(err).NotTo(HaveOccurred())
+
+		return *cfg
+	}
+
+	entry := func(level, log string) types.OutputEntry {
+		return types.OutputEntry{
+			Timestamp: time.Date(2025, 3, 4, 5, 6, 7, 123456789, time.UTC),
+			Record: map[string]any{
+				"log":   log,
+				"level": level,
+				"kubernetes": map[string]any{
+					"namespace_name": "garden",
+					"pod_name":       "etcd-0",
+					"container_name": "etcd",
+				},
+			},
+		}
+	}
+
+	send := func(cfg config.Config, entries ...types.OutputEntry) *otlp.ExporterClient {
+		client, err := New(context.Background(), cfg, log.NewNoop(), testMetrics)
+		Expect(err).NotTo(HaveOccurred())
+		for _, e := range entries {
+			Expect(client.Handle(e)).To(Succeed())
+		}
+		client.StopWait()
+
+		return client
+	}
+
+	It("should send octet counted RFC 5424 messages over TCP", func() {
+		collector := newFakeCollector("tcp", nil)
+		cfg := parseConfig(collector.addr, map[string]any{"SyslogNetwork": "tcp", "SyslogFacility": "local0"})
+
+		client := send(cfg, entry("error", "compaction failed"), entry("info", "line\nwith newline"))
+
+		Eventually(collector.received).Should(HaveLen(2))
+		messages := collector.received()
+		fields := rfc5424.FindStringSubmatch(messages[0])
+		Expect(fields).NotTo(BeNil(), messages[0])
+		Expect(fields[1]).To(Equal("131")) // local0 (16) * 8 + error (3)
+		Expect(fields[2]).To(Equal("2025-03-04T05:06:07.123457Z"))
+		Expect(fields[3]).To(Equal("node-1"))
+		Expect(fields[4]).To(Equal("fluent-bit"))
+		Expect(fields[5]).To(HavePrefix("[k8s@32473 "))
+		Expect(fields[5]).To(ContainSubstring(`k8s.namespace.name="garden"`))
+		Expect(fields[5]).To(ContainSubstring(`k8s.pod.name="etcd-0"`))
+		Expect(fields[5]).To(ContainSubstring(`k8s.container.name="etcd"`))
+		Expect(fields[6]).To(Equal("compaction failed"))
+
+		Expect(messages[1]).To(HavePrefix("<134>1 "))
+		Expect(messages[1]).To(HaveSuffix("] line\nwith newline"))
+		Expect(collector.connections()).To(Equal(1))
+		Expect(testutil.ToFloat64(testMetrics.OutputClientLogs.WithLabelValues(client.Endpoint()))).To(Equal(2.0))
+	})
+
+	It("should send messages as datagrams over UDP", func() {
+		collector := newFakeCollector("udp", nil)
+		cfg := parseConfig(collector.addr, map[string]any{"SyslogNetwork": "udp", "SyslogAppName": "audit"})
+
+		send(cfg, entry("warn", "first"), entry("debug", "second"))
+
+		Eventually(collector.received).Should(HaveLen(2))
+		Expect(collector.received()[0]).To(MatchRegexp(`^<12>1 \S+ node-1 audit - - \[k8s@32473 .*\] first$`))
+		Expect(collector.received()[1]).To(MatchRegexp(`^<15>1 .* second$`))
+	})
+
+	It("should send messages over TLS", func() {
+		cert, pool := selfSignedCertificate()
+		collector := newFakeCollector("tcp", cert)
+		cfg := parseConfig(collector.addr, nil)
+		cfg.OTLPConfig.TLSConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+
+		send(cfg, entry("info", "secured"))
+
+		Eventually(collector.received).Should(HaveLen(1))
+		Expect(collector.received()[0]).To(HaveSuffix("] secured"))
+	})
+
+	It("should reconnect and resend the messages not written", func() {
+		collector := newFakeCollector("tcp", nil)
+		cfg := parseConfig(collector.addr, map[string]any{"SyslogNetwork": "tcp"})
+		exporter := newExporter(cfg, log.NewNoop())
+		defer func() { _ = exporter.Shutdown(context.Background()) }()
+
+		records := func(bodies ...string) []sdklog.Record {
+			out := make([]sdklog.Record, len(bodies))
+			for i, body := range bodies {
+				out[i].SetBody(otlplog.StringValue(body))
+			}
+
+			return out
+		}
+
+		Expect(exporter.Export(context.Background(), records("first"))).To(Succeed())
+		Eventually(collector.received).Should(HaveLen(1))
+
+		// The next write fails on the closed connection, the retry reconnects
+		Expect(exporter.sender.conn.Close()).To(Succeed())
+		Expect(exporter.Export(context.Background(), records("second", "third"))).To(Succeed())
+
+		Eventually(collector.received).Should(HaveLen(3))
+		Expect(collector.received()[1]).To(Equal("<14>1 - - fluent-bit - - - second"))
+		Expect(collector.connections()).To(Equal(2))
+	})
+
+	It("should give up when the collector is unreachable", func() {
+		cfg := parseConfig("127.0.0.1:1", map[string]any{"SyslogNetwork": "tcp", "RetryMaxElapsedTime": "50ms"})
+		exporter := newExporter(cfg, log.NewNoop())
+
+		var record sdklog.Record
+		record.SetBody(otlplog.StringValue("line"))
+		err := exporter.Export(context.Background(), []sdklog.Record{record})
+		Expect(err).To(MatchError(ContainSubstring("giving up")))
+	})
+
+	It("should escape structured data and encode structured bodies as JSON", func() {
+		f := &formatter{facility: 1, appName: "fluent-bit", structuredDataID: config.DefaultSyslogStructuredDataID}
+
+		var record otlplog.Record
+		record.SetTimestamp(time.Date(2025, 3, 4, 5, 6, 7, 0, time.FixedZone("CET", 3600)))
+		record.SetSeverity(otlplog.SeverityFatal4)
+		record.SetBody(otlplog.MapValue(otlplog.String("msg", "failed")))
+		record.AddAttributes(
+			otlplog.String("k8s.pod.name", `a"b\c]d`),
+			otlplog.String("k8s.pod.annotation.very-long-annotation-name", "skipped"),
+			otlplog.String("other", "skipped"),
+		)
+		records := otlptest.Records(record)
+
+		Expect(string(f.format(&records[0]))).To(Equal(`<8>1 2025-03-04T05:06:07.000000+01:00 - fluent-bit - - [k8s@32473 k8s.pod.name="a\"b\\c\]d"] {"msg":"failed"}`))
+	})
+
+	DescribeTable("should map the OTLP severity",
+		func(s otlplog.Severity, expected int) {
+			Expect(severity(s)).To(Equal(expected))
+		},
+		Entry("undefined", otlplog.SeverityUndefined, severityInfo),
+		Entry("trace", otlplog.SeverityTrace, severityDebug),
+		Entry("debug", otlplog.SeverityDebug4, severityDebug),
+		Entry("info", otlplog.SeverityInfo, severityInfo),
+		Entry("info2", otlplog.SeverityInfo2, severityNotice),
+		Entry("warn", otlplog.SeverityWarn, severityWarning),
+		Entry("error", otlplog.SeverityError3, severityError),
+		Entry("fatal", otlplog.SeverityFatal, severityCritical),
+		Entry("fatal3", otlplog.SeverityFatal3, severityAlert),
+		Entry("fatal4", otlplog.SeverityFatal4, severityEmergency),
+	)
+})
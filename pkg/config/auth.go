@@ -0,0 +1,93 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Supported authenticators of the OTLP clients
+const (
+	// AuthenticatorNone sends no Authorization header
+	AuthenticatorNone = "none"
+	// AuthenticatorOAuth2 sends bearer tokens of the OAuth2 client credentials flow
+	AuthenticatorOAuth2 = "oauth2"
+	// AuthenticatorKubernetes sends the projected service account token as bearer token
+	AuthenticatorKubernetes = "kubernetes"
+	// AuthenticatorBasic sends the username and the password of a secret file as basic authentication
+	AuthenticatorBasic = "basic"
+)
+
+// DefaultKubernetesTokenFile is the service account token mounted into pods
+const DefaultKubernetesTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// processAuthConfig validates the authenticator of the OTLP clients
+func processAuthConfig(config *Config, _ map[string]any) error {
+	otlp := &config.OTLPConfig
+
+	otlp.Authenticator = strings.ToLower(strings.TrimSpace(otlp.Authenticator))
+	if otlp.Authenticator == "" {
+		otlp.Authenticator = AuthenticatorNone
+	}
+
+	switch otlp.Authenticator {
+	case AuthenticatorNone:
+		return nil
+	case AuthenticatorOAuth2:
+		if err := validateOAuth2Config(otlp); err != nil {
+			return err
+		}
+	case AuthenticatorKubernetes:
+		if otlp.KubernetesTokenFile == "" {
+			return errors.New("Authenticator kubernetes requires KubernetesTokenFile")
+		}
+	case AuthenticatorBasic:
+		if otlp.BasicAuthUsername == "" || otlp.BasicAuthPasswordFile == "" {
+			return errors.New("Authenticator basic requires BasicAuthUsername and BasicAuthPasswordFile")
+		}
+	default:
+		return fmt.Errorf("invalid Authenticator %q, supported authenticators are %s, %s, %s, %s", otlp.Authenticator,
+			AuthenticatorNone, AuthenticatorOAuth2, AuthenticatorKubernetes, AuthenticatorBasic)
+	}
+
+	// The authenticator sets the Authorization header, it must not be configured twice
+	for header := range otlp.Headers {
+		if strings.EqualFold(header, "authorization") {
+			return fmt.Errorf("Authenticator %s cannot be combined with an Authorization header in Headers", otlp.Authenticator)
+		}
+	}
+	if otlp.HeaderFiles != nil {
+		for header := range otlp.HeaderFiles.Values() {
+			if strings.EqualFold(header, "authorization") {
+				return fmt.Errorf("Authenticator %s cannot be combined with an Authorization header in HeaderFiles", otlp.Authenticator)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateOAuth2Config(otlp *OTLPConfig) error {
+	if otlp.OAuth2TokenURL == "" || otlp.OAuth2ClientID == "" || otlp.OAuth2ClientSecretFile == "" {
+		return errors.New("Authenticator oauth2 requires OAuth2TokenURL, OAuth2ClientID and OAuth2ClientSecretFile")
+	}
+
+	tokenURL, err := url.Parse(otlp.OAuth2TokenURL)
+	if err != nil || (tokenURL.Scheme != "http" && tokenURL.Scheme != "https") || tokenURL.Host == "" {
+		return fmt.Errorf("invalid OAuth2TokenURL %q, expected an http:// or https:// URL", otlp.OAuth2TokenURL)
+	}
+
+	scopes := make([]string, 0, len(otlp.OAuth2Scopes))
+	for _, scope := range otlp.OAuth2Scopes {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	otlp.OAuth2Scopes = scopes
+
+	return nil
+}
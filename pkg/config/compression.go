@@ -0,0 +1,64 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+// Supported request compressions, set with Compression
+const (
+	CompressionNone   = "none"
+	CompressionGzip   = "gzip"
+	CompressionZstd   = "zstd"
+	CompressionSnappy = "snappy"
+)
+
+// compressions lists all supported compressions
+var compressions = []string{CompressionNone, CompressionGzip, CompressionZstd, CompressionSnappy}
+
+// clientCompressions lists the compressions supported by the client types compressing their requests,
+// the other client types ignore Compression
+var clientCompressions = map[types.Type][]string{
+	types.OTLPGRPC:   compressions,
+	types.OTLPHTTP:   compressions,
+	types.OPENSEARCH: {CompressionNone, CompressionGzip},
+	types.KAFKA:      compressions,
+}
+
+// processCompression normalizes Compression and validates it against the seed and shoot client types.
+// The integer values of earlier versions are accepted, 0 for none and 1 for gzip.
+// The deprecated 2 enabled gzip for the HTTP client and is accepted for gzip with a warning.
+func processCompression(config *Config, _ map[string]any) error {
+	compression := strings.ToLower(config.OTLPConfig.Compression)
+	//nolint:revive // enforce-switch-style: default-case is omitted on purpose, named compressions are validated below
+	switch compression {
+	case "", "0":
+		compression = CompressionNone
+	case "1":
+		compression = CompressionGzip
+	case "2":
+		compression = CompressionGzip
+		config.Warnings = append(config.Warnings, `Compression "2" is deprecated and used as gzip, set Compression to none, gzip, zstd or snappy`)
+	}
+
+	if !slices.Contains(compressions, compression) {
+		return fmt.Errorf("invalid Compression value %q, supported compressions are %s", compression, strings.Join(compressions, ", "))
+	}
+
+	for _, clientType := range []string{config.PluginConfig.SeedType, config.PluginConfig.ShootType} {
+		t := types.ClientTypeFromString(clientType)
+		if supported, ok := clientCompressions[t]; ok && !slices.Contains(supported, compression) {
+			return fmt.Errorf("compression %q is not supported by the %s client, supported compressions are %s",
+				compression, t, strings.Join(supported, ", "))
+		}
+	}
+	config.OTLPConfig.Compression = compression
+
+	return nil
+}
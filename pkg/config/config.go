@@ -9,6 +9,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -38,6 +40,15 @@ type Config struct {
 	ControllerConfig ControllerConfig `mapstructure:",squash"`
 	PluginConfig     PluginConfig     `mapstructure:",squash"`
 	OTLPConfig       OTLPConfig       `mapstructure:",squash"`
+	FileConfig       FileConfig       `mapstructure:",squash"`
+	LokiConfig       LokiConfig       `mapstructure:",squash"`
+	OpenSearchConfig OpenSearchConfig `mapstructure:",squash"`
+	KafkaConfig      KafkaConfig      `mapstructure:",squash"`
+	SyslogConfig     SyslogConfig     `mapstructure:",squash"`
+	MultiConfig      MultiConfig      `mapstructure:",squash"`
+
+	// Warnings about deprecated values found while parsing, logged by the plugin
+	Warnings []string `mapstructure:"-"`
 }
 
 // sanitizeConfigString removes surrounding quotes (" or ') from configuration string values
@@ -253,6 +264,21 @@ func postProcessConfig(config *Config, configMap map[string]any) error {
 		processQueueSyncConfig,
 		processControllerBoolConfigs,
 		processOTLPConfig,
+		processCompression,
+		processFailoverConfig,
+		processAuthConfig,
+		processThrottleConfig,
+		processTenantConfig,
+		buildSeverityConfig,
+		processKubernetesAttributesConfig,
+		processProcessorsConfig,
+		processMultilineConfig,
+		processFileConfig,
+		processLokiConfig,
+		processOpenSearchConfig,
+		processKafkaConfig,
+		processSyslogConfig,
+		processMultiConfig,
 		processLogLevel,
 	}
 
@@ -338,6 +364,14 @@ func processOTLPConfig(config *Config, configMap map[string]any) error {
 		config.OTLPConfig.EndpointURLPath = endpointURLPath
 	}
 
+	// HTTPPath is an alias of EndpointURLPath, the latter takes precedence
+	if httpPath, ok := configMap["httppath"].(string); ok && httpPath != "" && configMap["endpointurlpath"] == nil {
+		if !strings.HasPrefix(httpPath, "/") || strings.ContainsAny(httpPath, " :") {
+			return fmt.Errorf("invalid HTTPPath: %s", httpPath)
+		}
+		config.OTLPConfig.EndpointURLPath = httpPath
+	}
+
 	// Process Insecure
 	if insecure, ok := configMap["insecure"].(string); ok && insecure != "" {
 		boolVal, err := strconv.ParseBool(insecure)
@@ -347,16 +381,10 @@ func processOTLPConfig(config *Config, configMap map[string]any) error {
 		config.OTLPConfig.Insecure = boolVal
 	}
 
-	// Process Compression
-	if compression, ok := configMap["compression"].(string); ok && compression != "" {
-		compVal, err := strconv.Atoi(compression)
-		if err != nil {
-			return fmt.Errorf("failed to parse Compression as integer: %w", err)
-		}
-		if compVal < 0 || compVal > 2 { // 0=none, 1=gzip, 2=deflate typically
-			return fmt.Errorf("invalid Compression value %d: must be between 0 and 2", compVal)
-		}
-		config.OTLPConfig.Compression = compVal
+	// Process Encoding
+	config.OTLPConfig.Encoding = strings.ToLower(config.OTLPConfig.Encoding)
+	if config.OTLPConfig.Encoding != OTLPEncodingProtobuf && config.OTLPConfig.Encoding != OTLPEncodingJSON {
+		return fmt.Errorf("invalid Encoding %q, supported encodings are %s, %s", config.OTLPConfig.Encoding, OTLPEncodingProtobuf, OTLPEncodingJSON)
 	}
 
 	// Process Timeout
@@ -380,6 +408,11 @@ func processOTLPConfig(config *Config, configMap map[string]any) error {
 		config.OTLPConfig.Headers = headerMap
 	}
 
+	// Process HeaderFiles - headers with values read from files
+	if err := processHeaderFiles(config, configMap); err != nil {
+		return err
+	}
+
 	// Process RetryConfig fields
 	if enabled, ok := configMap["retryenabled"].(string); ok && enabled != "" {
 		boolVal, err := strconv.ParseBool(enabled)
@@ -463,6 +496,28 @@ func processOTLPConfig(config *Config, configMap map[string]any) error {
 		config.OTLPConfig.DQueBatchProcessorMaxBatchSize = val
 	}
 
+	if maxBatchBytes, ok := configMap["dquebatchprocessormaxbatchbytes"].(string); ok && maxBatchBytes != "" {
+		val, err := strconv.Atoi(maxBatchBytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse DQueBatchProcessorMaxBatchBytes as integer: %w", err)
+		}
+		if val < 0 {
+			return fmt.Errorf("DQueBatchProcessorMaxBatchBytes must not be negative, got %d", val)
+		}
+		config.OTLPConfig.DQueBatchProcessorMaxBatchBytes = val
+	}
+
+	if deadLetterMaxBytes, ok := configMap["dquebatchprocessordeadlettermaxbytes"].(string); ok && deadLetterMaxBytes != "" {
+		val, err := strconv.Atoi(deadLetterMaxBytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse DQueBatchProcessorDeadLetterMaxBytes as integer: %w", err)
+		}
+		if val < 0 {
+			return fmt.Errorf("DQueBatchProcessorDeadLetterMaxBytes must not be negative, got %d", val)
+		}
+		config.OTLPConfig.DQueBatchProcessorDeadLetterMaxBytes = val
+	}
+
 	if bufferSize, ok := configMap["dquebatchprocessorbuffersize"].(string); ok && bufferSize != "" {
 		val, err := strconv.Atoi(bufferSize)
 		if err != nil {
@@ -496,6 +551,51 @@ func processOTLPConfig(config *Config, configMap map[string]any) error {
 		return fmt.Errorf("failed to build TLS config: %w", err)
 	}
 
+	// Build HTTP proxy config from individual fields
+	if err := buildProxyConfig(config); err != nil {
+		return fmt.Errorf("failed to build HTTP proxy config: %w", err)
+	}
+
+	// Process Body configuration fields
+	if structuredBody, ok := configMap["structuredbody"].(string); ok && structuredBody != "" {
+		boolVal, err := strconv.ParseBool(structuredBody)
+		if err != nil {
+			return fmt.Errorf("failed to parse StructuredBody as boolean: %w", err)
+		}
+		config.OTLPConfig.StructuredBody = boolVal
+	}
+
+	if maxBodySize, ok := configMap["maxbodysize"].(string); ok && maxBodySize != "" {
+		val, err := strconv.Atoi(maxBodySize)
+		if err != nil {
+			return fmt.Errorf("failed to parse MaxBodySize as integer: %w", err)
+		}
+		if val < 0 {
+			return fmt.Errorf("MaxBodySize cannot be negative, got %d", val)
+		}
+		config.OTLPConfig.MaxBodySize = val
+	}
+
+	if maxStructuredBodySize, ok := configMap["maxstructuredbodysize"].(string); ok && maxStructuredBodySize != "" {
+		val, err := strconv.Atoi(maxStructuredBodySize)
+		if err != nil {
+			return fmt.Errorf("failed to parse MaxStructuredBodySize as integer: %w", err)
+		}
+		if val < 0 {
+			return fmt.Errorf("MaxStructuredBodySize cannot be negative, got %d", val)
+		}
+		config.OTLPConfig.MaxStructuredBodySize = val
+	}
+
+	// Process ResourceGrouping
+	if resourceGrouping, ok := configMap["resourcegrouping"].(string); ok && resourceGrouping != "" {
+		boolVal, err := strconv.ParseBool(resourceGrouping)
+		if err != nil {
+			return fmt.Errorf("failed to parse ResourceGrouping as boolean: %w", err)
+		}
+		config.OTLPConfig.ResourceGrouping = boolVal
+	}
+
 	// Process Throttle configuration fields
 	if throttleEnabled, ok := configMap["throttleenabled"].(string); ok && throttleEnabled != "" {
 		boolVal, err := strconv.ParseBool(throttleEnabled)
@@ -571,6 +671,32 @@ func processLogLevel(config *Config, configMap map[string]any) error {
 	return nil
 }
 
+// endpointHost returns the host of EndpointURL if set, otherwise of Endpoint
+func endpointHost(otlp *OTLPConfig) string {
+	if otlp.EndpointURL != "" {
+		return hostOf(otlp.EndpointURL)
+	}
+
+	return hostOf(otlp.Endpoint)
+}
+
+// hostOf returns the host of an endpoint given as URL or host:port
+func hostOf(endpoint string) string {
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return ""
+		}
+
+		return u.Hostname()
+	}
+	if host, _, err := net.SplitHostPort(endpoint); err == nil {
+		return host
+	}
+
+	return endpoint
+}
+
 // buildTLSConfig constructs a tls.Config from OTLP TLS configuration fields
 func buildTLSConfig(config *Config) error {
 	otlp := &config.OTLPConfig
@@ -587,29 +713,24 @@ func buildTLSConfig(config *Config) error {
 		InsecureSkipVerify: otlp.TLSInsecureSkipVerify, // #nosec G402 //nolint:gosec // This is configured by the user
 	}
 
-	// Load client certificate if both cert and key files are specified
+	// Load client certificate if both cert and key files are specified, it is reloaded when the files change
 	if otlp.TLSCertFile != "" && otlp.TLSKeyFile != "" {
-		cert, err := tls.LoadX509KeyPair(otlp.TLSCertFile, otlp.TLSKeyFile)
-		if err != nil {
-			return fmt.Errorf("failed to load client certificate: %w", err)
+		if err := reloadClientCertificate(tlsConfig, otlp.TLSCertFile, otlp.TLSKeyFile); err != nil {
+			return err
 		}
-		tlsConfig.Certificates = []tls.Certificate{cert}
 	} else if otlp.TLSCertFile != "" || otlp.TLSKeyFile != "" {
 		return errors.New("both TLSCertFile and TLSKeyFile must be specified together")
 	}
 
-	// Load CA certificate if specified
+	// Load CA certificate if specified, it is reloaded when the file changes
 	if otlp.TLSCAFile != "" {
-		caCert, err := os.ReadFile(otlp.TLSCAFile)
-		if err != nil {
-			return fmt.Errorf("failed to read CA certificate file: %w", err)
+		serverName := otlp.TLSServerName
+		if serverName == "" {
+			serverName = endpointHost(otlp)
 		}
-
-		caCertPool := x509.NewCertPool()
-		if !caCertPool.AppendCertsFromPEM(caCert) {
-			return errors.New("failed to parse CA certificate")
+		if err := reloadRootCAs(tlsConfig, otlp.TLSCAFile, serverName); err != nil {
+			return err
 		}
-		tlsConfig.RootCAs = caCertPool
 	}
 
 	// Set TLS version constraints
@@ -655,6 +776,65 @@ func parseTLSVersion(version string) (uint16, error) {
 	}
 }
 
+// buildProxyConfig parses the HTTP proxy URL and constructs the tls.Config used towards https:// proxies
+func buildProxyConfig(config *Config) error {
+	otlp := &config.OTLPConfig
+
+	if otlp.HTTPProxy == "" {
+		if otlp.HTTPNoProxy != "" || otlp.HTTPProxyTLSCAFile != "" || otlp.HTTPProxyTLSServerName != "" || otlp.HTTPProxyTLSInsecureSkipVerify {
+			return errors.New("HTTPNoProxy and HTTPProxyTLS settings require HTTPProxy")
+		}
+
+		return nil
+	}
+
+	proxyURL, err := url.Parse(otlp.HTTPProxy)
+	if err != nil {
+		return fmt.Errorf("invalid HTTPProxy: %w", err)
+	}
+	if proxyURL.Scheme != "http" && proxyURL.Scheme != "https" {
+		return fmt.Errorf("invalid HTTPProxy scheme %q, supported schemes are http, https", proxyURL.Scheme)
+	}
+	if proxyURL.Hostname() == "" {
+		return fmt.Errorf("invalid HTTPProxy %q: missing host", proxyURL.Redacted())
+	}
+	otlp.HTTPProxyURL = proxyURL
+
+	if proxyURL.Scheme != "https" {
+		if otlp.HTTPProxyTLSCAFile != "" || otlp.HTTPProxyTLSServerName != "" || otlp.HTTPProxyTLSInsecureSkipVerify {
+			return errors.New("HTTPProxyTLS settings require an https:// HTTPProxy")
+		}
+
+		return nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         otlp.HTTPProxyTLSServerName,
+		InsecureSkipVerify: otlp.HTTPProxyTLSInsecureSkipVerify, // #nosec G402 //nolint:gosec // This is configured by the user
+		MinVersion:         tls.VersionTLS12,
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = proxyURL.Hostname()
+	}
+
+	if otlp.HTTPProxyTLSCAFile != "" {
+		caCert, err := os.ReadFile(otlp.HTTPProxyTLSCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read proxy CA certificate file: %w", err)
+		}
+
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return errors.New("failed to parse proxy CA certificate")
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	otlp.HTTPProxyTLSConfig = tlsConfig
+
+	return nil
+}
+
 // RetryConfig holds the retry configuration for OTLP exporter
 type RetryConfig struct {
 	Enabled         bool
@@ -728,8 +908,19 @@ func defaultConfig() (*Config, error) {
 				TagPrefix:     DefaultKubernetesMetadataTagPrefix,
 				TagExpression: DefaultKubernetesMetadataTagExpression,
 			},
+			Multiline: MultilineConfig{
+				FlushTimeout: time.Second,
+				MaxLines:     500,
+				MaxBytes:     64 * 1024,
+				MaxStreams:   1024,
+			},
 		},
-		OTLPConfig: DefaultOTLPConfig,
+		OTLPConfig:       DefaultOTLPConfig,
+		FileConfig:       DefaultFileConfig,
+		LokiConfig:       DefaultLokiConfig,
+		OpenSearchConfig: DefaultOpenSearchConfig,
+		KafkaConfig:      DefaultKafkaConfig,
+		SyslogConfig:     DefaultSyslogConfig,
 	}
 
 	return config, nil
@@ -35,6 +35,17 @@ type ControllerConfig struct {
 	// Additionally, the namespace name must match DynamicHostRegex.
 	// When empty, all namespaces are considered (no filtering).
 	OpenTelemetryCollectorNamespaceLabelSelector string `mapstructure:"OpenTelemetryCollectorNamespaceLabelSelector"`
+
+	// TenantRequestsPerSec is the default quota of records per second of each dynamic client, 0 means no limit.
+	// It can be overridden per tenant by annotations of the Cluster, Shoot, Namespace or OpenTelemetryCollector.
+	TenantRequestsPerSec int `mapstructure:"TenantRequestsPerSec"`
+	// TenantBytesPerSec is the default quota of estimated record bytes per second of each dynamic client, 0 means no limit.
+	TenantBytesPerSec int `mapstructure:"TenantBytesPerSec"`
+	// TenantFairShareRequestsPerSec is a budget of records per second shared fairly by all dynamic clients, 0 means no budget.
+	// Each tenant is guaranteed an equal share, tenants exceeding their share use the budget left idle by the others.
+	TenantFairShareRequestsPerSec int `mapstructure:"TenantFairShareRequestsPerSec"`
+	// TenantFairShareBytesPerSec is a budget of estimated record bytes per second shared fairly by all dynamic clients, 0 means no budget.
+	TenantFairShareBytesPerSec int `mapstructure:"TenantFairShareBytesPerSec"`
 }
 
 // ControllerClientConfiguration contains flags which
@@ -0,0 +1,278 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fileStamp identifies a version of a file by its modification time and size
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// reloadingFiles caches a value loaded from files and loads it again when one of the files changed.
+// Rotated credentials are picked up without background goroutines, the files are checked whenever the value is used.
+type reloadingFiles[T any] struct {
+	paths []string
+	load  func() (T, error)
+
+	mu     sync.Mutex
+	stamps []fileStamp
+	value  T
+}
+
+// newReloadingFiles loads the value from the files, failing if the initial load fails
+func newReloadingFiles[T any](load func() (T, error), paths ...string) (*reloadingFiles[T], error) {
+	r := &reloadingFiles[T]{paths: paths, load: load}
+	stamps, err := r.stat()
+	if err != nil {
+		return nil, err
+	}
+	if r.value, err = load(); err != nil {
+		return nil, err
+	}
+	r.stamps = stamps
+
+	return r, nil
+}
+
+// get returns the value, loading it again if the files changed.
+// If the files cannot be loaded, e.g. while a rotation has only replaced some of them, the previous value is returned
+// and the files are loaded again on the next use.
+func (r *reloadingFiles[T]) get() T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stamps, err := r.stat()
+	if err != nil || stampsEqual(stamps, r.stamps) {
+		return r.value
+	}
+	if value, err := r.load(); err == nil {
+		r.value, r.stamps = value, stamps
+	}
+
+	return r.value
+}
+
+func (r *reloadingFiles[T]) stat() ([]fileStamp, error) {
+	stamps := make([]fileStamp, 0, len(r.paths))
+	for _, path := range r.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		stamps = append(stamps, fileStamp{modTime: info.ModTime(), size: info.Size()})
+	}
+
+	return stamps, nil
+}
+
+func stampsEqual(a, b []fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].modTime.Equal(b[i].modTime) || a[i].size != b[i].size {
+			return false
+		}
+	}
+
+	return true
+}
+
+// reloadClientCertificate sets GetClientCertificate, so that the client certificate is reloaded when the files change
+func reloadClientCertificate(tlsConfig *tls.Config, certFile, keyFile string) error {
+	certificate, err := newReloadingFiles(func() (*tls.Certificate, error) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+
+		return &cert, err
+	}, certFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load client certificate: %w", err)
+	}
+
+	tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		return certificate.get(), nil
+	}
+
+	return nil
+}
+
+// reloadRootCAs verifies the server certificates against the CA certificates of the file, reloaded when the file changes.
+// The verification of the TLS stack uses static RootCAs, so it is replaced by VerifyConnection. The certificate is verified
+// for the server name sent in the handshake, or for serverName if none was sent, like for endpoints with IP addresses.
+// Nothing is verified if the configuration skips the verification.
+func reloadRootCAs(tlsConfig *tls.Config, caFile, serverName string) error {
+	roots, err := newReloadingFiles(func() (*x509.CertPool, error) {
+		caCert, err := os.ReadFile(caFile) // #nosec G304 -- Path is configured by the user
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("failed to parse CA certificate")
+		}
+
+		return pool, nil
+	}, caFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to read CA certificate file: %w", err)
+		}
+
+		return err
+	}
+
+	tlsConfig.RootCAs = roots.get()
+	if tlsConfig.InsecureSkipVerify {
+		return nil
+	}
+	tlsConfig.InsecureSkipVerify = true // #nosec G402 //nolint:gosec // The certificate is verified by VerifyConnection
+	tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+		name := cs.ServerName
+		if name == "" {
+			name = serverName
+		}
+		if name == "" {
+			return errors.New("failed to verify the server certificate: TLSServerName is required for endpoints with IP addresses")
+		}
+		if len(cs.PeerCertificates) == 0 {
+			return errors.New("failed to verify the server certificate: no certificate received")
+		}
+
+		opts := x509.VerifyOptions{
+			Roots:         roots.get(),
+			DNSName:       name,
+			Intermediates: x509.NewCertPool(),
+		}
+		for _, cert := range cs.PeerCertificates[1:] {
+			opts.Intermediates.AddCert(cert)
+		}
+		if _, err := cs.PeerCertificates[0].Verify(opts); err != nil {
+			return &tls.CertificateVerificationError{UnverifiedCertificates: cs.PeerCertificates, Err: err}
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// ReloadingFile provides the trimmed content of a file, e.g. a token or password, read again when the file changes
+type ReloadingFile struct {
+	path    string
+	content *reloadingFiles[string]
+}
+
+// NewReloadingFile reads the file, failing if it cannot be read
+func NewReloadingFile(path string) (*ReloadingFile, error) {
+	content, err := newReloadingFiles(func() (string, error) {
+		data, err := os.ReadFile(path) // #nosec G304 -- Path is configured by the user
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(string(data)), nil
+	}, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReloadingFile{path: path, content: content}, nil
+}
+
+// Content returns the current content of the file, the previous content if the file cannot be read
+func (f *ReloadingFile) Content() string {
+	return f.content.get()
+}
+
+// Path returns the path of the file
+func (f *ReloadingFile) Path() string {
+	return f.path
+}
+
+// HeaderFiles provides header values read from files, e.g. projected service account tokens.
+// The files are read again when they change.
+type HeaderFiles struct {
+	headers []fileHeader
+}
+
+// fileHeader is a header whose value is the prefix followed by the trimmed content of a file
+type fileHeader struct {
+	name   string
+	prefix string
+	value  *ReloadingFile
+}
+
+// headerFileItem is the JSON representation of a header read from a file with a value prefix
+type headerFileItem struct {
+	File   string `json:"file"`
+	Prefix string `json:"prefix"`
+}
+
+// Values returns the current header values
+func (h *HeaderFiles) Values() map[string]string {
+	values := make(map[string]string, len(h.headers))
+	for _, header := range h.headers {
+		values[header.name] = header.prefix + header.value.Content()
+	}
+
+	return values
+}
+
+// processHeaderFiles parses the HeaderFiles JSON object, mapping header names to files
+// or to objects with the file and a prefix of the value, e.g. {"file": "/var/run/secrets/token", "prefix": "Bearer "}
+func processHeaderFiles(config *Config, configMap map[string]any) error {
+	value, ok := configMap["headerfiles"].(string)
+	if !ok || value == "" {
+		return nil
+	}
+
+	if len(value) > MaxJSONSize {
+		return fmt.Errorf("field HeaderFiles JSON exceeds maximum size of %d bytes", MaxJSONSize)
+	}
+
+	var items map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(value), &items); err != nil {
+		return fmt.Errorf("failed to parse HeaderFiles JSON: %w", err)
+	}
+
+	headerFiles := &HeaderFiles{}
+	for name, raw := range items {
+		var item headerFileItem
+		if err := json.Unmarshal(raw, &item.File); err != nil {
+			if err := json.Unmarshal(raw, &item); err != nil {
+				return fmt.Errorf("invalid HeaderFiles entry %q, expected a file or an object with file and prefix", name)
+			}
+		}
+		if item.File == "" {
+			return fmt.Errorf("invalid HeaderFiles entry %q, file must not be empty", name)
+		}
+		for header := range config.OTLPConfig.Headers {
+			if strings.EqualFold(header, name) {
+				return fmt.Errorf("header %q is configured in Headers and HeaderFiles", name)
+			}
+		}
+
+		header, err := NewReloadingFile(item.File)
+		if err != nil {
+			return fmt.Errorf("failed to read header file of %q: %w", name, err)
+		}
+		headerFiles.headers = append(headerFiles.headers, fileHeader{name: name, prefix: item.Prefix, value: header})
+	}
+	config.OTLPConfig.HeaderFiles = headerFiles
+
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// processFailoverConfig normalizes and validates the failover endpoints of the OTLP clients
+func processFailoverConfig(config *Config, _ map[string]any) error {
+	otlp := &config.OTLPConfig
+	if len(otlp.FailoverEndpoints) == 0 {
+		otlp.FailoverEndpoints = nil
+
+		return nil
+	}
+
+	seen := map[string]bool{otlp.Endpoint: true}
+	if otlp.EndpointURL != "" {
+		seen[otlp.EndpointURL] = true
+	}
+	endpoints := make([]string, 0, len(otlp.FailoverEndpoints))
+	for _, endpoint := range otlp.FailoverEndpoints {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint == "" {
+			return fmt.Errorf("invalid FailoverEndpoints %q, endpoints must not be empty", strings.Join(otlp.FailoverEndpoints, ","))
+		}
+		if strings.Contains(endpoint, "://") && !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+			return fmt.Errorf("invalid FailoverEndpoints entry %q, expected host:port or an http:// or https:// URL", endpoint)
+		}
+		// Without SNI the certificates of endpoints addressed by IP are verified against TLSServerName,
+		// which defaults to the host of the primary endpoint
+		if otlp.TLSCAFile != "" && otlp.TLSServerName == "" && !otlp.TLSInsecureSkipVerify && !otlp.Insecure &&
+			!strings.HasPrefix(endpoint, "http://") && net.ParseIP(hostOf(endpoint)) != nil {
+			return fmt.Errorf("FailoverEndpoints entry %q is an IP address and requires TLSServerName with TLSCAFile", endpoint)
+		}
+		if seen[endpoint] {
+			return fmt.Errorf("duplicate FailoverEndpoints entry %q", endpoint)
+		}
+		seen[endpoint] = true
+		endpoints = append(endpoints, endpoint)
+	}
+	otlp.FailoverEndpoints = endpoints
+
+	if otlp.FailoverFailureThreshold < 1 {
+		return fmt.Errorf("FailoverFailureThreshold must be at least 1, got %d", otlp.FailoverFailureThreshold)
+	}
+	if otlp.FailoverCooldown <= 0 {
+		return fmt.Errorf("FailoverCooldown must be positive, got %v", otlp.FailoverCooldown)
+	}
+
+	return nil
+}
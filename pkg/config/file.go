@@ -0,0 +1,60 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Supported formats of the file client
+const (
+	// FileFormatJSON writes one JSON object per record, like the stdout client
+	FileFormatJSON = "json"
+	// FileFormatOTLPJSON writes one OTLP/JSON ExportLogsServiceRequest per record
+	FileFormatOTLPJSON = "otlp_json"
+)
+
+// FileConfig holds the configuration of the file client
+type FileConfig struct {
+	// Path is the file the records are written to
+	Path string `mapstructure:"FilePath"`
+	// Format is the format of the written records, json or otlp_json
+	Format string `mapstructure:"FileFormat"`
+	// MaxSize is the size in bytes after which the file is rotated, 0 disables size based rotation
+	MaxSize int64 `mapstructure:"FileMaxSize"`
+	// RotationInterval is the age after which the file is rotated, 0 disables time based rotation
+	RotationInterval time.Duration `mapstructure:"FileRotationInterval"`
+	// MaxFiles is the number of rotated files kept, 0 keeps all rotated files
+	MaxFiles int `mapstructure:"FileMaxFiles"`
+	// Compress enables gzip compression of rotated files
+	Compress bool `mapstructure:"FileCompress"`
+}
+
+// DefaultFileConfig holds the default configuration of the file client
+var DefaultFileConfig = FileConfig{
+	Format:   FileFormatJSON,
+	MaxSize:  100 * 1024 * 1024, // 100 MiB
+	MaxFiles: 5,
+}
+
+// processFileConfig validates the file client configuration
+func processFileConfig(config *Config, _ map[string]any) error {
+	file := &config.FileConfig
+
+	file.Format = strings.ToLower(file.Format)
+	switch file.Format {
+	case FileFormatJSON, FileFormatOTLPJSON:
+	default:
+		return fmt.Errorf("invalid FileFormat %q, supported formats are %s, %s", file.Format, FileFormatJSON, FileFormatOTLPJSON)
+	}
+
+	if file.MaxSize < 0 || file.RotationInterval < 0 || file.MaxFiles < 0 {
+		return errors.New("FileMaxSize, FileRotationInterval and FileMaxFiles must not be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FilterOperator compares a record field with the value of a filter condition
+type FilterOperator string
+
+// Supported filter operators
+const (
+	// FilterEqual matches fields equal to the value
+	FilterEqual FilterOperator = "=="
+	// FilterNotEqual matches missing fields and fields not equal to the value
+	FilterNotEqual FilterOperator = "!="
+	// FilterMatch matches fields matching the regular expression
+	FilterMatch FilterOperator = "=~"
+	// FilterNotMatch matches missing fields and fields not matching the regular expression
+	FilterNotMatch FilterOperator = "!~"
+)
+
+// FilterCondition compares the record field addressed by Field with Value.
+// Fields are addressed like in the record processors, nested fields by their path separated with ".".
+type FilterCondition struct {
+	Field    string
+	Operator FilterOperator
+	Value    string
+	// Regex is the compiled Value of the =~ and !~ operators
+	Regex *regexp.Regexp
+}
+
+// Filter is a parsed filter expression. A record matches if all conditions of any group match.
+type Filter struct {
+	Expression string
+	Groups     [][]FilterCondition
+}
+
+// ParseFilter parses a filter expression of conditions "field operator value" combined with && and ||,
+// where && binds stronger than ||. The operators are ==, !=, =~ and !~.
+// Tokens are separated by spaces, values containing spaces are written as double quoted Go strings.
+func ParseFilter(expression string) (*Filter, error) {
+	tokens, err := filterTokens(expression)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("filter expression is empty")
+	}
+
+	filter := &Filter{Expression: expression}
+	var group []FilterCondition
+	for len(tokens) > 0 {
+		if len(tokens) < 3 {
+			return nil, fmt.Errorf("incomplete filter condition %q, expected field, operator and value", strings.Join(tokens, " "))
+		}
+		condition := FilterCondition{Field: tokens[0], Operator: FilterOperator(tokens[1]), Value: tokens[2]}
+		switch condition.Operator {
+		case FilterEqual, FilterNotEqual:
+		case FilterMatch, FilterNotMatch:
+			if condition.Regex, err = regexp.Compile(condition.Value); err != nil {
+				return nil, fmt.Errorf("invalid regular expression of field %q: %w", condition.Field, err)
+			}
+		default:
+			return nil, fmt.Errorf("unknown filter operator %q, supported operators are ==, !=, =~, !~", condition.Operator)
+		}
+		group = append(group, condition)
+		tokens = tokens[3:]
+
+		if len(tokens) == 0 {
+			break
+		}
+		switch tokens[0] {
+		case "&&":
+		case "||":
+			filter.Groups = append(filter.Groups, group)
+			group = nil
+		default:
+			return nil, fmt.Errorf("unexpected %q after filter condition, expected && or ||", tokens[0])
+		}
+		if tokens = tokens[1:]; len(tokens) == 0 {
+			return nil, errors.New("filter expression ends with an operator")
+		}
+	}
+	filter.Groups = append(filter.Groups, group)
+
+	return filter, nil
+}
+
+// filterTokens splits the expression at spaces, keeping double quoted strings together and unquoting them
+func filterTokens(expression string) ([]string, error) {
+	var tokens []string
+	rest := strings.TrimSpace(expression)
+	for rest != "" {
+		var token string
+		if rest[0] == '"' {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted string in filter expression: %w", err)
+			}
+			if token, err = strconv.Unquote(quoted); err != nil {
+				return nil, fmt.Errorf("invalid quoted string in filter expression: %w", err)
+			}
+			rest = rest[len(quoted):]
+			if rest != "" && !unicode.IsSpace(rune(rest[0])) {
+				return nil, fmt.Errorf("missing space after quoted string %s", quoted)
+			}
+		} else {
+			end := strings.IndexFunc(rest, unicode.IsSpace)
+			if end < 0 {
+				end = len(rest)
+			}
+			token, rest = rest[:end], rest[end:]
+		}
+		tokens = append(tokens, token)
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Supported value encodings of the Kafka client
+const (
+	// KafkaEncodingOTLPProto encodes each record as protobuf ExportLogsServiceRequest
+	KafkaEncodingOTLPProto = "otlp_proto"
+	// KafkaEncodingOTLPJSON encodes each record as OTLP/JSON ExportLogsServiceRequest
+	KafkaEncodingOTLPJSON = "otlp_json"
+)
+
+// Supported SASL mechanisms of the Kafka client
+const (
+	KafkaSASLPlain       = "PLAIN"
+	KafkaSASLScramSHA256 = "SCRAM-SHA-256"
+	KafkaSASLScramSHA512 = "SCRAM-SHA-512"
+)
+
+// DefaultKafkaTopic is the default topic template
+const DefaultKafkaTopic = "fluent-bit-logs"
+
+// DefaultKafkaKey is the default attribute used as message key, so the records of a pod keep their order
+const DefaultKafkaKey = "k8s.pod.uid"
+
+// kafkaTopicTemplate matches topic templates, topic characters with {attribute} placeholders
+var kafkaTopicTemplate = regexp.MustCompile(`^([a-zA-Z0-9._-]|\{[^{}]+\})+$`)
+
+// KafkaConfig holds the configuration of the Kafka producer client
+type KafkaConfig struct {
+	// Brokers are the bootstrap brokers as host:port - processed from KafkaBrokers
+	Brokers []string `mapstructure:"-"`
+	// Topic is the topic template, {attribute} placeholders are replaced with record or resource attribute values
+	Topic string `mapstructure:"KafkaTopic"`
+	// Key is the attribute used as message key, messages without key are distributed round robin
+	Key string `mapstructure:"KafkaKey"`
+	// Encoding is the message value encoding, otlp_proto or otlp_json
+	Encoding string `mapstructure:"KafkaEncoding"`
+	// RequiredAcks is the number of acknowledgements required from the brokers, -1 for all in-sync replicas
+	RequiredAcks int `mapstructure:"KafkaRequiredAcks"`
+	// AllowAutoTopicCreation lets the brokers create missing topics, e.g. of new namespaces in the topic template
+	AllowAutoTopicCreation bool `mapstructure:"KafkaAllowAutoTopicCreation"`
+	// SASLMechanism enables SASL authentication with PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512
+	SASLMechanism string `mapstructure:"KafkaSASLMechanism"`
+	// SASLUsername and SASLPassword are the SASL credentials
+	SASLUsername string `mapstructure:"KafkaSASLUsername"`
+	SASLPassword string `mapstructure:"KafkaSASLPassword"`
+}
+
+// DefaultKafkaConfig holds the default configuration of the Kafka client
+var DefaultKafkaConfig = KafkaConfig{
+	Topic:        DefaultKafkaTopic,
+	Key:          DefaultKafkaKey,
+	Encoding:     KafkaEncodingOTLPProto,
+	RequiredAcks: -1,
+}
+
+// processKafkaConfig parses the broker list and validates the Kafka client configuration
+func processKafkaConfig(config *Config, configMap map[string]any) error {
+	kafka := &config.KafkaConfig
+
+	if brokers, ok := configMap["kafkabrokers"].(string); ok && brokers != "" {
+		kafka.Brokers = nil
+		for broker := range strings.SplitSeq(brokers, ",") {
+			broker = strings.TrimSpace(broker)
+			if broker == "" {
+				continue
+			}
+			if !strings.Contains(broker, ":") {
+				return fmt.Errorf("invalid KafkaBrokers broker %q, expected host:port", broker)
+			}
+			kafka.Brokers = append(kafka.Brokers, broker)
+		}
+	}
+
+	if !kafkaTopicTemplate.MatchString(kafka.Topic) {
+		return fmt.Errorf("invalid KafkaTopic: %s", kafka.Topic)
+	}
+
+	kafka.Encoding = strings.ToLower(kafka.Encoding)
+	switch kafka.Encoding {
+	case KafkaEncodingOTLPProto, KafkaEncodingOTLPJSON:
+	default:
+		return fmt.Errorf("invalid KafkaEncoding %q, supported encodings are %s, %s", kafka.Encoding, KafkaEncodingOTLPProto, KafkaEncodingOTLPJSON)
+	}
+
+	switch kafka.RequiredAcks {
+	case -1, 0, 1:
+	default:
+		return fmt.Errorf("invalid KafkaRequiredAcks %d, must be -1, 0 or 1", kafka.RequiredAcks)
+	}
+
+	kafka.SASLMechanism = strings.ToUpper(kafka.SASLMechanism)
+	switch kafka.SASLMechanism {
+	case "":
+	case KafkaSASLPlain, KafkaSASLScramSHA256, KafkaSASLScramSHA512:
+		if kafka.SASLUsername == "" {
+			return errors.New("KafkaSASLMechanism requires KafkaSASLUsername")
+		}
+	default:
+		return fmt.Errorf("invalid KafkaSASLMechanism %q, supported mechanisms are %s, %s, %s",
+			kafka.SASLMechanism, KafkaSASLPlain, KafkaSASLScramSHA256, KafkaSASLScramSHA512)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// KubernetesAttributesConfig controls which kubernetes metadata is promoted to OTLP attributes
+type KubernetesAttributesConfig struct {
+	// PodLabels selects the pod labels promoted to k8s.pod.label.<key> attributes
+	PodLabels KeyFilter
+	// PodAnnotations selects the pod annotations promoted to k8s.pod.annotation.<key> attributes
+	PodAnnotations KeyFilter
+	// WorkloadAttributes enables deriving k8s.deployment.name, k8s.statefulset.name and
+	// other workload attributes from owner references, labels and pod names
+	WorkloadAttributes bool
+}
+
+// KeyFilter selects keys by allow and deny lists.
+// Entries are exact keys or prefixes ending with "*", a single "*" matches all keys.
+// A key is selected when it matches the allow list and does not match the deny list.
+type KeyFilter struct {
+	Allow []string
+	Deny  []string
+}
+
+// Matches reports whether the key is selected by the filter
+func (f KeyFilter) Matches(key string) bool {
+	return matchesAny(f.Allow, key) && !matchesAny(f.Deny, key)
+}
+
+// IsEmpty reports whether the filter selects no key at all
+func (f KeyFilter) IsEmpty() bool {
+	return len(f.Allow) == 0
+}
+
+func matchesAny(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+
+			continue
+		}
+		if pattern == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// processKubernetesAttributesConfig handles the kubernetes attributes configuration fields
+func processKubernetesAttributesConfig(config *Config, configMap map[string]any) error {
+	k8s := &config.OTLPConfig.KubernetesAttributes
+
+	k8s.PodLabels.Allow = parseListField(configMap, "k8spodlabelsallowlist", k8s.PodLabels.Allow)
+	k8s.PodLabels.Deny = parseListField(configMap, "k8spodlabelsdenylist", k8s.PodLabels.Deny)
+	k8s.PodAnnotations.Allow = parseListField(configMap, "k8spodannotationsallowlist", k8s.PodAnnotations.Allow)
+	k8s.PodAnnotations.Deny = parseListField(configMap, "k8spodannotationsdenylist", k8s.PodAnnotations.Deny)
+
+	if workloadAttributes, ok := configMap["k8sworkloadattributes"].(string); ok && workloadAttributes != "" {
+		boolVal, err := strconv.ParseBool(workloadAttributes)
+		if err != nil {
+			return fmt.Errorf("failed to parse K8sWorkloadAttributes as boolean: %w", err)
+		}
+		k8s.WorkloadAttributes = boolVal
+	}
+
+	return nil
+}
+
+// parseListField parses a comma separated list, returning current when the key is not set
+func parseListField(configMap map[string]any, key string, current []string) []string {
+	value, ok := configMap[key].(string)
+	if !ok || value == "" {
+		return current
+	}
+
+	list := make([]string, 0, strings.Count(value, ",")+1)
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
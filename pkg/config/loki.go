@@ -0,0 +1,78 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+)
+
+// DefaultLokiPushPath is the path of the Loki push API
+const DefaultLokiPushPath = "/loki/api/v1/push"
+
+// lokiLabelName matches valid Loki label names
+var lokiLabelName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// DefaultLokiLabels maps the Kubernetes attributes to the labels used by the former Vali output plugin
+var DefaultLokiLabels = map[string]string{
+	"k8s.namespace.name": "namespace_name",
+	"k8s.pod.name":       "pod_name",
+	"k8s.container.name": "container_name",
+	"k8s.node.name":      "nodename",
+	"origin":             "origin",
+}
+
+// LokiConfig holds the configuration of the Loki/Vali push API client
+type LokiConfig struct {
+	// PushPath is the path of the push API, used when no EndpointURL is configured
+	PushPath string `mapstructure:"LokiPushPath"`
+	// TenantID is sent as X-Scope-OrgID header when set
+	TenantID string `mapstructure:"LokiTenantID"`
+	// Labels maps record and resource attribute keys to stream label names - processed from LokiLabels
+	Labels map[string]string `mapstructure:"-"`
+	// StructuredMetadata sends the attributes which are not labels as structured metadata (Loki 3.0+)
+	StructuredMetadata bool `mapstructure:"LokiStructuredMetadata"`
+}
+
+// DefaultLokiConfig holds the default configuration of the Loki client
+var DefaultLokiConfig = LokiConfig{
+	PushPath: DefaultLokiPushPath,
+}
+
+// processLokiConfig parses the label mapping and validates the Loki client configuration
+func processLokiConfig(config *Config, configMap map[string]any) error {
+	loki := &config.LokiConfig
+
+	if !strings.HasPrefix(loki.PushPath, "/") || strings.ContainsAny(loki.PushPath, " :") {
+		return fmt.Errorf("invalid LokiPushPath: %s", loki.PushPath)
+	}
+
+	labels, ok := configMap["lokilabels"].(string)
+	if !ok || labels == "" {
+		loki.Labels = maps.Clone(DefaultLokiLabels)
+
+		return nil
+	}
+
+	if len(labels) > MaxJSONSize {
+		return fmt.Errorf("field LokiLabels JSON exceeds maximum size of %d bytes", MaxJSONSize)
+	}
+
+	var labelMap map[string]string
+	if err := json.Unmarshal([]byte(labels), &labelMap); err != nil {
+		return fmt.Errorf("failed to parse LokiLabels JSON: %w", err)
+	}
+
+	for key, name := range labelMap {
+		if !lokiLabelName.MatchString(name) {
+			return fmt.Errorf("invalid LokiLabels label name %q for attribute %q", name, key)
+		}
+	}
+	loki.Labels = labelMap
+
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+// destinationNameRegex matches valid destination names, they are used in metric labels and queue names
+var destinationNameRegex = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// MultiConfig holds the configuration of the multi client fanning out to several destinations
+type MultiConfig struct {
+	// Destinations are the destination clients - processed from the Destinations JSON array
+	Destinations []DestinationConfig `mapstructure:"-"`
+}
+
+// DestinationConfig holds the configuration of a single destination of the multi client
+type DestinationConfig struct {
+	// Name identifies the destination in logs and metrics
+	Name string
+	// Type is the client type of the destination
+	Type types.Type
+	// Filter selects the records sent to the destination, all records are sent if it is nil
+	Filter *Filter
+	// Config is the client configuration of the destination, the plugin configuration merged with the destination overrides
+	Config *Config
+}
+
+// processMultiConfig parses the destinations of the multi client from the Destinations JSON array.
+// Each destination is an object with name, type and an optional filter expression,
+// all other keys override the plugin configuration for the destination, e.g. Endpoint.
+func processMultiConfig(config *Config, configMap map[string]any) error {
+	seedType := types.ClientTypeFromString(config.PluginConfig.SeedType)
+	if types.ClientTypeFromString(config.PluginConfig.ShootType) == types.MULTI {
+		return errors.New("ShootType multi is not supported, shoot clients are created per cluster by the controller")
+	}
+
+	value, _ := configMap["destinations"].(string)
+	if value == "" {
+		if seedType == types.MULTI {
+			return errors.New("SeedType multi requires Destinations")
+		}
+
+		return nil
+	}
+	if seedType != types.MULTI {
+		return errors.New("Destinations require SeedType multi")
+	}
+
+	if len(value) > MaxJSONSize {
+		return fmt.Errorf("Destinations JSON exceeds maximum size of %d bytes", MaxJSONSize)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(value)))
+	decoder.UseNumber()
+	var items []map[string]any
+	if err := decoder.Decode(&items); err != nil {
+		return fmt.Errorf("failed to parse Destinations JSON: %w", err)
+	}
+	if len(items) == 0 {
+		return errors.New("Destinations must not be empty")
+	}
+
+	// The destinations inherit the plugin configuration except for the multi client settings
+	base := maps.Clone(configMap)
+	delete(base, "destinations")
+	delete(base, "shoottype")
+
+	destinations := make([]DestinationConfig, 0, len(items))
+	names := make(map[string]bool, len(items))
+	for i, item := range items {
+		destination, err := parseDestination(base, item)
+		if err != nil {
+			if destination.Name != "" {
+				return fmt.Errorf("invalid Destinations entry %q: %w", destination.Name, err)
+			}
+
+			return fmt.Errorf("invalid Destinations entry %d: %w", i, err)
+		}
+		if names[destination.Name] {
+			return fmt.Errorf("duplicate Destinations name %q", destination.Name)
+		}
+		names[destination.Name] = true
+		destinations = append(destinations, destination)
+	}
+	config.MultiConfig.Destinations = destinations
+
+	return nil
+}
+
+// parseDestination parses a single destination, merging its overrides into a copy of the base configuration map
+func parseDestination(base map[string]any, item map[string]any) (DestinationConfig, error) {
+	destination := DestinationConfig{}
+	overrides := make(map[string]any, len(item))
+	// The name is read first, so that the errors of the other keys can name the destination
+	for key, value := range item {
+		if strings.EqualFold(key, "name") {
+			destination.Name, _ = value.(string)
+		}
+	}
+	for key, value := range item {
+		switch strings.ToLower(key) {
+		case "name": // Read above
+		case "type":
+			t, _ := value.(string)
+			destination.Type = types.ClientTypeFromString(t)
+			// ClientTypeFromString falls back to noop for unknown types
+			if destination.Type == types.NOOP && !strings.EqualFold(t, types.NOOP.String()) {
+				destination.Type = types.Unknown
+			}
+		case "filter":
+			expression, _ := value.(string)
+			if expression == "" {
+				continue
+			}
+			filter, err := ParseFilter(expression)
+			if err != nil {
+				return destination, fmt.Errorf("invalid filter: %w", err)
+			}
+			destination.Filter = filter
+		default:
+			override, err := overrideString(value)
+			if err != nil {
+				return destination, fmt.Errorf("invalid value of %s: %w", key, err)
+			}
+			overrides[key] = override
+		}
+	}
+
+	if !destinationNameRegex.MatchString(destination.Name) {
+		return destination, fmt.Errorf("invalid name %q, expected lowercase letters, digits, '-' and '_'", destination.Name)
+	}
+	if destination.Type == types.Unknown || destination.Type == types.MULTI {
+		return destination, fmt.Errorf("invalid type %q", item["type"])
+	}
+
+	configMap := maps.Clone(base)
+	maps.Copy(configMap, normalizeConfigMapKeys(overrides))
+	configMap["seedtype"] = destination.Type.String()
+
+	cfg, err := ParseConfig(configMap)
+	if err != nil {
+		return destination, err
+	}
+	destination.Config = cfg
+
+	return destination, nil
+}
+
+// overrideString returns the string representation of a destination override,
+// the configuration values of the plugin are strings
+func overrideString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number, bool:
+		return fmt.Sprint(v), nil
+	case nil:
+		return "", nil
+	default:
+		data, err := json.Marshal(v)
+
+		return string(data), err
+	}
+}
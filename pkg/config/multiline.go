@@ -0,0 +1,99 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MultilineConfig holds the configuration of the multiline aggregator joining continuation lines,
+// e.g. of stack traces, with the preceding record of the same container stream
+type MultilineConfig struct {
+	// Rules match the log lines continuing the preceding line. The aggregator is disabled when empty.
+	Rules []MultilineRule `mapstructure:"-"`
+	// FlushTimeout is the time after which a pending record is flushed when no further line arrives
+	FlushTimeout time.Duration `mapstructure:"MultilineFlushTimeout"`
+	// MaxLines is the maximum number of lines joined into one record
+	MaxLines int `mapstructure:"MultilineMaxLines"`
+	// MaxBytes is the maximum size of the joined log lines of one record
+	MaxBytes int `mapstructure:"MultilineMaxBytes"`
+	// MaxStreams is the maximum number of container streams with pending records.
+	// Records of further streams are passed on without aggregation.
+	MaxStreams int `mapstructure:"MultilineMaxStreams"`
+}
+
+// Enabled reports whether multiline aggregation is configured
+func (m MultilineConfig) Enabled() bool {
+	return len(m.Rules) > 0
+}
+
+// MultilineRule matches the continuation lines of the records starting with a matching line
+type MultilineRule struct {
+	// Start matches the first line of the records the rule applies to, the rule applies to all records when nil
+	Start *regexp.Regexp
+	// Continuation matches the log lines continuing the record
+	Continuation *regexp.Regexp
+}
+
+// multilinePresets holds the continuation line rules of common stack trace formats
+var multilinePresets = map[string][]MultilineRule{
+	"go": {
+		// indented frames and "created by" lines, e.g. "\t/src/main.go:8 +0x1d"
+		{Continuation: regexp.MustCompile(`^(?:\s|created by )`)},
+		// goroutine dumps of panics, e.g. "goroutine 1 [running]:", "main.main()". Since these lines are not indented,
+		// they only continue records starting with a panic or goroutine header. A header always starts a new record.
+		{
+			Start:        regexp.MustCompile(`^(?:panic: |fatal error: |goroutine \d+ \[)`),
+			Continuation: regexp.MustCompile(`^(?:$|goroutine \d+ \[|\[signal |[\w./*()-]+\(.*\)$)`),
+		},
+	},
+	// java stack traces, e.g. "\tat com.example.Main.main(Main.java:10)", "Caused by: java.io.IOException"
+	"java": {{Continuation: regexp.MustCompile(`^(?:\s+at |\s+\.\.\. \d+ (?:more|common frames omitted)|\s*Caused by: |\s*Suppressed: |[\w$.]+(?:Exception|Error|Throwable)(?::|$))`)}},
+	// python tracebacks, e.g. "Traceback (most recent call last):", "  File \"main.py\", line 1", "ValueError: boom"
+	"python": {{Continuation: regexp.MustCompile(`^(?:\s|Traceback \(most recent call last\):|During handling of the above exception|The above exception was the direct cause|[\w.]+(?:Error|Exception|Warning|Exit|Interrupt)(?::|$))`)}},
+}
+
+// processMultilineConfig builds the continuation rules of the multiline aggregator from the presets and the custom regex
+func processMultilineConfig(config *Config, configMap map[string]any) error {
+	multiline := &config.PluginConfig.Multiline
+
+	if presets, ok := configMap["multilinepresets"].(string); ok && presets != "" {
+		for name := range strings.SplitSeq(presets, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			preset, ok := multilinePresets[name]
+			if !ok {
+				return fmt.Errorf("unknown MultilinePresets entry %q, supported presets are %s",
+					name, strings.Join(slices.Sorted(maps.Keys(multilinePresets)), ", "))
+			}
+			multiline.Rules = append(multiline.Rules, preset...)
+		}
+	}
+
+	if continuation, ok := configMap["multilinecontinuationregex"].(string); ok && continuation != "" {
+		re, err := regexp.Compile(continuation)
+		if err != nil {
+			return fmt.Errorf("failed to compile MultilineContinuationRegex: %w", err)
+		}
+		multiline.Rules = append(multiline.Rules, MultilineRule{Continuation: re})
+	}
+
+	if !multiline.Enabled() {
+		return nil
+	}
+
+	if multiline.FlushTimeout <= 0 {
+		return errors.New("MultilineFlushTimeout must be positive")
+	}
+	if multiline.MaxLines < 1 || multiline.MaxBytes < 1 || multiline.MaxStreams < 1 {
+		return errors.New("MultilineMaxLines, MultilineMaxBytes and MultilineMaxStreams must be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultOpenSearchIndex is the default index name template, a daily index like fluent-bit-2024.01.02
+const DefaultOpenSearchIndex = "fluent-bit-%Y.%m.%d"
+
+// OpenSearchConfig holds the configuration of the Elasticsearch/OpenSearch bulk API client
+type OpenSearchConfig struct {
+	// Index is the index name template, %Y, %m and %d are replaced with the UTC date of the record
+	Index string `mapstructure:"OpenSearchIndex"`
+	// Username and Password enable basic authentication
+	Username string `mapstructure:"OpenSearchUsername"`
+	Password string `mapstructure:"OpenSearchPassword"`
+	// APIKey enables API key authentication, the base64 encoded id:key pair
+	APIKey string `mapstructure:"OpenSearchAPIKey"`
+}
+
+// DefaultOpenSearchConfig holds the default configuration of the OpenSearch client
+var DefaultOpenSearchConfig = OpenSearchConfig{
+	Index: DefaultOpenSearchIndex,
+}
+
+// processOpenSearchConfig validates the OpenSearch client configuration
+func processOpenSearchConfig(config *Config, _ map[string]any) error {
+	opensearch := &config.OpenSearchConfig
+
+	if opensearch.Index == "" {
+		return errors.New("OpenSearchIndex must not be empty")
+	}
+	// Index names must be lowercase and must not contain these characters
+	name := strings.NewReplacer("%Y", "", "%m", "", "%d", "").Replace(opensearch.Index)
+	if name != strings.ToLower(name) || strings.ContainsAny(name, ` "*\<|,>/?#:`) {
+		return fmt.Errorf("invalid OpenSearchIndex: %s", opensearch.Index)
+	}
+
+	if opensearch.APIKey != "" && (opensearch.Username != "" || opensearch.Password != "") {
+		return errors.New("OpenSearchAPIKey and OpenSearchUsername/OpenSearchPassword are mutually exclusive")
+	}
+	if opensearch.Password != "" && opensearch.Username == "" {
+		return errors.New("OpenSearchPassword requires OpenSearchUsername")
+	}
+
+	return nil
+}
@@ -4,9 +4,18 @@ package config
 
 import (
 	"crypto/tls"
+	"net/url"
 	"time"
 )
 
+// Supported request encodings of the OTLP HTTP client
+const (
+	// OTLPEncodingProtobuf sends binary protobuf requests
+	OTLPEncodingProtobuf = "protobuf"
+	// OTLPEncodingJSON sends OTLP/JSON requests
+	OTLPEncodingJSON = "json"
+)
+
 // DQueConfig contains the dqueue settings
 type DQueConfig struct {
 	DQueDir         string `mapstructure:"DQueDir"`
@@ -29,18 +38,22 @@ type OTLPConfig struct {
 	EndpointURL     string            `mapstructure:"EndpointURL"`
 	EndpointURLPath string            `mapstructure:"EndpointURLPath"`
 	Insecure        bool              `mapstructure:"Insecure"`
-	Compression     int               `mapstructure:"Compression"`
+	Compression     string            `mapstructure:"Compression"` // none, gzip, zstd or snappy, supported compressions depend on the client type
+	Encoding        string            `mapstructure:"Encoding"`    // Request encoding of the otlp_http client, protobuf or json
 	Timeout         time.Duration     `mapstructure:"Timeout"`
 	Headers         map[string]string `mapstructure:"-"` // Handled manually in processOTLPConfig
+	HeaderFiles     *HeaderFiles      `mapstructure:"-"` // Headers with values read from files, handled in processHeaderFiles
 
 	DQueConfig DQueConfig `mapstructure:",squash"`
 
 	// Batch Processor configuration fields
-	DQueBatchProcessorMaxQueueSize     int           `mapstructure:"DQueBatchProcessorMaxQueueSize"`
-	DQueBatchProcessorMaxBatchSize     int           `mapstructure:"DQueBatchProcessorMaxBatchSize"`
-	DQueBatchProcessorExportTimeout    time.Duration `mapstructure:"DQueBatchProcessorExportTimeout"`
-	DQueBatchProcessorExportInterval   time.Duration `mapstructure:"DQueBatchProcessorExportInterval"`
-	DQueBatchProcessorExportBufferSize int           `mapstructure:"DQueBatchProcessorExportBufferSize"`
+	DQueBatchProcessorMaxQueueSize       int           `mapstructure:"DQueBatchProcessorMaxQueueSize"`
+	DQueBatchProcessorMaxBatchSize       int           `mapstructure:"DQueBatchProcessorMaxBatchSize"`
+	DQueBatchProcessorMaxBatchBytes      int           `mapstructure:"DQueBatchProcessorMaxBatchBytes"`      // Estimated bytes per batch, 0 means no limit
+	DQueBatchProcessorDeadLetterMaxBytes int           `mapstructure:"DQueBatchProcessorDeadLetterMaxBytes"` // Dead letter file size for records exceeding MaxBatchBytes, 0 drops them
+	DQueBatchProcessorExportTimeout      time.Duration `mapstructure:"DQueBatchProcessorExportTimeout"`
+	DQueBatchProcessorExportInterval     time.Duration `mapstructure:"DQueBatchProcessorExportInterval"`
+	DQueBatchProcessorExportBufferSize   int           `mapstructure:"DQueBatchProcessorExportBufferSize"`
 
 	// Retry configuration fields
 	RetryEnabled         bool          `mapstructure:"RetryEnabled"`
@@ -51,9 +64,62 @@ type OTLPConfig struct {
 	// RetryConfig - processed from the above fields
 	RetryConfig *RetryConfig `mapstructure:"-"`
 
+	// Failover configuration fields of the otlp_grpc and otlp_http clients
+	// FailoverEndpoints are tried in order when the exports to Endpoint fail
+	FailoverEndpoints        []string      `mapstructure:"FailoverEndpoints"`
+	FailoverFailureThreshold int           `mapstructure:"FailoverFailureThreshold"` // Consecutive failures opening the circuit of an endpoint
+	FailoverCooldown         time.Duration `mapstructure:"FailoverCooldown"`         // Time an open circuit skips the endpoint
+
+	// Authentication configuration fields of the otlp_grpc and otlp_http clients
+	// Authenticator sets the Authorization header of the requests: none, oauth2, kubernetes or basic
+	Authenticator          string   `mapstructure:"Authenticator"`
+	OAuth2TokenURL         string   `mapstructure:"OAuth2TokenURL"` // Token endpoint of the OAuth2 client credentials flow
+	OAuth2ClientID         string   `mapstructure:"OAuth2ClientID"`
+	OAuth2ClientSecretFile string   `mapstructure:"OAuth2ClientSecretFile"`
+	OAuth2Scopes           []string `mapstructure:"OAuth2Scopes"`
+	KubernetesTokenFile    string   `mapstructure:"KubernetesTokenFile"` // Projected service account token sent as bearer token
+	BasicAuthUsername      string   `mapstructure:"BasicAuthUsername"`
+	BasicAuthPasswordFile  string   `mapstructure:"BasicAuthPasswordFile"`
+
+	// Body configuration fields
+	// When StructuredBody is true, map and slice "log"/"message" values are sent as structured OTLP bodies
+	StructuredBody bool `mapstructure:"StructuredBody"`
+	MaxBodySize    int  `mapstructure:"MaxBodySize"` // Maximum size in bytes of bodies serialized from maps or byte slices, 0 means no limit
+	// Maximum serialized size in bytes of structured bodies, larger ones fall back to truncated strings, 0 means no limit
+	MaxStructuredBodySize int `mapstructure:"MaxStructuredBodySize"`
+
+	// When ResourceGrouping is true, records are grouped per pod/container resource
+	// and the Kubernetes attributes are set on the resource instead of every record
+	ResourceGrouping bool `mapstructure:"ResourceGrouping"`
+
+	// Trace context extraction fields
+	// Trace and span IDs found in these record fields are set on the OTLP log records
+	TraceContextEnabled     bool   `mapstructure:"TraceContextEnabled"`
+	TraceIDField            string `mapstructure:"TraceIDField"`
+	SpanIDField             string `mapstructure:"SpanIDField"`
+	TraceFlagsField         string `mapstructure:"TraceFlagsField"`
+	TraceParentField        string `mapstructure:"TraceParentField"`        // W3C traceparent header value
+	TraceContextObjectField string `mapstructure:"TraceContextObjectField"` // Nested object holding the trace context fields
+
+	// Kubernetes attributes configuration - processed from K8sPodLabelsAllowList, K8sPodLabelsDenyList,
+	// K8sPodAnnotationsAllowList, K8sPodAnnotationsDenyList and K8sWorkloadAttributes
+	KubernetesAttributes KubernetesAttributesConfig `mapstructure:"-"`
+
+	// Severity mapping rules - processed from SeverityPresets, SeverityFields, SeverityBodyRegex,
+	// SeverityValues and SeverityNumericValues
+	SeverityConfig SeverityConfig `mapstructure:"-"`
+
 	// Throttle configuration fields
 	ThrottleEnabled        bool `mapstructure:"ThrottleEnabled"`
 	ThrottleRequestsPerSec int  `mapstructure:"ThrottleRequestsPerSec"` // Maximum requests per second, 0 means no limit
+	// ThrottleBytesPerSec limits the estimated record bytes per second of the otlp_grpc and otlp_http clients, 0 means no limit
+	ThrottleBytesPerSec int `mapstructure:"ThrottleBytesPerSec"`
+	// When ThrottleAdaptive is true, the otlp_grpc and otlp_http clients lower the limits when the backend throttles the exports
+	// and raise them again up to the configured limits when it accepts them
+	ThrottleAdaptive                bool          `mapstructure:"ThrottleAdaptive"`
+	ThrottleAdaptiveMinPercent      int           `mapstructure:"ThrottleAdaptiveMinPercent"`      // Lowest limit in percent of the configured limits
+	ThrottleAdaptiveIncreasePercent int           `mapstructure:"ThrottleAdaptiveIncreasePercent"` // Increase per interval in percent of the configured limits
+	ThrottleAdaptiveInterval        time.Duration `mapstructure:"ThrottleAdaptiveInterval"`        // Interval of the increases and minimum time between decreases
 
 	// SDK BatchProcessor configuration fields
 	// When UseSDKBatchProcessor is true, uses OTEL SDK BatchProcessor instead of DQueBatchProcessor
@@ -75,41 +141,84 @@ type OTLPConfig struct {
 
 	// TLS configuration - processed from the above fields
 	TLSConfig *tls.Config `mapstructure:"-"`
+
+	// HTTP proxy configuration fields of the otlp_http client
+	// When HTTPProxy is empty, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used
+	HTTPProxy                      string `mapstructure:"HTTPProxy"`   // http:// or https:// proxy URL, optionally with user:password
+	HTTPNoProxy                    string `mapstructure:"HTTPNoProxy"` // Comma separated hosts, domains and CIDRs reached without the proxy
+	HTTPProxyTLSCAFile             string `mapstructure:"HTTPProxyTLSCAFile"`
+	HTTPProxyTLSServerName         string `mapstructure:"HTTPProxyTLSServerName"`
+	HTTPProxyTLSInsecureSkipVerify bool   `mapstructure:"HTTPProxyTLSInsecureSkipVerify"`
+
+	// HTTP proxy configuration - processed from the above fields
+	HTTPProxyURL       *url.URL    `mapstructure:"-"`
+	HTTPProxyTLSConfig *tls.Config `mapstructure:"-"` // Only set for https:// proxies
 }
 
 // DefaultOTLPConfig holds the default configuration for OTLP
 var DefaultOTLPConfig = OTLPConfig{
-	Endpoint:               "localhost:4317",
-	EndpointURL:            "",
-	EndpointURLPath:        "/v1/logs",
-	Insecure:               false,
-	Compression:            0, // No compression by default
-	Timeout:                30 * time.Second,
-	Headers:                make(map[string]string),
-	RetryEnabled:           true,
-	RetryInitialInterval:   5 * time.Second,
-	RetryMaxInterval:       30 * time.Second,
-	RetryMaxElapsedTime:    1 * time.Minute,
-	RetryConfig:            nil, // Will be built from other fields
-	ThrottleEnabled:        false,
-	ThrottleRequestsPerSec: 0, // No throttling by default
-	TLSCertFile:            "",
-	TLSKeyFile:             "",
-	TLSCAFile:              "",
-	TLSServerName:          "",
-	TLSInsecureSkipVerify:  false,
-	TLSMinVersion:          "1.2", // TLS 1.2 as default minimum
-	TLSMaxVersion:          "",    // Use Go's default maximum
-	TLSConfig:              nil,   // Will be built from other fields
+	Endpoint:                 "localhost:4317",
+	EndpointURL:              "",
+	EndpointURLPath:          "/v1/logs",
+	Insecure:                 false,
+	Compression:              CompressionNone,
+	Encoding:                 OTLPEncodingProtobuf,
+	Timeout:                  30 * time.Second,
+	Headers:                  make(map[string]string),
+	RetryEnabled:             true,
+	RetryInitialInterval:     5 * time.Second,
+	RetryMaxInterval:         30 * time.Second,
+	RetryMaxElapsedTime:      1 * time.Minute,
+	RetryConfig:              nil, // Will be built from other fields
+	FailoverEndpoints:        nil, // No failover by default
+	FailoverFailureThreshold: 3,
+	FailoverCooldown:         30 * time.Second,
+	Authenticator:            AuthenticatorNone,
+	KubernetesTokenFile:      DefaultKubernetesTokenFile,
+	StructuredBody:           false,
+	MaxBodySize:              1024,    // Bodies serialized from maps or byte slices are truncated at 1KiB
+	MaxStructuredBodySize:    1 << 20, // Structured bodies are kept up to 1MiB
+	ThrottleEnabled:          false,
+	ThrottleRequestsPerSec:   0, // No throttling by default
+	ThrottleBytesPerSec:      0,
+	ThrottleAdaptive:         false,
+
+	// Adaptive throttling halves the limits at most once per interval down to 10% and raises them by 5% per interval
+	ThrottleAdaptiveMinPercent:      10,
+	ThrottleAdaptiveIncreasePercent: 5,
+	ThrottleAdaptiveInterval:        time.Second,
+
+	TLSCertFile:           "",
+	TLSKeyFile:            "",
+	TLSCAFile:             "",
+	TLSServerName:         "",
+	TLSInsecureSkipVerify: false,
+	TLSMinVersion:         "1.2", // TLS 1.2 as default minimum
+	TLSMaxVersion:         "",    // Use Go's default maximum
+	TLSConfig:             nil,   // Will be built from other fields
+	HTTPProxy:             "",    // Use the proxy environment variables
 
 	DQueConfig: DefaultDQueConfig, // Use default dque config
 
+	// Trace context extraction defaults
+	TraceContextEnabled:     true,
+	TraceIDField:            "trace_id",
+	SpanIDField:             "span_id",
+	TraceFlagsField:         "trace_flags",
+	TraceParentField:        "traceparent",
+	TraceContextObjectField: "otel",
+
+	// Kubernetes attributes defaults - labels and annotations are only promoted when allow-listed
+	KubernetesAttributes: KubernetesAttributesConfig{WorkloadAttributes: true},
+
 	// Batch Processor defaults - tuned to prevent OOM under high load
-	DQueBatchProcessorMaxQueueSize:     512,              // Max records in queue before dropping
-	DQueBatchProcessorMaxBatchSize:     256,              // Max records per export batch
-	DQueBatchProcessorExportTimeout:    30 * time.Second, // Timeout for single export
-	DQueBatchProcessorExportInterval:   1 * time.Second,  // Flush interval
-	DQueBatchProcessorExportBufferSize: 10,
+	DQueBatchProcessorMaxQueueSize:       512,              // Max records in queue before dropping
+	DQueBatchProcessorMaxBatchSize:       256,              // Max records per export batch
+	DQueBatchProcessorMaxBatchBytes:      3 << 20,          // Below the default 4 MiB gRPC message limit of the collectors
+	DQueBatchProcessorDeadLetterMaxBytes: 64 << 20,         // Oversized records kept for inspection
+	DQueBatchProcessorExportTimeout:      30 * time.Second, // Timeout for single export
+	DQueBatchProcessorExportInterval:     1 * time.Second,  // Flush interval
+	DQueBatchProcessorExportBufferSize:   10,
 
 	// SDK BatchProcessor defaults (used when UseSDKBatchProcessor is true)
 	UseSDKBatchProcessor:       false,            // Default to DQueBatchProcessor for disk persistence
@@ -12,6 +12,10 @@ type PluginConfig struct {
 	KubernetesMetadata KubernetesMetadataExtraction `mapstructure:",squash"`
 	HostnameValue      string                       `mapstructure:"HostnameValue"`
 	Origin             string                       `mapstructure:"Origin"`
+	// Processors is the ordered chain of processors applied to records before routing
+	Processors []ProcessorConfig `mapstructure:"-"`
+	// Multiline configures joining continuation lines with the preceding record
+	Multiline MultilineConfig `mapstructure:",squash"`
 }
 
 // KubernetesMetadataExtraction holds kubernetes metadata extraction configuration
@@ -0,0 +1,164 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ProcessorType is the type of a record processor
+type ProcessorType string
+
+// Supported record processor types
+const (
+	// ProcessorDrop drops records whose field value matches a regular expression
+	ProcessorDrop ProcessorType = "drop"
+	// ProcessorRename renames a field
+	ProcessorRename ProcessorType = "rename"
+	// ProcessorRemove removes fields
+	ProcessorRemove ProcessorType = "remove"
+	// ProcessorAdd adds a field with a static value
+	ProcessorAdd ProcessorType = "add"
+	// ProcessorSeverityFloor drops records with a severity below a minimum
+	ProcessorSeverityFloor ProcessorType = "severity_floor"
+	// ProcessorRedact replaces secrets in string values
+	ProcessorRedact ProcessorType = "redact"
+)
+
+var processorTypes = []ProcessorType{ProcessorDrop, ProcessorRename, ProcessorRemove, ProcessorAdd, ProcessorSeverityFloor, ProcessorRedact}
+
+// ProcessorConfig holds the configuration of a single record processor.
+// Fields are addressed by name, nested fields by their path separated with ".", e.g. "kubernetes.namespace_name".
+type ProcessorConfig struct {
+	Type ProcessorType
+	// Field is the field the processor applies to. Used by drop, rename and add
+	Field string
+	// Fields are the fields removed by the remove processor
+	Fields []string
+	// Regex matches the field value of records dropped by the drop processor
+	Regex *regexp.Regexp
+	// To is the new name of the field renamed by the rename processor
+	To string
+	// Value is the value of the field added by the add processor
+	Value any
+	// Overwrite replaces existing fields with the added or renamed field
+	Overwrite bool
+	// Severity is the minimum OTLP severity number of records kept by the severity_floor processor
+	Severity int
+	// Redactions are the patterns applied by the redact processor
+	Redactions []Redaction
+	// Replacement replaces the secrets found by the redact processor
+	Replacement string
+}
+
+// processorItem is the JSON representation of a processor configuration
+type processorItem struct {
+	Type        string   `json:"type"`
+	Field       string   `json:"field"`
+	Fields      []string `json:"fields"`
+	Regex       string   `json:"regex"`
+	To          string   `json:"to"`
+	Value       any      `json:"value"`
+	Overwrite   bool     `json:"overwrite"`
+	Severity    any      `json:"severity"`
+	Detectors   []string `json:"detectors"`
+	Patterns    []string `json:"patterns"`
+	Replacement string   `json:"replacement"`
+}
+
+// processProcessorsConfig parses the ordered list of record processors from the Processors JSON array
+func processProcessorsConfig(config *Config, configMap map[string]any) error {
+	value, ok := configMap["processors"].(string)
+	if !ok || value == "" {
+		return nil
+	}
+
+	if len(value) > MaxJSONSize {
+		return fmt.Errorf("Processors JSON exceeds maximum size of %d bytes", MaxJSONSize)
+	}
+
+	var items []processorItem
+	if err := json.Unmarshal([]byte(value), &items); err != nil {
+		return fmt.Errorf("failed to parse Processors JSON: %w", err)
+	}
+
+	processors := make([]ProcessorConfig, 0, len(items))
+	for i, item := range items {
+		p, err := item.toProcessorConfig()
+		if err != nil {
+			return fmt.Errorf("invalid Processors entry %d: %w", i, err)
+		}
+		processors = append(processors, p)
+	}
+	config.PluginConfig.Processors = processors
+
+	return nil
+}
+
+func (item processorItem) toProcessorConfig() (ProcessorConfig, error) {
+	p := ProcessorConfig{
+		Type:      ProcessorType(strings.ToLower(item.Type)),
+		Field:     item.Field,
+		Fields:    item.Fields,
+		To:        item.To,
+		Value:     item.Value,
+		Overwrite: item.Overwrite,
+	}
+
+	switch p.Type {
+	case ProcessorDrop:
+		if p.Field == "" || item.Regex == "" {
+			return p, errors.New("drop processor requires field and regex")
+		}
+		re, err := regexp.Compile(item.Regex)
+		if err != nil {
+			return p, fmt.Errorf("failed to compile drop processor regex: %w", err)
+		}
+		p.Regex = re
+	case ProcessorRename:
+		if p.Field == "" || p.To == "" {
+			return p, errors.New("rename processor requires field and to")
+		}
+	case ProcessorRemove:
+		if len(p.Fields) == 0 {
+			return p, errors.New("remove processor requires fields")
+		}
+	case ProcessorAdd:
+		if p.Field == "" || p.Value == nil {
+			return p, errors.New("add processor requires field and value")
+		}
+	case ProcessorSeverityFloor:
+		severity, err := parseSeverity(item.Severity)
+		if err != nil {
+			return p, fmt.Errorf("severity_floor processor: %w", err)
+		}
+		p.Severity = severity
+	case ProcessorRedact:
+		redactions, err := buildRedactions(item.Detectors, item.Patterns)
+		if err != nil {
+			return p, err
+		}
+		if len(redactions) == 0 {
+			return p, errors.New("redact processor requires detectors or patterns")
+		}
+		p.Redactions = redactions
+		p.Replacement = item.Replacement
+		if p.Replacement == "" {
+			p.Replacement = DefaultRedactionReplacement
+		}
+	default:
+		supported := make([]string, 0, len(processorTypes))
+		for _, t := range processorTypes {
+			supported = append(supported, string(t))
+		}
+
+		return p, fmt.Errorf("unknown processor type %q, supported types are %s", item.Type, strings.Join(supported, ", "))
+	}
+
+	return p, nil
+}
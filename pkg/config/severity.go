@@ -0,0 +1,308 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// OTLP severity numbers of the first level of each severity range
+// https://opentelemetry.io/docs/specs/otel/logs/data-model/#field-severitynumber
+const (
+	SeverityTrace = 1
+	SeverityDebug = 5
+	SeverityInfo  = 9
+	SeverityWarn  = 13
+	SeverityError = 17
+	SeverityFatal = 21
+)
+
+// SeverityConfig holds the rules mapping log records to OTLP severity numbers
+type SeverityConfig struct {
+	// Fields are the record fields holding the log level, checked in order
+	Fields []string
+	// BodyPatterns extract the log level from the log body.
+	// The capture group named "level" is used if present, otherwise the first capture group.
+	BodyPatterns []*regexp.Regexp
+	// Values maps lowercase textual log levels to OTLP severity numbers
+	Values map[string]int
+	// NumericValues maps numeric log levels to OTLP severity numbers
+	NumericValues map[int]int
+	// Presets are the names of the presets merged into the rules
+	Presets []string
+}
+
+// IsEmpty reports whether no severity rules are configured
+func (s SeverityConfig) IsEmpty() bool {
+	return len(s.Fields) == 0 && len(s.BodyPatterns) == 0 && len(s.Values) == 0 && len(s.NumericValues) == 0
+}
+
+// DefaultSeverityConfig returns the severity rules used when no preset or custom rule is configured.
+// Numeric levels follow the syslog severity scale.
+func DefaultSeverityConfig() SeverityConfig {
+	return SeverityConfig{
+		Fields: []string{"level", "severity", "loglevel", "log_level", "lvl"},
+		Values: map[string]int{
+			"trace":       SeverityTrace,
+			"debug":       SeverityDebug,
+			"dbg":         SeverityDebug,
+			"info":        SeverityInfo,
+			"information": SeverityInfo,
+			"warn":        SeverityWarn,
+			"warning":     SeverityWarn,
+			"error":       SeverityError,
+			"err":         SeverityError,
+			"fatal":       SeverityFatal,
+			"critical":    SeverityFatal,
+			"crit":        SeverityFatal,
+		},
+		NumericValues: map[int]int{
+			0: SeverityFatal + 3, // Emergency
+			1: SeverityFatal + 3, // Alert
+			2: SeverityFatal,     // Critical
+			3: SeverityError,     // Error
+			4: SeverityWarn,      // Warning
+			5: SeverityInfo,      // Notice
+			6: SeverityInfo,      // Info
+			7: SeverityDebug,     // Debug
+		},
+	}
+}
+
+// severityPresets holds the built-in severity rules for common log formats
+var severityPresets = map[string]SeverityConfig{
+	// klog text format, e.g. "I0102 15:04:05.000000 1 main.go:10] message"
+	"klog": {
+		BodyPatterns: []*regexp.Regexp{regexp.MustCompile(`^(?P<level>[IWEF])\d{4} `)},
+		Values: map[string]int{
+			"i": SeverityInfo,
+			"w": SeverityWarn,
+			"e": SeverityError,
+			"f": SeverityFatal,
+		},
+	},
+	// logrus JSON and text formats, e.g. {"level":"warning"} or "time=... level=warning msg=..."
+	"logrus": {
+		Fields:       []string{"level"},
+		BodyPatterns: []*regexp.Regexp{regexp.MustCompile(`\blevel=(?P<level>[a-zA-Z]+)`)},
+		Values: map[string]int{
+			"panic":   SeverityFatal + 1,
+			"fatal":   SeverityFatal,
+			"error":   SeverityError,
+			"warning": SeverityWarn,
+			"info":    SeverityInfo,
+			"debug":   SeverityDebug,
+			"trace":   SeverityTrace,
+		},
+	},
+	// java.util.logging and log4j/logback levels, e.g. "2024-01-02 15:04:05,000 [main] SEVERE message"
+	"java": {
+		BodyPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`^\S+\s+\S+\s+(?:\[[^\]]*\]\s+)?(?P<level>SEVERE|WARNING|WARN|INFO|CONFIG|FINEST|FINER|FINE|ERROR|DEBUG|TRACE|FATAL)\b`),
+		},
+		Values: map[string]int{
+			"severe":  SeverityError,
+			"warning": SeverityWarn,
+			"info":    SeverityInfo,
+			"config":  SeverityDebug + 2,
+			"fine":    SeverityDebug,
+			"finer":   SeverityTrace + 2,
+			"finest":  SeverityTrace,
+		},
+	},
+	// bunyan and pino numeric levels, e.g. {"level":30}
+	"bunyan": {
+		Fields: []string{"level"},
+		NumericValues: map[int]int{
+			10: SeverityTrace,
+			20: SeverityDebug,
+			30: SeverityInfo,
+			40: SeverityWarn,
+			50: SeverityError,
+			60: SeverityFatal,
+		},
+	},
+}
+
+// severityNames maps OTLP severity names such as "warn" or "error3" to severity numbers
+var severityNames = func() map[string]int {
+	names := make(map[string]int, 24)
+	for name, number := range map[string]int{
+		"trace": SeverityTrace,
+		"debug": SeverityDebug,
+		"info":  SeverityInfo,
+		"warn":  SeverityWarn,
+		"error": SeverityError,
+		"fatal": SeverityFatal,
+	} {
+		names[name] = number
+		for i := 1; i < 4; i++ {
+			names[name+strconv.Itoa(i+1)] = number + i
+		}
+	}
+
+	return names
+}()
+
+// buildSeverityConfig constructs the severity rules from the defaults, the presets and the custom rules
+func buildSeverityConfig(config *Config, configMap map[string]any) error {
+	severity := DefaultSeverityConfig()
+
+	if presets, ok := configMap["severitypresets"].(string); ok && presets != "" {
+		for name := range strings.SplitSeq(presets, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			preset, ok := severityPresets[name]
+			if !ok {
+				return fmt.Errorf("unknown SeverityPresets entry %q, supported presets are %s",
+					name, strings.Join(slices.Sorted(maps.Keys(severityPresets)), ", "))
+			}
+			severity.merge(preset, false)
+			severity.Presets = append(severity.Presets, name)
+		}
+	}
+
+	custom := SeverityConfig{}
+
+	if fields, ok := configMap["severityfields"].(string); ok && fields != "" {
+		for field := range strings.SplitSeq(fields, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				custom.Fields = append(custom.Fields, field)
+			}
+		}
+	}
+
+	if bodyRegex, ok := configMap["severitybodyregex"].(string); ok && bodyRegex != "" {
+		re, err := regexp.Compile(bodyRegex)
+		if err != nil {
+			return fmt.Errorf("failed to compile SeverityBodyRegex: %w", err)
+		}
+		if re.NumSubexp() == 0 {
+			return fmt.Errorf("SeverityBodyRegex must contain a capture group: %s", bodyRegex)
+		}
+		custom.BodyPatterns = []*regexp.Regexp{re}
+	}
+
+	if values, ok := configMap["severityvalues"].(string); ok && values != "" {
+		parsed, err := parseSeverityTable(values, "SeverityValues")
+		if err != nil {
+			return err
+		}
+		custom.Values = make(map[string]int, len(parsed))
+		for k, v := range parsed {
+			custom.Values[strings.ToLower(k)] = v
+		}
+	}
+
+	if numericValues, ok := configMap["severitynumericvalues"].(string); ok && numericValues != "" {
+		parsed, err := parseSeverityTable(numericValues, "SeverityNumericValues")
+		if err != nil {
+			return err
+		}
+		custom.NumericValues = make(map[int]int, len(parsed))
+		for k, v := range parsed {
+			level, err := strconv.Atoi(k)
+			if err != nil {
+				return fmt.Errorf("invalid SeverityNumericValues level %q: %w", k, err)
+			}
+			custom.NumericValues[level] = v
+		}
+	}
+
+	// Custom rules take precedence over presets and defaults
+	severity.merge(custom, true)
+	config.OTLPConfig.SeverityConfig = severity
+
+	return nil
+}
+
+// merge adds the rules of other to s. Fields and body patterns of other are checked
+// before the existing ones when first is set, otherwise after them. Values of other override existing values.
+func (s *SeverityConfig) merge(other SeverityConfig, first bool) {
+	fields := make([]string, 0, len(s.Fields)+len(other.Fields))
+	patterns := make([]*regexp.Regexp, 0, len(s.BodyPatterns)+len(other.BodyPatterns))
+	if first {
+		fields = append(append(fields, other.Fields...), s.Fields...)
+		patterns = append(append(patterns, other.BodyPatterns...), s.BodyPatterns...)
+	} else {
+		fields = append(append(fields, s.Fields...), other.Fields...)
+		patterns = append(append(patterns, s.BodyPatterns...), other.BodyPatterns...)
+	}
+
+	// Keep the first occurrence of each field
+	seen := make(map[string]struct{}, len(fields))
+	s.Fields = slices.DeleteFunc(fields, func(field string) bool {
+		if _, ok := seen[field]; ok {
+			return true
+		}
+		seen[field] = struct{}{}
+
+		return false
+	})
+	s.BodyPatterns = patterns
+
+	if s.Values == nil {
+		s.Values = make(map[string]int, len(other.Values))
+	}
+	maps.Copy(s.Values, other.Values)
+
+	if s.NumericValues == nil {
+		s.NumericValues = make(map[int]int, len(other.NumericValues))
+	}
+	maps.Copy(s.NumericValues, other.NumericValues)
+}
+
+// parseSeverityTable parses a JSON object mapping log levels to OTLP severities.
+// Severities are given either as numbers (1-24) or as names like "warn" or "error2".
+func parseSeverityTable(value, key string) (map[string]int, error) {
+	if len(value) > MaxJSONSize {
+		return nil, fmt.Errorf("%s JSON exceeds maximum size of %d bytes", key, MaxJSONSize)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(value), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s JSON: %w", key, err)
+	}
+
+	table := make(map[string]int, len(raw))
+	for level, severity := range raw {
+		number, err := parseSeverity(severity)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s entry for level %q: %w", key, level, err)
+		}
+		table[level] = number
+	}
+
+	return table, nil
+}
+
+// parseSeverity parses an OTLP severity given as number (1-24) or name like "warn" or "error2"
+func parseSeverity(value any) (int, error) {
+	var number int
+	switch v := value.(type) {
+	case float64:
+		number = int(v)
+	case string:
+		n, ok := severityNames[strings.ToLower(v)]
+		if !ok {
+			return 0, fmt.Errorf("invalid severity %q", v)
+		}
+		number = n
+	case nil:
+		return 0, errors.New("severity is required")
+	default:
+		return 0, fmt.Errorf("invalid severity %v", value)
+	}
+	if number < 1 || number > 24 {
+		return 0, fmt.Errorf("invalid severity %d: must be between 1 and 24", number)
+	}
+
+	return number, nil
+}
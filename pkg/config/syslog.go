@@ -0,0 +1,101 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Supported transports of the syslog client
+const (
+	// SyslogNetworkUDP sends each message as datagram as defined by RFC 5426
+	SyslogNetworkUDP = "udp"
+	// SyslogNetworkTCP sends octet counted messages as defined by RFC 6587
+	SyslogNetworkTCP = "tcp"
+	// SyslogNetworkTCPTLS sends octet counted messages over TLS as defined by RFC 5425
+	SyslogNetworkTCPTLS = "tcp+tls"
+)
+
+// DefaultSyslogStructuredDataID is the default SD-ID of the Kubernetes structured data element,
+// 32473 is the private enterprise number reserved for documentation by RFC 5612
+const DefaultSyslogStructuredDataID = "k8s@32473"
+
+// syslogFacilities are the facility codes of RFC 5424 by their keyword
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11, "ntp": 12, "security": 13, "console": 14, "solaris-cron": 15,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19, "local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// SyslogConfig holds the configuration of the syslog client
+type SyslogConfig struct {
+	// Network is the transport, udp, tcp or tcp+tls
+	Network string `mapstructure:"SyslogNetwork"`
+	// Facility is the facility code - processed from SyslogFacility, a keyword like local0 or a number
+	Facility int `mapstructure:"-"`
+	// AppName is the APP-NAME of the messages
+	AppName string `mapstructure:"SyslogAppName"`
+	// StructuredDataID is the SD-ID of the structured data element holding the Kubernetes attributes
+	StructuredDataID string `mapstructure:"SyslogStructuredDataID"`
+}
+
+// DefaultSyslogConfig holds the default configuration of the syslog client
+var DefaultSyslogConfig = SyslogConfig{
+	Network:          SyslogNetworkTCPTLS,
+	Facility:         syslogFacilities["user"],
+	AppName:          "fluent-bit",
+	StructuredDataID: DefaultSyslogStructuredDataID,
+}
+
+// processSyslogConfig parses the facility and validates the syslog client configuration
+func processSyslogConfig(config *Config, configMap map[string]any) error {
+	syslog := &config.SyslogConfig
+
+	if facility, ok := configMap["syslogfacility"].(string); ok && facility != "" {
+		facility = strings.ToLower(strings.TrimSpace(facility))
+		code, ok := syslogFacilities[facility]
+		if !ok {
+			var err error
+			if code, err = strconv.Atoi(facility); err != nil || code < 0 || code > 23 {
+				return fmt.Errorf("invalid SyslogFacility %q, expected a facility keyword or a number between 0 and 23", facility)
+			}
+		}
+		syslog.Facility = code
+	}
+
+	syslog.Network = strings.ToLower(syslog.Network)
+	switch syslog.Network {
+	case SyslogNetworkUDP, SyslogNetworkTCP, SyslogNetworkTCPTLS:
+	default:
+		return fmt.Errorf("invalid SyslogNetwork %q, supported networks are %s, %s, %s",
+			syslog.Network, SyslogNetworkUDP, SyslogNetworkTCP, SyslogNetworkTCPTLS)
+	}
+
+	if len(syslog.AppName) > 48 || !isPrintUSASCII(syslog.AppName, "") {
+		return fmt.Errorf("invalid SyslogAppName %q, expected up to 48 printable US-ASCII characters", syslog.AppName)
+	}
+
+	if syslog.StructuredDataID == "" {
+		return errors.New("SyslogStructuredDataID must not be empty")
+	}
+	if len(syslog.StructuredDataID) > 32 || !isPrintUSASCII(syslog.StructuredDataID, `= ]"`) {
+		return fmt.Errorf("invalid SyslogStructuredDataID %q, expected up to 32 printable US-ASCII characters except '=', ' ', ']' and '\"'", syslog.StructuredDataID)
+	}
+
+	return nil
+}
+
+// isPrintUSASCII reports whether s only consists of printable US-ASCII characters not contained in exclude
+func isPrintUSASCII(s, exclude string) bool {
+	for _, c := range s {
+		if c < 33 || c > 126 || strings.ContainsRune(exclude, c) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,26 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+)
+
+// processTenantConfig validates the quotas and the fair share budget of the dynamic clients
+func processTenantConfig(config *Config, _ map[string]any) error {
+	ctl := &config.ControllerConfig
+
+	for name, value := range map[string]int{
+		"TenantRequestsPerSec":          ctl.TenantRequestsPerSec,
+		"TenantBytesPerSec":             ctl.TenantBytesPerSec,
+		"TenantFairShareRequestsPerSec": ctl.TenantFairShareRequestsPerSec,
+		"TenantFairShareBytesPerSec":    ctl.TenantFairShareBytesPerSec,
+	} {
+		if value < 0 {
+			return fmt.Errorf("%s must not be negative, got %d", name, value)
+		}
+	}
+
+	return nil
+}
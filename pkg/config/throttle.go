@@ -0,0 +1,51 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+// nonAdaptiveThrottleClients lists the client types limiting their records without adapting the limits,
+// since their exporters cannot tell when the backend throttles. The stdout, file and noop clients do not throttle at all.
+var nonAdaptiveThrottleClients = map[types.Type]bool{
+	types.KAFKA:  true,
+	types.SYSLOG: true,
+}
+
+// processThrottleConfig validates the byte limit and the adaptive throttling of the exporting clients
+func processThrottleConfig(config *Config, _ map[string]any) error {
+	otlp := &config.OTLPConfig
+
+	if otlp.ThrottleBytesPerSec < 0 {
+		return fmt.Errorf("ThrottleBytesPerSec must not be negative, got %d", otlp.ThrottleBytesPerSec)
+	}
+	if !otlp.ThrottleAdaptive {
+		return nil
+	}
+
+	for _, clientType := range []string{config.PluginConfig.SeedType, config.PluginConfig.ShootType} {
+		if t := types.ClientTypeFromString(clientType); nonAdaptiveThrottleClients[t] {
+			return fmt.Errorf("ThrottleAdaptive is not supported by the %s client", t)
+		}
+	}
+
+	if otlp.ThrottleRequestsPerSec <= 0 && otlp.ThrottleBytesPerSec <= 0 {
+		return errors.New("ThrottleAdaptive requires ThrottleRequestsPerSec or ThrottleBytesPerSec")
+	}
+	if otlp.ThrottleAdaptiveMinPercent < 1 || otlp.ThrottleAdaptiveMinPercent > 100 {
+		return fmt.Errorf("ThrottleAdaptiveMinPercent must be between 1 and 100, got %d", otlp.ThrottleAdaptiveMinPercent)
+	}
+	if otlp.ThrottleAdaptiveIncreasePercent < 1 || otlp.ThrottleAdaptiveIncreasePercent > 100 {
+		return fmt.Errorf("ThrottleAdaptiveIncreasePercent must be between 1 and 100, got %d", otlp.ThrottleAdaptiveIncreasePercent)
+	}
+	if otlp.ThrottleAdaptiveInterval <= 0 {
+		return fmt.Errorf("ThrottleAdaptiveInterval must be positive, got %v", otlp.ThrottleAdaptiveInterval)
+	}
+
+	return nil
+}
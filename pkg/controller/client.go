@@ -40,6 +40,7 @@ type controllerClient struct {
 	state       clusterState
 	logger      logr.Logger
 	name        string
+	tenant      *tenant // Limits the records of the cluster to its quota, nil if not limited
 }
 
 var _ api.Output = &controllerClient{}
@@ -63,6 +64,12 @@ func (c *controllerClient) Handle(log types.OutputEntry) error {
 	// in case they have changed during the two consequential calls to Handle.
 	sendToShoot, sendToSeed := !c.shootTarget.mute, !c.seedTarget.mute
 
+	if c.tenant != nil && (sendToShoot || sendToSeed) {
+		if err := c.tenant.allow(log.Record); err != nil {
+			return err
+		}
+	}
+
 	if sendToShoot {
 		if err := c.shootTarget.client.Handle(log); err != nil {
 			combineErr = errors.Join(combineErr, err)
@@ -79,14 +86,22 @@ func (c *controllerClient) Handle(log types.OutputEntry) error {
 
 // Stop the client.
 func (c *controllerClient) Stop() {
+	c.closeTenant()
 	c.shootTarget.client.Stop()
 }
 
 // StopWait stops the client waiting all saved logs to be sent.
 func (c *controllerClient) StopWait() {
+	c.closeTenant()
 	c.shootTarget.client.StopWait()
 }
 
+func (c *controllerClient) closeTenant() {
+	if c.tenant != nil {
+		c.tenant.close()
+	}
+}
+
 // SetState manages the mute flags for shoot and seed targets.
 func (c *controllerClient) SetState(state clusterState) {
 	if state == c.state {
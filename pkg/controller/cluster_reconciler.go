@@ -57,6 +57,7 @@ type clusterReconciler struct {
 	mgrDone      chan struct{} // signals when manager goroutine has stopped
 	metrics      *metrics.FluentBitGardenerMetrics
 	metricsSetup *otlp.MetricsSetup
+	fairShare    *fairShare // Shares the budget of all clusters, nil without budget
 }
 
 // newClusterController creates a new Controller for Cluster resources.
@@ -165,6 +166,7 @@ func buildClusterReconciler(
 		mgrDone:      make(chan struct{}),
 		metrics:      m,
 		metricsSetup: ms,
+		fairShare:    newFairShare(&conf.ControllerConfig),
 	}
 
 	if err := ctrl.NewControllerManagedBy(mgr).
@@ -230,6 +232,7 @@ func NewControllerWithClient(ctx context.Context, c k8sclient.Client, conf *conf
 		mgr:          nil,
 		metrics:      m,
 		metricsSetup: ms,
+		fairShare:    newFairShare(&conf.ControllerConfig),
 	}
 
 	return reconciler, nil
@@ -289,17 +292,19 @@ func (r *clusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	existingClient, clientExists := r.clients[cluster.Name]
 	r.lock.RUnlock()
 
+	quota := r.tenantQuota(cluster, shoot)
 	if clientExists {
 		if existingClient == nil {
 			log.Error(nil, "nil client for cluster, recreating")
-			r.createClient(cluster.Name, shoot)
+			r.createClient(cluster.Name, shoot, quota)
 		} else {
 			log.V(1).Info("updating cluster state")
 			r.updateClientState(existingClient, shoot)
+			r.updateClientQuota(existingClient, quota)
 		}
 	} else {
 		log.V(1).Info("creating new client for cluster")
-		r.createClient(cluster.Name, shoot)
+		r.createClient(cluster.Name, shoot, quota)
 	}
 
 	return ctrl.Result{}, nil
@@ -382,7 +387,7 @@ func (r *clusterReconciler) newControllerClient(clusterName string, clientConf *
 	return c, nil
 }
 
-func (r *clusterReconciler) createClient(clusterName string, shoot *gardenercorev1beta1.Shoot) {
+func (r *clusterReconciler) createClient(clusterName string, shoot *gardenercorev1beta1.Shoot, quota tenantQuota) {
 	clientConf := r.buildClientConfig(clusterName)
 
 	c, err := r.newControllerClient(clusterName, clientConf)
@@ -408,10 +413,13 @@ func (r *clusterReconciler) createClient(clusterName string, shoot *gardenercore
 		r.logger.Info("controller client already exists, discarding duplicate", "cluster", clusterName)
 		c.StopWait()
 		r.updateClientState(existingClient, shoot)
+		r.updateClientQuota(existingClient, quota)
 
 		return
 	}
 
+	// The tenant joins the fair share only once the client is added, discarded duplicates do not affect the shares
+	c.tenant = newTenant(clusterName, quota, r.fairShare, r.metrics)
 	r.metrics.Clients.WithLabelValues(targets.Shoot.String()).Inc()
 	r.clients[clusterName] = c
 	r.logger.Info("added controller client",
@@ -442,12 +450,32 @@ func (*clusterReconciler) updateClientState(c Client, shoot *gardenercorev1beta1
 	c.SetState(getShootState(shoot))
 }
 
+func (*clusterReconciler) updateClientQuota(c Client, quota tenantQuota) {
+	if cc, ok := c.(*controllerClient); ok && cc.tenant != nil {
+		cc.tenant.setQuota(quota)
+	}
+}
+
+// tenantQuota returns the default quota overridden by the annotations of the cluster,
+// the annotations of the shoot can only lower it as they are set by the shoot owners
+func (r *clusterReconciler) tenantQuota(cluster *extensionsv1alpha1.Cluster, shoot *gardenercorev1beta1.Shoot) tenantQuota {
+	log := r.logger.WithValues("cluster", cluster.Name)
+	quota := defaultTenantQuota(&r.conf.ControllerConfig).withAnnotations(cluster.Annotations, false, log)
+	if shoot != nil {
+		quota = quota.withAnnotations(shoot.Annotations, true, log)
+	}
+
+	return quota
+}
+
 func (r *clusterReconciler) buildClientConfig(clusterName string) *config.Config {
 	urlstr := fmt.Sprintf("%s%s%s", r.conf.ControllerConfig.DynamicHostPrefix, clusterName, r.conf.ControllerConfig.DynamicHostSuffix)
 	r.logger.V(1).Info("set endpoint", "endpoint", urlstr, "cluster", clusterName)
 
 	conf := *r.conf
 	conf.OTLPConfig.Endpoint = urlstr
+	// The failover endpoints are alternatives to the configured endpoint, not to the dynamic endpoints
+	conf.OTLPConfig.FailoverEndpoints = nil
 	conf.OTLPConfig.DQueConfig.DQueName = clusterName
 
 	return &conf
@@ -229,6 +229,26 @@ var _ = Describe("Controller", func() {
 				Expect(reconciler.conf.OTLPConfig.Endpoint).ToNot(
 					Equal(reconciler.conf.ControllerConfig.DynamicHostPrefix + hibernatedCluster.Name + reconciler.conf.ControllerConfig.DynamicHostSuffix))
 			})
+			It("Should apply the quota annotations of the cluster and the shoot", func() {
+				reconciler.conf.ControllerConfig.TenantRequestsPerSec = 100
+				shoot := developmentShoot.DeepCopy()
+				shoot.Annotations = map[string]string{AnnotationRequestsPerSec: "2000", AnnotationBytesPerSec: "500"}
+				shootRaw, _ := json.Marshal(shoot)
+				cluster := developmentCluster.DeepCopy()
+				cluster.Annotations = map[string]string{AnnotationRequestsPerSec: "1000"}
+				cluster.Spec.Shoot = runtime.RawExtension{Raw: shootRaw}
+				reconciler.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).Build()
+
+				reconcileCluster(cluster)
+				c, ok := reconciler.clients[shootName].(*controllerClient)
+				Expect(ok).To(BeTrue())
+				Expect(c.tenant.quota).To(Equal(tenantQuota{requestsPerSec: 1000, bytesPerSec: 500}))
+
+				cluster.Annotations[AnnotationRequestsPerSec] = "0"
+				Expect(reconciler.Update(ctx, cluster)).To(Succeed())
+				reconcileCluster(cluster)
+				Expect(c.tenant.quota).To(Equal(tenantQuota{requestsPerSec: 2000, bytesPerSec: 500}))
+			})
 		})
 
 		Context("#Reconcile - update", func() {
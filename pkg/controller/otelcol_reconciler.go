@@ -62,6 +62,7 @@ type otelCollectorReconciler struct {
 	dynamicHostRegex       *regexp.Regexp
 	metrics                *metrics.FluentBitGardenerMetrics
 	metricsSetup           *otlp.MetricsSetup
+	fairShare              *fairShare // Shares the budget of all namespaces, nil without budget
 }
 
 // newOpenTelemetryCollectorController creates a new Controller for OpenTelemetryCollector resources.
@@ -167,6 +168,7 @@ func buildOpenTelemetryCollectorReconciler(
 		dynamicHostRegex:       dynamicHostRegex,
 		metrics:                m,
 		metricsSetup:           ms,
+		fairShare:              newFairShare(&conf.ControllerConfig),
 	}
 
 	// Build predicate for filtering OpenTelemetryCollector resources by label
@@ -261,19 +263,40 @@ func (r *otelCollectorReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, nil
 	}
 
+	quota, err := r.tenantQuota(ctx, otelcol)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get tenant quota: %w", err)
+	}
+
 	// Create or update client
 	r.lock.RLock()
-	_, clientExists := r.clients[req.Namespace]
+	existingClient, clientExists := r.clients[req.Namespace]
 	r.lock.RUnlock()
 
 	if !clientExists {
 		log.V(1).Info("creating new client for OpenTelemetryCollector")
-		r.createClient(req.Namespace)
+		r.createClient(req.Namespace, quota)
+	} else if c, ok := existingClient.(*tenantClient); ok {
+		c.tenant.setQuota(quota)
 	}
 
 	return ctrl.Result{}, nil
 }
 
+// tenantQuota returns the default quota overridden by the annotations of the namespace and of the OpenTelemetryCollector.
+// Changed annotations of the namespace take effect when the OpenTelemetryCollector is reconciled the next time.
+func (r *otelCollectorReconciler) tenantQuota(ctx context.Context, otelcol *otelcolv1beta1.OpenTelemetryCollector) (tenantQuota, error) {
+	log := r.logger.WithValues("namespace", otelcol.Namespace)
+	quota := defaultTenantQuota(&r.conf.ControllerConfig)
+
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, k8sclient.ObjectKey{Name: otelcol.Namespace}, ns); err != nil {
+		return quota, fmt.Errorf("failed to get namespace %s: %w", otelcol.Namespace, err)
+	}
+
+	return quota.withAnnotations(ns.Annotations, false, log).withAnnotations(otelcol.Annotations, false, log), nil
+}
+
 // isNamespaceAllowed checks if the namespace matches both:
 // 1. The namespace label selector (e.g., gardener.cloud/role=shoot)
 // 2. The DynamicHostRegex (namespace name must match the regex)
@@ -314,7 +337,7 @@ func (r *otelCollectorReconciler) isNamespaceAllowed(ctx context.Context, namesp
 // After construction the write lock is acquired and a final duplicate check is
 // performed; if a concurrent call already inserted a client the newly created
 // one is stopped and discarded.
-func (r *otelCollectorReconciler) createClient(namespace string) {
+func (r *otelCollectorReconciler) createClient(namespace string, quota tenantQuota) {
 	clientConf := r.buildClientConfig(namespace)
 
 	opt := []client.Option{client.WithTarget(targets.Shoot), client.WithLogger(r.logger), client.WithMetrics(r.metrics), client.WithOTLPMetricsSetup(r.metricsSetup)}
@@ -342,8 +365,9 @@ func (r *otelCollectorReconciler) createClient(namespace string) {
 		return
 	}
 
+	// The tenant joins the fair share only once the client is added, discarded duplicates do not affect the shares
 	r.metrics.Clients.WithLabelValues(targets.Shoot.String()).Inc()
-	r.clients[namespace] = outputClient
+	r.clients[namespace] = &tenantClient{Output: outputClient, tenant: newTenant(namespace, quota, r.fairShare, r.metrics)}
 	r.logger.Info("added client for namespace", "namespace", namespace, "endpoint", clientConf.OTLPConfig.Endpoint)
 }
 
@@ -375,6 +399,8 @@ func (r *otelCollectorReconciler) buildClientConfig(namespace string) *config.Co
 
 	conf := *r.conf
 	conf.OTLPConfig.Endpoint = endpoint
+	// The failover endpoints are alternatives to the configured endpoint, not to the dynamic endpoints
+	conf.OTLPConfig.FailoverEndpoints = nil
 	conf.OTLPConfig.DQueConfig.DQueName = namespace
 
 	return &conf
@@ -160,6 +160,34 @@ var _ = Describe("otelCollectorReconciler", func() {
 			Expect(reconciler.clients).To(HaveKey(namespace))
 		})
 
+		It("should apply the quota annotations of the namespace and the OpenTelemetryCollector", func() {
+			reconciler.conf.ControllerConfig.TenantRequestsPerSec = 100
+			ns.Annotations = map[string]string{AnnotationRequestsPerSec: "1000"}
+			otelcol.Annotations = map[string]string{AnnotationBytesPerSec: "500"}
+			reconciler.Client = fake.NewClientBuilder().
+				WithScheme(otelcolScheme).
+				WithObjects(otelcol, ns).
+				Build()
+			req := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      otelcolName,
+					Namespace: namespace,
+				},
+			}
+
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+			c, ok := reconciler.clients[namespace].(*tenantClient)
+			Expect(ok).To(BeTrue())
+			Expect(c.tenant.quota).To(Equal(tenantQuota{requestsPerSec: 1000, bytesPerSec: 500}))
+
+			ns.Annotations[AnnotationRequestsPerSec] = "10"
+			Expect(reconciler.Update(ctx, ns)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(c.tenant.quota).To(Equal(tenantQuota{requestsPerSec: 10, bytesPerSec: 500}))
+		})
+
 		It("should delete client when OpenTelemetryCollector is not found", func() {
 			reconciler.clients[namespace] = &fakeOutputClient{}
 			reconciler.Client = fake.NewClientBuilder().
@@ -294,6 +322,13 @@ var _ = Describe("otelCollectorReconciler", func() {
 			Expect(conf.OTLPConfig.Endpoint).To(Equal(dynamicHostPrefix + namespace + dynamicHostSuffix))
 			Expect(conf.OTLPConfig.DQueConfig.DQueName).To(Equal(namespace))
 		})
+
+		It("should not inherit the failover endpoints of the configured endpoint", func() {
+			reconciler.conf.OTLPConfig.FailoverEndpoints = []string{"secondary:4317"}
+			conf := reconciler.buildClientConfig(namespace)
+			Expect(conf.OTLPConfig.FailoverEndpoints).To(BeEmpty())
+			Expect(reconciler.conf.OTLPConfig.FailoverEndpoints).To(HaveLen(1))
+		})
 	})
 
 	Describe("#deleteClient", func() {
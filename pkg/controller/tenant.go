@@ -0,0 +1,337 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
+
+	"github.com/gardener/logging/v1/pkg/client/api"
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+// Annotations overriding the quota of a tenant, the values are records or estimated bytes per second, 0 means no limit.
+// Cluster and Namespace annotations replace the default quota, Shoot annotations set by the shoot owners can only lower it.
+const (
+	// AnnotationRequestsPerSec overrides TenantRequestsPerSec for the tenant of the annotated resource
+	AnnotationRequestsPerSec = "logging.gardener.cloud/requests-per-sec"
+	// AnnotationBytesPerSec overrides TenantBytesPerSec for the tenant of the annotated resource
+	AnnotationBytesPerSec = "logging.gardener.cloud/bytes-per-sec"
+)
+
+// tenantQuota is the number of records and estimated bytes per second a tenant may send, 0 means no limit
+type tenantQuota struct {
+	requestsPerSec int
+	bytesPerSec    int
+}
+
+func defaultTenantQuota(conf *config.ControllerConfig) tenantQuota {
+	return tenantQuota{requestsPerSec: conf.TenantRequestsPerSec, bytesPerSec: conf.TenantBytesPerSec}
+}
+
+// withAnnotations returns the quota overridden by the annotations, invalid values are logged and ignored.
+// With lowerOnly the annotations can only set limits below the quota.
+func (q tenantQuota) withAnnotations(annotations map[string]string, lowerOnly bool, logger logr.Logger) tenantQuota {
+	override := func(annotation string, limit *int) {
+		value, ok := annotations[annotation]
+		if !ok {
+			return
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			logger.Error(err, "ignoring invalid quota annotation", "annotation", annotation, "value", value)
+
+			return
+		}
+		if lowerOnly && (parsed == 0 || (*limit > 0 && parsed >= *limit)) {
+			return
+		}
+		*limit = parsed
+	}
+	override(AnnotationRequestsPerSec, &q.requestsPerSec)
+	override(AnnotationBytesPerSec, &q.bytesPerSec)
+
+	return q
+}
+
+// tenant limits the records of a dynamic client to the quota of the tenant and to its fair share of the budget of all tenants.
+// Rejected records are counted by the ThrottledLogs metric with the tenant name as host.
+type tenant struct {
+	name      string
+	fairShare *fairShare // Nil without budget
+	metrics   *metrics.FluentBitGardenerMetrics
+
+	mu      sync.Mutex
+	quota   tenantQuota
+	limiter atomic.Pointer[otlp.Limiter] // Nil without quota
+}
+
+// newTenant creates the tenant with the quota and joins the fair share, the tenant must be closed when its client stops
+func newTenant(name string, quota tenantQuota, fs *fairShare, m *metrics.FluentBitGardenerMetrics) *tenant {
+	t := &tenant{name: name, fairShare: fs, metrics: m}
+	t.setQuota(quota)
+	if fs != nil {
+		fs.join(name)
+	}
+
+	return t
+}
+
+// setQuota replaces the limiter of the tenant if the quota changed
+func (t *tenant) setQuota(quota tenantQuota) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if quota == t.quota {
+		return
+	}
+	t.quota = quota
+	t.deleteLimitMetrics()
+	t.limiter.Store(otlp.NewLimiter(config.OTLPConfig{
+		ThrottleEnabled:        true,
+		ThrottleRequestsPerSec: quota.requestsPerSec,
+		ThrottleBytesPerSec:    quota.bytesPerSec,
+	}, t.name, t.metrics))
+}
+
+// allow returns otlp.ErrThrottled if the record exceeds the quota or the fair share of the tenant
+func (t *tenant) allow(record map[string]any) error {
+	limiter := t.limiter.Load()
+	if limiter == nil && t.fairShare == nil {
+		return nil
+	}
+
+	size := otlp.RecordSize(record)
+	var cancel func()
+	if t.fairShare != nil {
+		var ok bool
+		if cancel, ok = t.fairShare.reserve(t.name, size); !ok {
+			return t.throttled()
+		}
+	}
+	if limiter != nil && !limiter.Allow(size) {
+		// The record is not sent, so it must not use the fair share either
+		if cancel != nil {
+			cancel()
+		}
+
+		return t.throttled()
+	}
+
+	return nil
+}
+
+func (t *tenant) throttled() error {
+	t.metrics.ThrottledLogs.WithLabelValues(t.name).Inc()
+
+	return otlp.ErrThrottled
+}
+
+// close leaves the fair share and removes the limit metrics of the tenant
+func (t *tenant) close() {
+	if t.fairShare != nil {
+		t.fairShare.leave(t.name)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.deleteLimitMetrics()
+}
+
+func (t *tenant) deleteLimitMetrics() {
+	t.metrics.ThrottleLimit.DeleteLabelValues(t.name, "records")
+	t.metrics.ThrottleLimit.DeleteLabelValues(t.name, "bytes")
+}
+
+// fairShare divides budgets of records and estimated bytes per second equally between the tenants.
+// A tenant within its share is always served and charged to the budget, even if other tenants exhausted it.
+// A tenant exceeding its share is served only from the budget left idle by the other tenants,
+// so that a noisy tenant cannot starve the others but idle capacity is not wasted.
+type fairShare struct {
+	records *fairShareBudget // Nil without budget of records
+	bytes   *fairShareBudget // Nil without budget of bytes
+}
+
+// newFairShare creates the fair share of the budgets, nil if no budget is configured
+func newFairShare(conf *config.ControllerConfig) *fairShare {
+	if conf.TenantFairShareRequestsPerSec <= 0 && conf.TenantFairShareBytesPerSec <= 0 {
+		return nil
+	}
+
+	return &fairShare{
+		records: newFairShareBudget(conf.TenantFairShareRequestsPerSec),
+		bytes:   newFairShareBudget(conf.TenantFairShareBytesPerSec),
+	}
+}
+
+func (f *fairShare) join(tenant string) {
+	f.records.join(tenant)
+	f.bytes.join(tenant)
+}
+
+func (f *fairShare) leave(tenant string) {
+	f.records.leave(tenant)
+	f.bytes.leave(tenant)
+}
+
+// reserve takes a record of the size from the budgets, the returned function gives it back if the record is not sent
+func (f *fairShare) reserve(tenant string, size int) (func(), bool) {
+	now := time.Now()
+
+	cancelRecord, ok := f.records.reserve(tenant, 1, now)
+	if !ok {
+		return nil, false
+	}
+	cancelBytes, ok := f.bytes.reserve(tenant, size, now)
+	if !ok {
+		cancelRecord()
+
+		return nil, false
+	}
+
+	return func() {
+		cancelRecord()
+		cancelBytes()
+	}, true
+}
+
+// fairShareBudget is the budget of one unit with a limiter per tenant for its share of the budget.
+// The methods of a nil budget allow everything.
+type fairShareBudget struct {
+	limit  float64
+	budget *rate.Limiter
+
+	mu     sync.RWMutex
+	shares map[string]*rate.Limiter
+}
+
+func newFairShareBudget(limit int) *fairShareBudget {
+	if limit <= 0 {
+		return nil
+	}
+
+	return &fairShareBudget{
+		limit:  float64(limit),
+		budget: rate.NewLimiter(rate.Limit(limit), limit*2),
+		shares: make(map[string]*rate.Limiter, expectedActiveClusters),
+	}
+}
+
+func (b *fairShareBudget) join(tenant string) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.shares[tenant]; !ok {
+		b.shares[tenant] = nil
+		b.resize()
+	}
+}
+
+func (b *fairShareBudget) leave(tenant string) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.shares[tenant]; ok {
+		delete(b.shares, tenant)
+		b.resize()
+	}
+}
+
+// resize divides the budget equally between the tenants, the shares allow bursts of twice the share.
+// Joining tenants without limiter get a limiter with a full burst.
+func (b *fairShareBudget) resize() {
+	if len(b.shares) == 0 {
+		return
+	}
+
+	share := b.limit / float64(len(b.shares))
+	burst := max(1, int(math.Round(share*2)))
+	for tenant, limiter := range b.shares {
+		if limiter == nil {
+			b.shares[tenant] = rate.NewLimiter(rate.Limit(share), burst)
+
+			continue
+		}
+		limiter.SetLimit(rate.Limit(share))
+		limiter.SetBurst(burst)
+	}
+}
+
+func (b *fairShareBudget) reserve(tenant string, n int, now time.Time) (func(), bool) {
+	if b == nil {
+		return func() {}, true
+	}
+
+	b.mu.RLock()
+	share, ok := b.shares[tenant]
+	b.mu.RUnlock()
+	if !ok {
+		return func() {}, true
+	}
+
+	// Records larger than the bursts are counted as the bursts, so that they can be sent at all
+	n = min(max(n, 1), b.budget.Burst())
+	own := share.ReserveN(now, min(n, share.Burst()))
+	if own.OK() && own.DelayFrom(now) == 0 {
+		charged := b.budget.ReserveN(now, n)
+
+		return func() {
+			own.CancelAt(now)
+			charged.CancelAt(now)
+		}, true
+	}
+	own.CancelAt(now)
+
+	borrowed := b.budget.ReserveN(now, n)
+	if borrowed.OK() && borrowed.DelayFrom(now) == 0 {
+		return func() { borrowed.CancelAt(now) }, true
+	}
+	borrowed.CancelAt(now)
+
+	return nil, false
+}
+
+// tenantClient limits the records of a dynamic client of the OpenTelemetryCollector controller to the quotas of its tenant
+type tenantClient struct {
+	api.Output
+	tenant *tenant
+}
+
+var _ api.Output = &tenantClient{}
+
+// Handle sends the record if the tenant is within its quota and fair share
+func (c *tenantClient) Handle(log types.OutputEntry) error {
+	if err := c.tenant.allow(log.Record); err != nil {
+		return err
+	}
+
+	return c.Output.Handle(log)
+}
+
+// Stop stops the client and releases the fair share of the tenant
+func (c *tenantClient) Stop() {
+	c.tenant.close()
+	c.Output.Stop()
+}
+
+// StopWait stops the client waiting for the saved logs to be sent and releases the fair share of the tenant
+func (c *tenantClient) StopWait() {
+	c.tenant.close()
+	c.Output.StopWait()
+}
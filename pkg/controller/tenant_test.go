@@ -0,0 +1,141 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
+	pkgtypes "github.com/gardener/logging/v1/pkg/types"
+)
+
+var _ = Describe("Tenant", func() {
+	var (
+		testMetrics *metrics.FluentBitGardenerMetrics
+		record      = map[string]any{"log": "test"}
+	)
+
+	BeforeEach(func() {
+		testMetrics = metrics.RegisterFluentBitGardenerMetrics(metrics.NewRegistry())
+	})
+
+	allowed := func(t *tenant, n int) int {
+		count := 0
+		for range n {
+			if t.allow(record) == nil {
+				count++
+			}
+		}
+
+		return count
+	}
+
+	DescribeTable("#withAnnotations",
+		func(annotations map[string]string, lowerOnly bool, want tenantQuota) {
+			quota := tenantQuota{requestsPerSec: 100, bytesPerSec: 1000}
+			Expect(quota.withAnnotations(annotations, lowerOnly, logr.Discard())).To(Equal(want))
+		},
+		Entry("should keep the quota without annotations", nil, false,
+			tenantQuota{requestsPerSec: 100, bytesPerSec: 1000}),
+		Entry("should replace the quota", map[string]string{AnnotationRequestsPerSec: "500", AnnotationBytesPerSec: "0"}, false,
+			tenantQuota{requestsPerSec: 500, bytesPerSec: 0}),
+		Entry("should ignore invalid values", map[string]string{AnnotationRequestsPerSec: "many", AnnotationBytesPerSec: "-1"}, false,
+			tenantQuota{requestsPerSec: 100, bytesPerSec: 1000}),
+		Entry("should only lower the quota", map[string]string{AnnotationRequestsPerSec: "500", AnnotationBytesPerSec: "10"}, true,
+			tenantQuota{requestsPerSec: 100, bytesPerSec: 10}),
+		Entry("should not remove the quota when only lowering", map[string]string{AnnotationRequestsPerSec: "0"}, true,
+			tenantQuota{requestsPerSec: 100, bytesPerSec: 1000}),
+	)
+
+	It("should lower an unlimited quota", func() {
+		quota := tenantQuota{}.withAnnotations(map[string]string{AnnotationRequestsPerSec: "5"}, true, logr.Discard())
+		Expect(quota).To(Equal(tenantQuota{requestsPerSec: 5}))
+	})
+
+	It("should allow everything without quota and fair share", func() {
+		t := newTenant("shoot--dev--logging", tenantQuota{}, nil, testMetrics)
+		Expect(allowed(t, 1000)).To(Equal(1000))
+	})
+
+	It("should limit the tenant to its quota", func() {
+		t := newTenant("shoot--dev--logging", tenantQuota{requestsPerSec: 5}, nil, testMetrics)
+		Expect(testutil.ToFloat64(testMetrics.ThrottleLimit.WithLabelValues("shoot--dev--logging", "records"))).To(Equal(5.0))
+
+		Expect(allowed(t, 10)).To(Equal(10))
+		Expect(t.allow(record)).To(MatchError(otlp.ErrThrottled))
+		Expect(testutil.ToFloat64(testMetrics.ThrottledLogs.WithLabelValues("shoot--dev--logging"))).To(Equal(1.0))
+	})
+
+	It("should update the quota", func() {
+		t := newTenant("shoot--dev--logging", tenantQuota{requestsPerSec: 5}, nil, testMetrics)
+		Expect(allowed(t, 11)).To(Equal(10))
+
+		t.setQuota(tenantQuota{})
+		Expect(allowed(t, 100)).To(Equal(100))
+		Expect(testutil.CollectAndCount(testMetrics.ThrottleLimit)).To(BeZero())
+	})
+
+	Context("fair share", func() {
+		var fs *fairShare
+
+		BeforeEach(func() {
+			fs = newFairShare(&config.ControllerConfig{TenantFairShareRequestsPerSec: 100})
+		})
+
+		It("should not be created without budget", func() {
+			Expect(newFairShare(&config.ControllerConfig{})).To(BeNil())
+		})
+
+		It("should lend the idle budget to a noisy tenant but keep the share of the others", func() {
+			noisy := newTenant("shoot--dev--noisy", tenantQuota{}, fs, testMetrics)
+			quiet := newTenant("shoot--dev--quiet", tenantQuota{}, fs, testMetrics)
+
+			// The shares of 50 records per second allow bursts of 100, the budget allows bursts of 200
+			Expect(allowed(noisy, 300)).To(Equal(200))
+			Expect(allowed(quiet, 300)).To(Equal(100))
+			Expect(testutil.ToFloat64(testMetrics.ThrottledLogs.WithLabelValues("shoot--dev--noisy"))).To(Equal(100.0))
+		})
+
+		It("should not charge the fair share for records exceeding the quota", func() {
+			limited := newTenant("shoot--dev--limited", tenantQuota{requestsPerSec: 5}, fs, testMetrics)
+			other := newTenant("shoot--dev--other", tenantQuota{}, fs, testMetrics)
+
+			Expect(allowed(limited, 300)).To(Equal(10))
+			Expect(allowed(other, 300)).To(Equal(190))
+		})
+
+		It("should divide the budget between the remaining tenants", func() {
+			first := newTenant("shoot--dev--first", tenantQuota{}, fs, testMetrics)
+			second := newTenant("shoot--dev--second", tenantQuota{}, fs, testMetrics)
+			Expect(fs.records.shares["shoot--dev--first"].Limit()).To(BeNumerically("==", 50))
+
+			second.close()
+			Expect(fs.records.shares).To(HaveLen(1))
+			Expect(fs.records.shares["shoot--dev--first"].Limit()).To(BeNumerically("==", 100))
+			Expect(allowed(first, 300)).To(Equal(200))
+		})
+	})
+
+	Describe("tenantClient", func() {
+		It("should throttle the records of the tenant and leave the fair share when stopped", func() {
+			fs := newFairShare(&config.ControllerConfig{TenantFairShareRequestsPerSec: 100})
+			output := &fakeOutputClient{}
+			c := &tenantClient{Output: output, tenant: newTenant("shoot--dev--logging", tenantQuota{requestsPerSec: 1}, fs, testMetrics)}
+			entry := pkgtypes.OutputEntry{Record: record}
+
+			Expect(c.Handle(entry)).To(Succeed())
+			Expect(c.Handle(entry)).To(Succeed())
+			Expect(c.Handle(entry)).To(MatchError(otlp.ErrThrottled))
+
+			c.Stop()
+			Expect(output.isStopped).To(BeTrue())
+			Expect(fs.records.shares).To(BeEmpty())
+		})
+	})
+})
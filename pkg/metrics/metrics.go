@@ -30,6 +30,16 @@ type FluentBitGardenerMetrics struct {
 	BufferedLogs *prometheus.GaugeVec
 	// DqueSize is a prometheus metric which keeps the current size of the dque queue
 	DqueSize *prometheus.GaugeVec
+	// StructuredBodyFallbacks is a prometheus metric which keeps the number of structured bodies sent as strings for their size
+	StructuredBodyFallbacks *prometheus.CounterVec
+	// Redactions is a prometheus metric which keeps the number of secrets redacted from logs
+	Redactions *prometheus.CounterVec
+	// DestinationLogs is a prometheus metric which keeps the number of logs per destination of the multi client and result
+	DestinationLogs *prometheus.CounterVec
+	// ActiveEndpoint is a prometheus metric which is 1 for the endpoint a failover client currently exports to and 0 for the others
+	ActiveEndpoint *prometheus.GaugeVec
+	// ThrottleLimit is a prometheus metric which keeps the current rate limit of a throttled client per unit
+	ThrottleLimit *prometheus.GaugeVec
 }
 
 // RegisterFluentBitGardenerMetrics creates and registers all fluent-bit gardener metrics with the given registerer.
@@ -87,5 +97,30 @@ func RegisterFluentBitGardenerMetrics(reg prometheus.Registerer) *FluentBitGarde
 			Name:      "dque_size",
 			Help:      "Current size of the dque queue",
 		}, []string{"name"}),
+		StructuredBodyFallbacks: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "structured_body_fallbacks_total",
+			Help:      "Total number of structured bodies exceeding MaxStructuredBodySize, sent as truncated strings instead",
+		}, []string{"host"}),
+		Redactions: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "redactions_total",
+			Help:      "Total number of secrets redacted from logs by the output plugin",
+		}, []string{"detector"}),
+		DestinationLogs: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "destination_logs_total",
+			Help:      "Total number of logs handled per destination of the multi client by result",
+		}, []string{"destination", "result"}),
+		ActiveEndpoint: promauto.With(reg).NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "active_endpoint",
+			Help:      "Endpoint a failover client currently exports to, 1 for the active endpoint and 0 for the others",
+		}, []string{"host", "endpoint"}),
+		ThrottleLimit: promauto.With(reg).NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "throttle_limit",
+			Help:      "Current rate limit of a throttled client in records or bytes per second, lowered by adaptive throttling",
+		}, []string{"host", "unit"}),
 	}
 }
@@ -73,6 +73,22 @@ var _ = Describe("FluentBitGardenerMetrics", func() {
 			"# TYPE fluentbit_gardener_dque_size gauge",
 			`fluentbit_gardener_dque_size{name="test-queue"} 42`,
 		),
+		Entry("fluentbit_gardener_redactions_total",
+			"# TYPE fluentbit_gardener_redactions_total counter",
+			`fluentbit_gardener_redactions_total{detector="jwt"} 1`,
+		),
+		Entry("fluentbit_gardener_destination_logs_total",
+			"# TYPE fluentbit_gardener_destination_logs_total counter",
+			`fluentbit_gardener_destination_logs_total{destination="vali",result="sent"} 1`,
+		),
+		Entry("fluentbit_gardener_active_endpoint",
+			"# TYPE fluentbit_gardener_active_endpoint gauge",
+			`fluentbit_gardener_active_endpoint{endpoint="http://secondary",host="http://localhost"} 1`,
+		),
+		Entry("fluentbit_gardener_throttle_limit",
+			"# TYPE fluentbit_gardener_throttle_limit gauge",
+			`fluentbit_gardener_throttle_limit{host="http://localhost",unit="bytes"} 1.048576e+06`,
+		),
 	)
 
 	Describe("Functional correctness", func() {
@@ -131,6 +147,10 @@ func scrapeMetrics(reg *prometheus.Registry, m *metrics.FluentBitGardenerMetrics
 	m.ThrottledLogs.WithLabelValues("http://localhost").Inc()
 	m.BufferedLogs.WithLabelValues("http://localhost").Set(1)
 	m.DqueSize.WithLabelValues("test-queue").Set(42)
+	m.Redactions.WithLabelValues("jwt").Inc()
+	m.DestinationLogs.WithLabelValues("vali", "sent").Inc()
+	m.ActiveEndpoint.WithLabelValues("http://localhost", "http://secondary").Set(1)
+	m.ThrottleLimit.WithLabelValues("http://localhost", "bytes").Set(1 << 20)
 
 	handler := promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
 	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
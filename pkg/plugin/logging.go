@@ -38,6 +38,8 @@ type logging struct {
 	ctx                             context.Context
 	cancel                          context.CancelFunc
 	metrics                         *metrics.FluentBitGardenerMetrics
+	processors                      processorChain
+	multiline                       *multilineAggregator
 }
 
 // NewPlugin returns OutputPlugin output plugin
@@ -55,6 +57,12 @@ func NewPlugin(cfg *config.Config, logger logr.Logger, m *metrics.FluentBitGarde
 		metrics: m,
 	}
 
+	if l.processors, err = newProcessorChain(cfg, m); err != nil {
+		cancel()
+
+		return nil, err
+	}
+
 	// TODO(nickytd): Revisit the decision the dynamic host configuration is required to create the controller.
 	// Consider use of configuration to enable/disable the controller and dynamic host feature independently.
 	if len(cfg.ControllerConfig.DynamicHostPath) > 0 {
@@ -102,6 +110,11 @@ func NewPlugin(cfg *config.Config, logger logr.Logger, m *metrics.FluentBitGarde
 	}
 	l.metrics.Clients.WithLabelValues(targets.Seed.String()).Inc()
 
+	// The aggregator is created last, so that its background flush does not leak when creating the plugin fails
+	if cfg.PluginConfig.Multiline.Enabled() {
+		l.multiline = newMultilineAggregator(cfg.PluginConfig.Multiline, l)
+	}
+
 	logger.Info("logging plugin created",
 		"seed_client_url", redactCredentialsFromEndpoint(l.seedClient.Endpoint()),
 		"seed_queue_name", cfg.OTLPConfig.DQueConfig.DQueName,
@@ -126,6 +139,12 @@ func NewPluginWithController(cfg *config.Config, logger logr.Logger, m *metrics.
 		metrics:    m,
 	}
 
+	if l.processors, err = newProcessorChain(cfg, m); err != nil {
+		cancel()
+
+		return nil, err
+	}
+
 	if len(cfg.ControllerConfig.DynamicHostPath) > 0 {
 		l.dynamicHostRegexp = regexp.MustCompile(cfg.ControllerConfig.DynamicHostRegex)
 	}
@@ -143,6 +162,11 @@ func NewPluginWithController(cfg *config.Config, logger logr.Logger, m *metrics.
 	}
 	l.metrics.Clients.WithLabelValues(targets.Seed.String()).Inc()
 
+	// The aggregator is created last, so that its background flush does not leak when creating the plugin fails
+	if cfg.PluginConfig.Multiline.Enabled() {
+		l.multiline = newMultilineAggregator(cfg.PluginConfig.Multiline, l)
+	}
+
 	logger.Info("logging plugin created with controller",
 		"seed_client_url", redactCredentialsFromEndpoint(l.seedClient.Endpoint()),
 		"seed_queue_name", cfg.OTLPConfig.DQueConfig.DQueName,
@@ -152,17 +176,19 @@ func NewPluginWithController(cfg *config.Config, logger logr.Logger, m *metrics.
 }
 
 // SendRecord sends fluent-bit records to logging as an entry.
+// Records which belong to a fluent-bit log group (opentelemetry envelope) carry
+// their group in the entry, so the clients can keep the original resource and scope.
 //
 // TODO: we receive map[any]any from fluent-bit,
 // we should convert it to corresponding otlp log record
 // with resource attributes reflecting k8s metadata and origin info
-// TODO: it shall also handle otlp log records directly when fluent-bit has otlp envelope enabled
 func (l *logging) SendRecord(log types.OutputEntry) error {
 	record := log.Record
 
 	// Check if metadata is missing // TODO: There is no point to have fallback as a configuration
+	// Records of a log group are identified by their group resource instead of the tag.
 	_, ok := record["kubernetes"]
-	if !ok && l.cfg.PluginConfig.KubernetesMetadata.FallbackToTagWhenMetadataIsMissing {
+	if !ok && log.Group == nil && l.cfg.PluginConfig.KubernetesMetadata.FallbackToTagWhenMetadataIsMissing {
 		// Attempt to extract Kubernetes metadata from the tag
 		if err := extractKubernetesMetadataFromTag(
 			record,
@@ -180,6 +206,40 @@ func (l *logging) SendRecord(log types.OutputEntry) error {
 		}
 	}
 
+	// Continuation lines are joined with the pending record of their stream before any further processing
+	if l.multiline != nil {
+		return l.multiline.Add(log)
+	}
+
+	return l.send(log)
+}
+
+// delivery is an entry which is processed and routed to the client of its destination
+type delivery struct {
+	entry           types.OutputEntry
+	client          api.Output
+	host            string // host label of the metrics
+	dynamicHostName string
+}
+
+// send processes the entry and hands it to the client of its destination
+func (l *logging) send(log types.OutputEntry) error {
+	d := l.prepare(log)
+	if d == nil {
+		return nil
+	}
+
+	return l.deliver(d)
+}
+
+// prepare processes the entry and routes it to the client of its destination.
+// It returns nil if the entry is dropped or there is no client for it.
+func (l *logging) prepare(log types.OutputEntry) *delivery {
+	record := log.Record
+
+	// Processors run before routing, so they may change the fields the dynamic host name is taken from
+	keep := l.processors.Process(record)
+
 	dynamicHostName := getDynamicHostName(record, l.cfg.ControllerConfig.DynamicHostPath)
 	host := dynamicHostName
 	if !l.isDynamicHost(host) {
@@ -188,6 +248,12 @@ func (l *logging) SendRecord(log types.OutputEntry) error {
 
 	l.metrics.IncomingLogs.WithLabelValues(host).Inc()
 
+	if !keep {
+		l.metrics.DroppedLogs.WithLabelValues(host, "processor").Inc()
+
+		return nil
+	}
+
 	if len(record) == 0 {
 		l.logger.Info("no record left after removing keys", "host", dynamicHostName)
 
@@ -209,8 +275,13 @@ func (l *logging) SendRecord(log types.OutputEntry) error {
 		return nil
 	}
 
+	return &delivery{entry: log, client: c, host: host, dynamicHostName: dynamicHostName}
+}
+
+// deliver hands the processed entry to its client, it can be called again if the client failed
+func (l *logging) deliver(d *delivery) error {
 	// Client uses its own lifecycle context
-	err := c.Handle(log)
+	err := d.client.Handle(d.entry)
 	if err == nil {
 		return nil
 	}
@@ -218,13 +289,24 @@ func (l *logging) SendRecord(log types.OutputEntry) error {
 		return err
 	}
 
-	l.logger.Error(err, "error sending record to logging", "host", dynamicHostName)
+	l.logger.Error(err, "error sending record to logging", "host", d.dynamicHostName)
 	l.metrics.Errors.WithLabelValues(metrics.ErrorSendRecord).Inc()
 
 	return err
 }
 
+// drop counts an entry which could not be delivered and is given up
+func (l *logging) drop(d *delivery, err error) {
+	l.logger.Error(err, "dropping multiline record which could not be delivered", "host", d.dynamicHostName)
+	l.metrics.DroppedLogs.WithLabelValues(d.host, "multiline").Inc()
+}
+
 func (l *logging) Close() {
+	// Flush pending multiline records before the clients are stopped
+	if l.multiline != nil {
+		l.multiline.Close()
+	}
+
 	// Cancel the plugin context first to signal all operations to stop
 	l.cancel()
 
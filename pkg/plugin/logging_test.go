@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"sync"
 	"time"
 
@@ -304,6 +305,65 @@ var _ = Describe("OutputPlugin plugin", func() {
 					return promtest.ToFloat64(testMetrics.LogsWithoutMetadata.WithLabelValues(metrics.MissingMetadataType))
 				}, "5s", "100ms").Should(BeNumerically(">", 0))
 			})
+
+			It("should not drop records of a log group without kubernetes metadata", func() {
+				cfg.PluginConfig.KubernetesMetadata.FallbackToTagWhenMetadataIsMissing = true
+				cfg.PluginConfig.KubernetesMetadata.DropLogEntryWithoutK8sMetadata = true
+				plugin.Close()
+				var err error
+				plugin, err = NewPlugin(cfg, logger, testMetrics, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				entry := types.OutputEntry{
+					Timestamp: time.Now(),
+					Record: map[string]any{
+						"log": "test",
+					},
+					Group: &types.LogGroup{
+						ResourceAttributes: map[string]any{"service.name": "test-service"},
+					},
+				}
+
+				err = plugin.SendRecord(entry)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(promtest.ToFloat64(testMetrics.LogsWithoutMetadata.WithLabelValues(metrics.MissingMetadataType))).To(BeZero())
+				Expect(promtest.ToFloat64(testMetrics.IncomingLogs.WithLabelValues("garden"))).To(BeNumerically("==", 1))
+			})
+		})
+
+		Context("record processors", func() {
+			BeforeEach(func() {
+				cfg.PluginConfig.Processors = []config.ProcessorConfig{
+					{Type: config.ProcessorDrop, Field: "log", Regex: regexp.MustCompile("healthz")},
+					{Type: config.ProcessorRemove, Fields: []string{"stream"}},
+				}
+				plugin.Close()
+				var err error
+				plugin, err = NewPlugin(cfg, logger, testMetrics, nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should drop records rejected by a processor", func() {
+				err := plugin.SendRecord(types.OutputEntry{
+					Timestamp: time.Now(),
+					Record:    map[string]any{"log": "GET /healthz 200"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(promtest.ToFloat64(testMetrics.IncomingLogs.WithLabelValues("garden"))).To(BeNumerically("==", 1))
+				Expect(promtest.ToFloat64(testMetrics.DroppedLogs.WithLabelValues("garden", "processor"))).To(BeNumerically("==", 1))
+			})
+
+			It("should send records transformed by the processors", func() {
+				record := map[string]any{"log": "GET /metrics 200", "stream": "stdout"}
+
+				err := plugin.SendRecord(types.OutputEntry{Timestamp: time.Now(), Record: record})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(record).NotTo(HaveKey("stream"))
+				Expect(promtest.ToFloat64(testMetrics.DroppedLogs.WithLabelValues("garden", "processor"))).To(BeZero())
+			})
 		})
 
 		Context("metrics verification", func() {
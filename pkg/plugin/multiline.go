@@ -0,0 +1,305 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin // nolint:revive // var-naming the plugin package is the main entry point
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+// multilineBodyKeys are the record fields holding the log line, checked in order
+var multilineBodyKeys = []string{"log", "message"}
+
+// multilineSink processes, routes and delivers the records of the multiline aggregator
+type multilineSink interface {
+	// prepare processes and routes the entry, it returns nil if the entry is dropped or there is no client for it
+	prepare(types.OutputEntry) *delivery
+	// deliver hands the prepared entry to its client
+	deliver(*delivery) error
+	// drop counts a prepared entry which is given up after it could not be delivered
+	drop(*delivery, error)
+}
+
+// multilineAggregator joins continuation lines, e.g. of stack traces, with the preceding record of the same
+// container stream. The last record of each stream is kept pending until a line arrives which does not continue it,
+// the size limits are reached or the flush timeout expires.
+type multilineAggregator struct {
+	cfg  config.MultilineConfig
+	sink multilineSink
+
+	mu      sync.Mutex
+	pending map[string]*pendingRecord
+	retries []failedDelivery
+	closed  bool
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// pendingRecord is a record waiting for continuation lines
+type pendingRecord struct {
+	entry   types.OutputEntry
+	bodyKey string
+	lines   []string
+	size    int
+	updated time.Time
+	rules   []config.MultilineRule // rules applying to the first line
+}
+
+// failedDelivery is a flushed record which could not be delivered and is retried in the background
+type failedDelivery struct {
+	delivery *delivery
+	err      error
+}
+
+// newMultilineAggregator creates an aggregator handing joined records to the sink.
+// Pending records are flushed and failed deliveries retried in the background.
+func newMultilineAggregator(cfg config.MultilineConfig, sink multilineSink) *multilineAggregator {
+	a := &multilineAggregator{
+		cfg:     cfg,
+		sink:    sink,
+		pending: make(map[string]*pendingRecord),
+		stopCh:  make(chan struct{}),
+	}
+
+	a.wg.Add(1)
+	go a.flushLoop()
+
+	return a
+}
+
+// Add aggregates the entry with the pending record of its stream.
+// Only errors of entries delivered directly are returned, flushed pending records which cannot be delivered are retried
+// in the background, since fluent-bit already acknowledged the chunks they arrived with.
+// The sink is called without holding the lock, so that a slow client does not block the other streams.
+func (a *multilineAggregator) Add(entry types.OutputEntry) error {
+	key := streamKey(entry.Record)
+	bodyKey, line, ok := multilineBody(entry.Record)
+
+	flushed, direct := a.add(key, bodyKey, line, ok, entry)
+	if flushed != nil {
+		a.emit(flushed.record())
+	}
+	if !direct {
+		return nil
+	}
+
+	d := a.sink.prepare(entry)
+	if d == nil {
+		return nil
+	}
+
+	return a.sink.deliver(d)
+}
+
+// add aggregates the entry under the lock. It returns the pending record to flush and whether the entry has to be
+// delivered directly.
+func (a *multilineAggregator) add(key, bodyKey, line string, ok bool, entry types.OutputEntry) (*pendingRecord, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	p := a.pending[key]
+
+	// Records of log groups and records without a textual log line are not aggregated
+	if !ok || entry.Group != nil || a.closed {
+		if p != nil {
+			delete(a.pending, key)
+		}
+
+		return p, true
+	}
+
+	if p != nil {
+		if p.continues(line) && len(p.lines) < a.cfg.MaxLines && p.size+1+len(line) <= a.cfg.MaxBytes {
+			p.lines = append(p.lines, line)
+			p.size += 1 + len(line)
+			p.updated = time.Now()
+
+			return nil, false
+		}
+		delete(a.pending, key)
+	}
+
+	// Bound the memory by passing on records of further streams without aggregation
+	if len(a.pending) >= a.cfg.MaxStreams || len(line) >= a.cfg.MaxBytes {
+		return p, true
+	}
+
+	a.pending[key] = &pendingRecord{
+		entry:   entry,
+		bodyKey: bodyKey,
+		lines:   []string{line},
+		size:    len(line),
+		updated: time.Now(),
+		rules:   a.rules(line),
+	}
+
+	return p, false
+}
+
+// Close stops the background flush, flushes all pending records and retries the failed deliveries once.
+// Records which still cannot be delivered are dropped. Records added afterwards are delivered without aggregation.
+func (a *multilineAggregator) Close() {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+
+		return
+	}
+	a.closed = true
+	a.mu.Unlock()
+
+	close(a.stopCh)
+	a.wg.Wait()
+
+	a.flush(func(*pendingRecord) bool { return true })
+	a.retry()
+}
+
+func (a *multilineAggregator) flushLoop() {
+	defer a.wg.Done()
+
+	ticker := time.NewTicker(max(a.cfg.FlushTimeout/2, 10*time.Millisecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.retry()
+			deadline := time.Now().Add(-a.cfg.FlushTimeout)
+			a.flush(func(p *pendingRecord) bool { return !p.updated.After(deadline) })
+		case <-a.stopCh:
+			return
+		}
+	}
+}
+
+// flush emits the pending records selected by the filter
+func (a *multilineAggregator) flush(selected func(*pendingRecord) bool) {
+	var flushed []*pendingRecord
+
+	a.mu.Lock()
+	for key, p := range a.pending {
+		if !selected(p) {
+			continue
+		}
+		delete(a.pending, key)
+		flushed = append(flushed, p)
+	}
+	a.mu.Unlock()
+
+	for _, p := range flushed {
+		a.emit(p.record())
+	}
+}
+
+// emit delivers a flushed record and queues it for retry if the delivery fails
+func (a *multilineAggregator) emit(entry types.OutputEntry) {
+	d := a.sink.prepare(entry)
+	if d == nil {
+		return
+	}
+	if err := a.sink.deliver(d); err != nil {
+		a.requeue(nil, failedDelivery{delivery: d, err: err})
+	}
+}
+
+// retry delivers the failed records in order and stops at the first one failing again
+func (a *multilineAggregator) retry() {
+	a.mu.Lock()
+	retries := a.retries
+	a.retries = nil
+	a.mu.Unlock()
+
+	for i, r := range retries {
+		if err := a.sink.deliver(r.delivery); err != nil {
+			retries[i].err = err
+			a.requeue(retries[i:])
+
+			return
+		}
+	}
+}
+
+// requeue queues the retried deliveries ahead and the newly failed ones behind the queued deliveries.
+// The oldest deliveries are dropped when the queue exceeds MaxStreams records, all of them once the aggregator is closed.
+func (a *multilineAggregator) requeue(retried []failedDelivery, failed ...failedDelivery) {
+	a.mu.Lock()
+	retries := append(append(retried, a.retries...), failed...)
+	var dropped []failedDelivery
+	if a.closed {
+		dropped, retries = retries, nil
+	} else if n := len(retries) - a.cfg.MaxStreams; n > 0 {
+		dropped, retries = retries[:n], retries[n:]
+	}
+	a.retries = retries
+	a.mu.Unlock()
+
+	for _, r := range dropped {
+		a.sink.drop(r.delivery, r.err)
+	}
+}
+
+// rules returns the rules applying to records starting with the line
+func (a *multilineAggregator) rules(line string) []config.MultilineRule {
+	var rules []config.MultilineRule
+	for _, rule := range a.cfg.Rules {
+		if rule.Start == nil || rule.Start.MatchString(line) {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+func (p *pendingRecord) continues(line string) bool {
+	for _, rule := range p.rules {
+		if rule.Continuation.MatchString(line) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// record returns the entry of the pending record with the joined log lines
+func (p *pendingRecord) record() types.OutputEntry {
+	if len(p.lines) > 1 {
+		p.entry.Record[p.bodyKey] = strings.Join(p.lines, "\n")
+	}
+
+	return p.entry
+}
+
+// multilineBody returns the field and the log line of the record without the trailing line break
+func multilineBody(record map[string]any) (string, string, bool) {
+	for _, key := range multilineBodyKeys {
+		switch v := record[key].(type) {
+		case string:
+			return key, strings.TrimRight(v, "\r\n"), true
+		case []byte:
+			return key, strings.TrimRight(string(v), "\r\n"), true
+		default:
+		}
+	}
+
+	return "", "", false
+}
+
+// streamKey identifies the container stream of the record by namespace, pod, container and stream name
+func streamKey(record map[string]any) string {
+	var namespace, pod, container string
+	if k8s, ok := record["kubernetes"].(map[string]any); ok {
+		namespace, _ = k8s[namespaceName].(string)
+		pod, _ = k8s[podName].(string)
+		container, _ = k8s[containerName].(string)
+	}
+	stream, _ := record["stream"].(string)
+
+	return namespace + "/" + pod + "/" + container + "/" + stream
+}
@@ -0,0 +1,341 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/types"
+)
+
+// fakeMultilineSink records the delivered entries and fails while err is set
+type fakeMultilineSink struct {
+	mu      sync.Mutex
+	emitted []types.OutputEntry
+	dropped []types.OutputEntry
+	err     error
+}
+
+func (s *fakeMultilineSink) prepare(entry types.OutputEntry) *delivery {
+	return &delivery{entry: entry}
+}
+
+func (s *fakeMultilineSink) deliver(d *delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.emitted = append(s.emitted, d.entry)
+
+	return nil
+}
+
+func (s *fakeMultilineSink) drop(d *delivery, _ error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropped = append(s.dropped, d.entry)
+}
+
+func (s *fakeMultilineSink) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+var _ = Describe("Multiline aggregator", func() {
+	var (
+		sink       *fakeMultilineSink
+		aggregator *multilineAggregator
+	)
+
+	logs := func(entries []types.OutputEntry) []any {
+		result := make([]any, 0, len(entries))
+		for _, e := range entries {
+			result = append(result, e.Record["log"])
+		}
+
+		return result
+	}
+
+	emittedLogs := func() []any {
+		sink.mu.Lock()
+		defer sink.mu.Unlock()
+
+		return logs(sink.emitted)
+	}
+
+	droppedLogs := func() []any {
+		sink.mu.Lock()
+		defer sink.mu.Unlock()
+
+		return logs(sink.dropped)
+	}
+
+	newAggregator := func(configMap map[string]any) *multilineAggregator {
+		cfg, err := config.ParseConfig(configMap)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.PluginConfig.Multiline.Enabled()).To(BeTrue())
+
+		return newMultilineAggregator(cfg.PluginConfig.Multiline, sink)
+	}
+
+	entry := func(pod, log string) types.OutputEntry {
+		return types.OutputEntry{
+			Timestamp: time.Now(),
+			Record: map[string]any{
+				"log":    log,
+				"stream": "stderr",
+				"kubernetes": map[string]any{
+					"namespace_name": "garden",
+					"pod_name":       pod,
+					"container_name": "app",
+				},
+			},
+		}
+	}
+
+	add := func(pod string, lines ...string) {
+		for _, line := range lines {
+			Expect(aggregator.Add(entry(pod, line))).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		sink = &fakeMultilineSink{}
+		aggregator = newAggregator(map[string]any{"MultilinePresets": "go,java,python", "MultilineFlushTimeout": "1h"})
+	})
+
+	AfterEach(func() {
+		aggregator.Close()
+	})
+
+	It("should join java stack traces", func() {
+		add("app-0",
+			"2024-01-02 15:04:05 ERROR request failed\n",
+			"java.lang.IllegalStateException: boom\n",
+			"\tat com.example.Main.main(Main.java:10)\n",
+			"Caused by: java.io.IOException: closed\n",
+			"\t... 3 more\n",
+			"2024-01-02 15:04:06 INFO next request\n",
+		)
+
+		Expect(emittedLogs()).To(Equal([]any{
+			"2024-01-02 15:04:05 ERROR request failed\n" +
+				"java.lang.IllegalStateException: boom\n" +
+				"\tat com.example.Main.main(Main.java:10)\n" +
+				"Caused by: java.io.IOException: closed\n" +
+				"\t... 3 more",
+		}))
+	})
+
+	It("should join go panics", func() {
+		add("app-0",
+			"panic: runtime error: index out of range [5] with length 3",
+			"",
+			"goroutine 1 [running]:",
+			"main.main()",
+			"\t/src/main.go:8 +0x1d",
+			"exit status 2",
+		)
+
+		Expect(emittedLogs()).To(Equal([]any{
+			"panic: runtime error: index out of range [5] with length 3\n\ngoroutine 1 [running]:\nmain.main()\n\t/src/main.go:8 +0x1d",
+		}))
+	})
+
+	It("should start a new record with go panic and goroutine headers", func() {
+		add("app-0",
+			"starting worker",
+			"panic: boom",
+			"",
+			"goroutine 7 [running]:",
+			"main.work(...)",
+			"\t/src/main.go:12 +0x1d",
+			"created by main.main in goroutine 1",
+			"\t/src/main.go:20 +0x2e",
+			"calling handler.Serve(ctx)",
+			"fatal error: all goroutines are asleep - deadlock!",
+			"next",
+		)
+
+		Expect(emittedLogs()).To(Equal([]any{
+			"starting worker",
+			"panic: boom\n\ngoroutine 7 [running]:\nmain.work(...)\n\t/src/main.go:12 +0x1d\ncreated by main.main in goroutine 1\n\t/src/main.go:20 +0x2e",
+			"calling handler.Serve(ctx)",
+			"fatal error: all goroutines are asleep - deadlock!",
+		}))
+	})
+
+	It("should join only indented go frames with ordinary lines", func() {
+		add("app-0",
+			"request failed",
+			"\t/src/main.go:12 +0x1d",
+			"main.handle(0x1)",
+			"goroutine 1 [running]:",
+		)
+
+		Expect(emittedLogs()).To(Equal([]any{"request failed\n\t/src/main.go:12 +0x1d", "main.handle(0x1)"}))
+	})
+
+	It("should join python tracebacks", func() {
+		add("app-0",
+			"ERROR:root:failed",
+			"Traceback (most recent call last):",
+			`  File "main.py", line 1, in <module>`,
+			"ValueError: boom",
+			"INFO:root:done",
+		)
+
+		Expect(emittedLogs()).To(Equal([]any{
+			"ERROR:root:failed\nTraceback (most recent call last):\n  File \"main.py\", line 1, in <module>\nValueError: boom",
+		}))
+	})
+
+	It("should keep the fields of the first record and single lines unchanged", func() {
+		add("app-0", "first\n", "second\n")
+
+		Expect(sink.emitted).To(HaveLen(1))
+		Expect(sink.emitted[0].Record).To(HaveKeyWithValue("log", "first\n"))
+		Expect(sink.emitted[0].Record).To(HaveKeyWithValue("stream", "stderr"))
+	})
+
+	It("should aggregate streams separately", func() {
+		add("app-0", "error in app-0")
+		add("app-1", "error in app-1")
+		add("app-0", "\tat app-0")
+		add("app-1", "\tat app-1")
+		aggregator.Close()
+
+		Expect(emittedLogs()).To(ConsistOf("error in app-0\n\tat app-0", "error in app-1\n\tat app-1"))
+	})
+
+	It("should flush pending records after the flush timeout", func() {
+		aggregator.Close()
+		aggregator = newAggregator(map[string]any{"MultilinePresets": "java", "MultilineFlushTimeout": "50ms"})
+
+		add("app-0", "request failed", "\tat com.example.Main.main(Main.java:10)")
+		Expect(emittedLogs()).To(BeEmpty())
+
+		Eventually(emittedLogs, "2s", "10ms").Should(Equal([]any{"request failed\n\tat com.example.Main.main(Main.java:10)"}))
+	})
+
+	It("should bound the number of joined lines", func() {
+		aggregator.Close()
+		aggregator = newAggregator(map[string]any{"MultilinePresets": "java", "MultilineMaxLines": "2", "MultilineFlushTimeout": "1h"})
+
+		add("app-0", "request failed", "\tat a", "\tat b", "\tat c", "next")
+
+		Expect(emittedLogs()).To(Equal([]any{"request failed\n\tat a", "\tat b\n\tat c"}))
+	})
+
+	It("should pass on records of further streams without aggregation", func() {
+		aggregator.Close()
+		aggregator = newAggregator(map[string]any{"MultilinePresets": "java", "MultilineMaxStreams": "1", "MultilineFlushTimeout": "1h"})
+
+		add("app-0", "pending")
+		add("app-1", "passed on")
+
+		Expect(emittedLogs()).To(Equal([]any{"passed on"}))
+	})
+
+	It("should pass on records of log groups after flushing the pending record", func() {
+		add("app-0", "pending")
+
+		grouped := entry("app-0", "\tat grouped")
+		grouped.Group = &types.LogGroup{}
+		Expect(aggregator.Add(grouped)).To(Succeed())
+
+		Expect(emittedLogs()).To(Equal([]any{"pending", "\tat grouped"}))
+	})
+
+	It("should flush pending records on close and pass on later records", func() {
+		add("app-0", "pending")
+		aggregator.Close()
+		Expect(emittedLogs()).To(Equal([]any{"pending"}))
+
+		add("app-0", "after close")
+		Expect(emittedLogs()).To(Equal([]any{"pending", "after close"}))
+	})
+
+	It("should retry flushed records which cannot be delivered", func() {
+		aggregator.Close()
+		aggregator = newAggregator(map[string]any{"MultilinePresets": "java", "MultilineFlushTimeout": "50ms"})
+
+		sink.fail(errors.New("unavailable"))
+		add("app-0", "request failed", "\tat com.example.Main.main(Main.java:10)")
+		Consistently(emittedLogs, "200ms", "10ms").Should(BeEmpty())
+
+		// Only errors of entries delivered directly are returned
+		grouped := entry("app-1", "grouped")
+		grouped.Group = &types.LogGroup{}
+		Expect(aggregator.Add(grouped)).To(MatchError("unavailable"))
+
+		sink.fail(nil)
+		Eventually(emittedLogs, "2s", "10ms").Should(Equal([]any{"request failed\n\tat com.example.Main.main(Main.java:10)"}))
+		Expect(droppedLogs()).To(BeEmpty())
+	})
+
+	It("should drop the oldest failed records when the retry queue is full", func() {
+		aggregator.Close()
+		aggregator = newAggregator(map[string]any{"MultilinePresets": "java", "MultilineMaxStreams": "1", "MultilineFlushTimeout": "1h"})
+
+		sink.fail(errors.New("unavailable"))
+		add("app-0", "first", "second", "third")
+
+		Expect(droppedLogs()).To(Equal([]any{"first"}))
+	})
+
+	It("should drop failed records on close", func() {
+		sink.fail(errors.New("unavailable"))
+		add("app-0", "pending")
+		aggregator.Close()
+
+		Expect(emittedLogs()).To(BeEmpty())
+		Expect(droppedLogs()).To(Equal([]any{"pending"}))
+	})
+
+	It("should not hold the lock while delivering", func() {
+		blocking := &blockingMultilineSink{fakeMultilineSink: sink, release: make(chan struct{})}
+		aggregator.Close()
+		cfg, err := config.ParseConfig(map[string]any{"MultilinePresets": "java", "MultilineFlushTimeout": "1h"})
+		Expect(err).NotTo(HaveOccurred())
+		aggregator = newMultilineAggregator(cfg.PluginConfig.Multiline, blocking)
+
+		add("app-0", "pending")
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_ = aggregator.Add(entry("app-0", "blocks"))
+		}()
+		Eventually(blocking.blocked.Load).Should(BeTrue())
+
+		// Other streams are aggregated while the delivery is blocked
+		Expect(aggregator.Add(entry("app-1", "other"))).To(Succeed())
+
+		close(blocking.release)
+		Eventually(done).Should(BeClosed())
+	})
+})
+
+// blockingMultilineSink blocks deliveries until released
+type blockingMultilineSink struct {
+	*fakeMultilineSink
+	release chan struct{}
+	blocked atomic.Bool
+}
+
+func (s *blockingMultilineSink) deliver(d *delivery) error {
+	s.blocked.Store(true)
+	<-s.release
+
+	return s.fakeMultilineSink.deliver(d)
+}
@@ -0,0 +1,268 @@
+// Copyright 2025 SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin // nolint:revive // var-naming the plugin package is the main entry point
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	otlplog "go.opentelemetry.io/otel/log"
+
+	"github.com/gardener/logging/v1/pkg/client/otlp"
+	"github.com/gardener/logging/v1/pkg/config"
+	"github.com/gardener/logging/v1/pkg/metrics"
+)
+
+// Processor transforms records before they are routed to a client
+type Processor interface {
+	// Process transforms the record in place and reports whether the record is kept
+	Process(record map[string]any) bool
+}
+
+// processorChain applies processors in order, stopping at the first processor dropping the record
+type processorChain []Processor
+
+// Process applies the processors of the chain and reports whether the record is kept
+func (c processorChain) Process(record map[string]any) bool {
+	for _, p := range c {
+		if !p.Process(record) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newProcessorChain creates the processors configured in the plugin configuration
+func newProcessorChain(cfg *config.Config, m *metrics.FluentBitGardenerMetrics) (processorChain, error) {
+	chain := make(processorChain, 0, len(cfg.PluginConfig.Processors))
+	for _, pc := range cfg.PluginConfig.Processors {
+		switch pc.Type {
+		case config.ProcessorDrop:
+			chain = append(chain, &dropProcessor{field: pc.Field, regex: pc.Regex})
+		case config.ProcessorRename:
+			chain = append(chain, &renameProcessor{field: pc.Field, to: pc.To, overwrite: pc.Overwrite})
+		case config.ProcessorRemove:
+			chain = append(chain, &removeProcessor{fields: pc.Fields})
+		case config.ProcessorAdd:
+			chain = append(chain, &addProcessor{field: pc.Field, value: pc.Value, overwrite: pc.Overwrite})
+		case config.ProcessorSeverityFloor:
+			chain = append(chain, &severityFloorProcessor{
+				floor: otlplog.Severity(pc.Severity),
+				rules: cfg.OTLPConfig.SeverityConfig,
+			})
+		case config.ProcessorRedact:
+			chain = append(chain, &redactProcessor{redactions: pc.Redactions, replacement: pc.Replacement, metrics: m})
+		default:
+			return nil, fmt.Errorf("unknown processor type %q", pc.Type)
+		}
+	}
+
+	return chain, nil
+}
+
+// dropProcessor drops records whose field value matches the regular expression
+type dropProcessor struct {
+	field string
+	regex *regexp.Regexp
+}
+
+func (p *dropProcessor) Process(record map[string]any) bool {
+	m, key := resolveField(record, p.field, false)
+	value, ok := m[key]
+	if !ok {
+		return true
+	}
+
+	switch v := value.(type) {
+	case string:
+		return !p.regex.MatchString(v)
+	case []byte:
+		return !p.regex.Match(v)
+	default:
+		return !p.regex.MatchString(fmt.Sprintf("%v", v))
+	}
+}
+
+// renameProcessor renames a field, keeping an existing field with the new name unless overwrite is set
+type renameProcessor struct {
+	field     string
+	to        string
+	overwrite bool
+}
+
+func (p *renameProcessor) Process(record map[string]any) bool {
+	m, key := resolveField(record, p.field, false)
+	value, ok := m[key]
+	if !ok {
+		return true
+	}
+
+	target, targetKey := resolveField(record, p.to, true)
+	if _, exists := target[targetKey]; exists && !p.overwrite {
+		return true
+	}
+	delete(m, key)
+	target[targetKey] = value
+
+	return true
+}
+
+// removeProcessor removes fields
+type removeProcessor struct {
+	fields []string
+}
+
+func (p *removeProcessor) Process(record map[string]any) bool {
+	for _, field := range p.fields {
+		m, key := resolveField(record, field, false)
+		delete(m, key)
+	}
+
+	return true
+}
+
+// addProcessor adds a field with a static value, keeping an existing field unless overwrite is set
+type addProcessor struct {
+	field     string
+	value     any
+	overwrite bool
+}
+
+func (p *addProcessor) Process(record map[string]any) bool {
+	m, key := resolveField(record, p.field, true)
+	if _, exists := m[key]; exists && !p.overwrite {
+		return true
+	}
+	m[key] = p.value
+
+	return true
+}
+
+// severityFloorProcessor drops records with a severity below the floor.
+// The severity is mapped with the configured severity rules, records without a level are treated as info.
+type severityFloorProcessor struct {
+	floor otlplog.Severity
+	rules config.SeverityConfig
+}
+
+func (p *severityFloorProcessor) Process(record map[string]any) bool {
+	severity, _ := otlp.MapSeverity(record, p.rules)
+
+	return severity >= p.floor
+}
+
+// redactProcessor replaces secrets in all string values of the record, including nested maps and arrays
+type redactProcessor struct {
+	redactions  []config.Redaction
+	replacement string
+	metrics     *metrics.FluentBitGardenerMetrics
+}
+
+func (p *redactProcessor) Process(record map[string]any) bool {
+	for k, v := range record {
+		if redacted, ok := p.redactValue(v); ok {
+			record[k] = redacted
+		}
+	}
+
+	return true
+}
+
+// redactValue returns the value with secrets replaced and whether a secret was found.
+// Maps and arrays are redacted in place.
+func (p *redactProcessor) redactValue(value any) (any, bool) {
+	switch v := value.(type) {
+	case string:
+		return p.redactString(v)
+	case []byte:
+		if s, ok := p.redactString(string(v)); ok {
+			return s, true
+		}
+	case map[string]any:
+		p.Process(v)
+	case []any:
+		for i, item := range v {
+			if redacted, ok := p.redactValue(item); ok {
+				v[i] = redacted
+			}
+		}
+	default:
+	}
+
+	return value, false
+}
+
+func (p *redactProcessor) redactString(s string) (string, bool) {
+	found := false
+	for _, r := range p.redactions {
+		redacted, n := redact(s, r.Pattern, p.replacement)
+		if n == 0 {
+			continue
+		}
+		s, found = redacted, true
+		if p.metrics != nil {
+			p.metrics.Redactions.WithLabelValues(r.Detector).Add(float64(n))
+		}
+	}
+
+	return s, found
+}
+
+// redact replaces the matches of the pattern in s, or only their "secret" capture group if the pattern has one.
+// It returns the redacted string and the number of replaced matches.
+func redact(s string, pattern *regexp.Regexp, replacement string) (string, int) {
+	matches := pattern.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s, 0
+	}
+
+	group := pattern.SubexpIndex("secret")
+
+	var b strings.Builder
+	b.Grow(len(s))
+	last := 0
+	for _, match := range matches {
+		start, end := match[0], match[1]
+		if group > 0 && match[2*group] >= 0 {
+			start, end = match[2*group], match[2*group+1]
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(replacement)
+		last = end
+	}
+	b.WriteString(s[last:])
+
+	return b.String(), len(matches)
+}
+
+// resolveField returns the map holding the field addressed by path and the key of the field within it.
+// Path segments separated by "." descend into nested maps. Keys containing dots, e.g. label names,
+// are matched as a whole if they exist. Missing fields resolve to the deepest existing map,
+// unless create is set, then the missing parent maps are created.
+func resolveField(record map[string]any, path string, create bool) (map[string]any, string) {
+	m := record
+	for {
+		if _, ok := m[path]; ok {
+			return m, path
+		}
+
+		head, rest, found := strings.Cut(path, ".")
+		if !found {
+			return m, path
+		}
+
+		value, exists := m[head]
+		next, ok := value.(map[string]any)
+		if !ok {
+			if exists || !create {
+				return m, path
+			}
+			next = make(map[string]any)
+			m[head] = next
+		}
+		m, path = next, rest
+	}
+}
@@ -21,6 +21,18 @@ const (
 	OTLPGRPC
 	// OTLPHTTP represents an OTLP HTTP client type
 	OTLPHTTP
+	// FILE represents a file client type
+	FILE
+	// LOKI represents a Loki/Vali push API client type
+	LOKI
+	// OPENSEARCH represents an Elasticsearch/OpenSearch bulk API client type
+	OPENSEARCH
+	// KAFKA represents a Kafka producer client type
+	KAFKA
+	// SYSLOG represents an RFC 5424 syslog client type
+	SYSLOG
+	// MULTI represents a client fanning out to several destination clients
+	MULTI
 	// Unknown represents an unknown client type
 	Unknown
 )
@@ -36,6 +48,18 @@ func ClientTypeFromString(clientType string) Type {
 		return OTLPGRPC
 	case "OTLPHTTP", "OTLP_HTTP":
 		return OTLPHTTP
+	case "FILE":
+		return FILE
+	case "LOKI", "VALI":
+		return LOKI
+	case "OPENSEARCH", "ELASTICSEARCH":
+		return OPENSEARCH
+	case "KAFKA":
+		return KAFKA
+	case "SYSLOG":
+		return SYSLOG
+	case "MULTI":
+		return MULTI
 	default:
 		return NOOP
 	}
@@ -52,6 +76,18 @@ func (t Type) String() string {
 		return "otlp_grpc"
 	case OTLPHTTP:
 		return "otlp_http"
+	case FILE:
+		return "file"
+	case LOKI:
+		return "loki"
+	case OPENSEARCH:
+		return "opensearch"
+	case KAFKA:
+		return "kafka"
+	case SYSLOG:
+		return "syslog"
+	case MULTI:
+		return "multi"
 	case Unknown:
 		return "unknown"
 	default:
@@ -63,4 +99,17 @@ func (t Type) String() string {
 type OutputEntry struct {
 	Timestamp time.Time
 	Record    map[string]any
+	// Group is set when the record belongs to a fluent-bit log group,
+	// e.g. logs received by the opentelemetry input or wrapped by the opentelemetry-envelope processor.
+	Group *LogGroup
+}
+
+// LogGroup holds the resource and instrumentation scope shared by all records of a fluent-bit log group
+type LogGroup struct {
+	ResourceAttributes map[string]any `json:"resource_attributes,omitempty"`
+	ResourceSchemaURL  string         `json:"resource_schema_url,omitempty"`
+	ScopeName          string         `json:"scope_name,omitempty"`
+	ScopeVersion       string         `json:"scope_version,omitempty"`
+	ScopeAttributes    map[string]any `json:"scope_attributes,omitempty"`
+	ScopeSchemaURL     string         `json:"scope_schema_url,omitempty"`
 }